@@ -0,0 +1,17 @@
+# The data source finds an available CIDR without persisting it, so the
+# result will change whenever the inputs change
+data "utility_available_cidr" "example" {
+  from_cidrs = ["10.0.0.0/16"]
+  used_cidrs = ["10.0.0.0/20", "10.0.16.0/24"]
+  mask       = 24
+}
+
+# value will be "10.0.17.0/24"
+output "cidr" {
+  value = data.utility_available_cidr.example.result
+}
+
+# found is false once the from_cidrs are full, rather than failing the plan
+output "needs_new_network" {
+  value = !data.utility_available_cidr.example.found
+}
@@ -0,0 +1,8 @@
+data "utility_cidr_aggregate" "example" {
+  cidrs = ["10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/23", "10.0.8.0/24"]
+}
+
+# value will be ["10.0.0.0/22", "10.0.8.0/24"]
+output "aggregated" {
+  value = data.utility_cidr_aggregate.example.aggregated
+}
@@ -0,0 +1,9 @@
+data "utility_cidr_contains" "example" {
+  outer = "10.0.0.0/8"
+  inner = "10.1.2.0/24"
+}
+
+# value will be true
+output "contained" {
+  value = data.utility_cidr_contains.example.contained
+}
@@ -0,0 +1,10 @@
+# Report the space left over in a VPC after its subnets
+data "utility_cidr_diff" "example" {
+  from = "10.0.0.0/16"
+  used = ["10.0.0.0/20", "10.0.16.0/24"]
+}
+
+# value will be ["10.0.17.0/24", "10.0.18.0/23", "10.0.20.0/22", "10.0.24.0/21", "10.0.32.0/19", "10.0.64.0/18", "10.0.128.0/17"]
+output "free" {
+  value = data.utility_cidr_diff.example.free
+}
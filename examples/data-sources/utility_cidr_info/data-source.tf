@@ -0,0 +1,13 @@
+data "utility_cidr_info" "example" {
+  cidr = "10.0.0.0/16"
+}
+
+# value will be "10.0.255.255"
+output "broadcast_address" {
+  value = data.utility_cidr_info.example.broadcast_address
+}
+
+# value will be 65536
+output "host_count" {
+  value = data.utility_cidr_info.example.host_count
+}
@@ -0,0 +1,16 @@
+# Check whether a range picked by hand is still free
+data "utility_cidr_is_available" "example" {
+  candidate  = "10.0.5.0/24"
+  from_cidrs = ["10.0.0.0/16"]
+  used_cidrs = ["10.0.4.0/24", "10.0.5.128/25"]
+}
+
+# value will be false
+output "available" {
+  value = data.utility_cidr_is_available.example.available
+}
+
+# value will be "10.0.5.128/25"
+output "conflicting_cidr" {
+  value = data.utility_cidr_is_available.example.conflicting_cidr
+}
@@ -0,0 +1,8 @@
+data "utility_cidr_overlap" "example" {
+  cidrs = ["10.0.0.0/16", "10.1.0.0/16", "10.0.128.0/20"]
+}
+
+# value will be [["10.0.0.0/16", "10.0.128.0/20"]]
+output "overlaps" {
+  value = data.utility_cidr_overlap.example.overlaps
+}
@@ -0,0 +1,10 @@
+# Split a VPC into four equally sized subnets
+data "utility_cidr_subnets" "example" {
+  cidr     = "10.0.0.0/16"
+  new_bits = 2
+}
+
+# value will be ["10.0.0.0/18", "10.0.64.0/18", "10.0.128.0/18", "10.0.192.0/18"]
+output "subnets" {
+  value = data.utility_cidr_subnets.example.subnets
+}
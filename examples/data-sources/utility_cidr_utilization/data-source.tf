@@ -0,0 +1,15 @@
+# Track how full a VPC is getting
+data "utility_cidr_utilization" "example" {
+  from_cidrs = ["10.0.0.0/16"]
+  used_cidrs = ["10.0.0.0/20", "10.0.16.0/24"]
+}
+
+# value will be 6.640625
+output "utilization_percent" {
+  value = data.utility_cidr_utilization.example.utilization_percent
+}
+
+# value will be "10.0.128.0/17"
+output "largest_free_block" {
+  value = data.utility_cidr_utilization.example.largest_free_block
+}
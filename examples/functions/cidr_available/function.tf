@@ -0,0 +1,6 @@
+# The function finds an available CIDR without persisting it, so the
+# result will change whenever the arguments change
+locals {
+  # value will be "10.0.17.0/24"
+  cidr = provider::utility::cidr_available(["10.0.0.0/16"], ["10.0.0.0/20", "10.0.16.0/24"], 24)
+}
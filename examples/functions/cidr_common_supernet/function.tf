@@ -0,0 +1,4 @@
+locals {
+  # value will be "10.0.0.0/23"
+  supernet = provider::utility::cidr_common_supernet("10.0.0.0/24", "10.0.1.0/24")
+}
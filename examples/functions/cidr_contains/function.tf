@@ -0,0 +1,7 @@
+locals {
+  # value will be true
+  contained = provider::utility::cidr_contains("10.0.0.0/8", "10.1.2.0/24")
+
+  # bare IP addresses are also supported, value will be false
+  ip_contained = provider::utility::cidr_contains("10.0.0.0/16", "10.1.2.3")
+}
@@ -0,0 +1,7 @@
+locals {
+  # value will be "10.0.0.1"
+  gateway = provider::utility::cidr_host("10.0.0.0/24", 1)
+
+  # negative indexes count back from the end, value will be "10.0.0.254"
+  last_host = provider::utility::cidr_host("10.0.0.0/24", -2)
+}
@@ -0,0 +1,14 @@
+variable "vpc_cidr" {
+  type = string
+}
+
+resource "terraform_data" "vpc" {
+  input = var.vpc_cidr
+
+  lifecycle {
+    precondition {
+      condition     = provider::utility::cidr_is_private(var.vpc_cidr)
+      error_message = "The VPC CIDR must be private (RFC 1918) address space."
+    }
+  }
+}
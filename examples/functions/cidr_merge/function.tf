@@ -0,0 +1,4 @@
+locals {
+  # value will be ["10.0.0.0/23", "10.0.4.0/24"]
+  summarized = provider::utility::cidr_merge(["10.0.0.0/25", "10.0.0.128/25", "10.0.1.0/24", "10.0.4.0/24"])
+}
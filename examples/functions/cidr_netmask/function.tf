@@ -0,0 +1,7 @@
+locals {
+  # value will be "255.255.255.0"
+  netmask = provider::utility::cidr_netmask("10.0.0.0/24")
+
+  # value will be "ffff:ffff:ffff:ffff::"
+  ipv6_netmask = provider::utility::cidr_netmask("fd00::/64")
+}
@@ -0,0 +1,4 @@
+locals {
+  # value will be "10.0.5.0/24"
+  subnet = provider::utility::cidr_nth_subnet("10.0.0.0/16", 8, 5)
+}
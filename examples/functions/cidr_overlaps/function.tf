@@ -0,0 +1,14 @@
+variable "subnet_cidr" {
+  type = string
+}
+
+resource "terraform_data" "subnet" {
+  input = var.subnet_cidr
+
+  lifecycle {
+    precondition {
+      condition     = !provider::utility::cidr_overlaps(var.subnet_cidr, "10.0.0.0/24")
+      error_message = "The subnet must not overlap the management range 10.0.0.0/24."
+    }
+  }
+}
@@ -0,0 +1,7 @@
+locals {
+  # value will be { first = "10.0.0.1", last = "10.0.0.254" }
+  usable = provider::utility::cidr_range("10.0.0.0/24", true)
+
+  # value will be { first = "10.0.0.0", last = "10.0.0.255" }
+  absolute = provider::utility::cidr_range("10.0.0.0/24", false)
+}
@@ -0,0 +1,7 @@
+locals {
+  # value will be 256
+  addresses = provider::utility::cidr_size("10.0.0.0/24")
+
+  # value will be "18446744073709551616"
+  ipv6_addresses = provider::utility::cidr_size("fd00::/64")
+}
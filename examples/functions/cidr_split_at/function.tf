@@ -0,0 +1,12 @@
+locals {
+  # value will be { lower = ["10.0.0.0/26", "10.0.0.64/27", "10.0.0.96/30"], upper = ["10.0.0.100/30", "10.0.0.104/29", "10.0.0.112/28", "10.0.0.128/25"] }
+  split = provider::utility::cidr_split_at("10.0.0.0/24", "10.0.0.100")
+}
+
+# Keep the first 100 addresses for static assignment and allocate
+# subnets from the rest
+resource "utility_available_cidr" "dynamic" {
+  from_cidrs = local.split.upper
+  used_cidrs = []
+  mask       = 28
+}
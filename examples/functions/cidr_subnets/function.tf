@@ -0,0 +1,4 @@
+locals {
+  # value will be ["10.0.0.0/18", "10.0.64.0/18", "10.0.128.0/18"]
+  subnets = provider::utility::cidr_subnets("10.0.0.0/16", 2, 3)
+}
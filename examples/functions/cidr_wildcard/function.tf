@@ -0,0 +1,4 @@
+locals {
+  # value will be "0.0.0.255"
+  wildcard = provider::utility::cidr_wildcard("10.0.0.0/24")
+}
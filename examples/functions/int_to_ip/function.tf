@@ -0,0 +1,7 @@
+locals {
+  # value will be "10.0.0.1"
+  address = provider::utility::int_to_ip(167772161, 4)
+
+  # value will be "fd00::2"
+  next_address = provider::utility::int_to_ip(provider::utility::ip_to_int("fd00::1") + 1, 6)
+}
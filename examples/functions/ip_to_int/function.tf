@@ -0,0 +1,4 @@
+locals {
+  # value will be 167772161
+  address = provider::utility::ip_to_int("10.0.0.1")
+}
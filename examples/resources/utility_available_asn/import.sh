@@ -0,0 +1,2 @@
+# Existing ASNs can be imported by ASN
+terraform import utility_available_asn.example 64514
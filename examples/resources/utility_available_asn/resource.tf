@@ -0,0 +1,11 @@
+# Find an available private ASN for a new BGP peer given
+# the ASNs that are already in use
+resource "utility_available_asn" "example" {
+  from_ranges = ["64512-65534", "4200000000-4294967294"]
+  used_asns   = [64512, 64513]
+}
+
+# value will be 64514
+output "asn" {
+  value = utility_available_asn.example.result
+}
@@ -0,0 +1,8 @@
+# Existing CIDRs can be imported by CIDR
+terraform import utility_available_cidr.example 10.0.1.0/24
+
+# IPv6 CIDRs are imported the same way
+terraform import utility_available_cidr.example fd00:0:0:1::/64
+
+# Include the from_cidrs and used_cidrs, separated by semicolons, to import the full state
+terraform import utility_available_cidr.example "10.0.1.0/24;10.0.0.0/16;10.0.0.0/24"
@@ -0,0 +1,2 @@
+# Existing integers can be imported by value
+terraform import utility_available_integer.example 120
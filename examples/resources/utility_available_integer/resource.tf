@@ -0,0 +1,14 @@
+# Find an available priority for a new rule, leaving
+# room between rules by allocating in steps of 10
+resource "utility_available_integer" "example" {
+  min  = 100
+  max  = 4000
+  step = 10
+  used = [100, 110, 115, 130]
+}
+
+# value will be 120, since 115 isn't on the grid of
+# values that can be allocated and is ignored
+output "priority" {
+  value = utility_available_integer.example.result
+}
@@ -0,0 +1,2 @@
+# Existing ports can be imported by port number
+terraform import utility_available_port.example 30002
@@ -0,0 +1,11 @@
+# Find an available NodePort for a service given the
+# ports that are already in use
+resource "utility_available_port" "example" {
+  from_range = "30000-32767"
+  used_ports = [30000, 30001, 30003]
+}
+
+# value will be 30002
+output "port" {
+  value = utility_available_port.example.result
+}
@@ -0,0 +1,2 @@
+# Existing VLANs can be imported by VLAN ID
+terraform import utility_available_vlan.example 103
@@ -0,0 +1,11 @@
+# Find an available VLAN ID given the VLAN IDs that
+# are already in use across the fabric
+resource "utility_available_vlan" "example" {
+  from_range = "100-199"
+  used_vlans = [100, 101, 102]
+}
+
+# value will be 103
+output "vlan" {
+  value = utility_available_vlan.example.result
+}
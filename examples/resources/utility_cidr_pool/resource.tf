@@ -0,0 +1,16 @@
+# Allocate subnets for each tier of an application from a single
+# network, avoiding the subnet that is already in use
+resource "utility_cidr_pool" "example" {
+  from_cidrs = ["10.0.0.0/16"]
+  used_cidrs = ["10.0.0.0/24"]
+  requests = {
+    app  = 24
+    data = 24
+    web  = 22
+  }
+}
+
+# value will be { app = "10.0.1.0/24", data = "10.0.2.0/24", web = "10.0.4.0/22" }
+output "subnets" {
+  value = utility_cidr_pool.example.allocations
+}
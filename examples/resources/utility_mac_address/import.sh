@@ -0,0 +1,2 @@
+# Existing MAC addresses can be imported by address
+terraform import utility_mac_address.example 02:00:00:00:00:02
@@ -0,0 +1,10 @@
+# Find an available locally administered MAC address
+# given the addresses already assigned to the appliances
+resource "utility_mac_address" "example" {
+  used_macs = ["02:00:00:00:00:00", "02:00:00:00:00:01"]
+}
+
+# value will be "02:00:00:00:00:02"
+output "mac_address" {
+  value = utility_mac_address.example.result
+}
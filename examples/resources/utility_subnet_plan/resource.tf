@@ -0,0 +1,20 @@
+# Lay out the public, private and database subnets of a network
+# in a single allocation
+resource "utility_subnet_plan" "example" {
+  from_cidrs = ["10.0.0.0/16"]
+  requests = [
+    { name = "public", mask = 24, count = 3 },
+    { name = "private", mask = 22, count = 3 },
+    { name = "db", mask = 26, count = 3 },
+  ]
+}
+
+# value will be ["10.0.0.0/22", "10.0.4.0/22", "10.0.8.0/22"]
+output "private_subnets" {
+  value = utility_subnet_plan.example.plan["private"]
+}
+
+# value will be ["10.0.15.0/26", "10.0.15.64/26", "10.0.15.128/26"]
+output "db_subnets" {
+  value = utility_subnet_plan.example.plan["db"]
+}
@@ -0,0 +1,142 @@
+package cidrutil
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"strings"
+)
+
+// Aggregate returns the smallest set of networks that covers exactly the same addresses as the given networks
+// (route summarization). Overlapping and adjacent networks are merged, and the result is the same as repeatedly
+// combining pairs of equally sized sibling blocks into their parent until no more can be combined. IPv4 and IPv6
+// networks are aggregated separately, with the IPv4 networks listed first, each in ascending order.
+func Aggregate(networks []*net.IPNet) []*net.IPNet {
+	aggregated := []*net.IPNet{}
+	for _, bits := range []int{32, 128} {
+		intervals := []interval{}
+		for _, network := range networks {
+			if AddressBits(network) != bits {
+				continue
+			}
+			first, last := firstAndLast(network)
+			intervals = append(intervals, interval{first: first, last: last})
+		}
+
+		for _, merged := range mergeIntervals(intervals) {
+			aggregated = append(aggregated, intervalToNetworks(merged, bits)...)
+		}
+	}
+	return aggregated
+}
+
+// mergeIntervals combines overlapping and adjacent intervals, returning them in ascending order.
+func mergeIntervals(intervals []interval) []interval {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].first.Cmp(intervals[j].first) < 0
+	})
+
+	merged := []interval{}
+	for _, i := range intervals {
+		if len(merged) > 0 {
+			prev := &merged[len(merged)-1]
+			if next := new(big.Int).Add(prev.last, big.NewInt(1)); i.first.Cmp(next) <= 0 {
+				prev.last = maxInt(prev.last, i.last)
+				continue
+			}
+		}
+		merged = append(merged, interval{first: i.first, last: i.last})
+	}
+	return merged
+}
+
+// intervalToNetworks splits an interval into the fewest aligned networks that cover it, in ascending order. Each
+// network is the largest block that starts at the next uncovered address without extending past the interval.
+func intervalToNetworks(i interval, bits int) []*net.IPNet {
+	networks := []*net.IPNet{}
+	start := new(big.Int).Set(i.first)
+	for start.Cmp(i.last) <= 0 {
+		hostBits := int(start.TrailingZeroBits())
+		if start.Sign() == 0 || hostBits > bits {
+			hostBits = bits
+		}
+		for {
+			end := new(big.Int).Add(start, blockSize(bits-hostBits, bits))
+			if end.Sub(end, big.NewInt(1)).Cmp(i.last) <= 0 {
+				break
+			}
+			hostBits--
+		}
+
+		networks = append(networks, &net.IPNet{IP: intToIP(start, bits), Mask: net.CIDRMask(bits-hostBits, bits)})
+		start.Add(start, blockSize(bits-hostBits, bits))
+	}
+	return networks
+}
+
+// Free returns the smallest set of networks that covers the addresses within from that aren't covered by any of
+// the used networks, in ascending order. Used networks of a different address family or outside of from are
+// ignored.
+func Free(from *net.IPNet, used []*net.IPNet) []*net.IPNet {
+	free := []*net.IPNet{}
+	for _, gap := range freeIntervals(from, used) {
+		free = append(free, intervalToNetworks(gap, AddressBits(from))...)
+	}
+	return free
+}
+
+// Pad returns the smallest set of networks that covers the gap addresses on either side of network, in ascending
+// order, clamped to the address space. Treating them as used keeps anything allocated at least gap addresses away
+// from network.
+func Pad(network *net.IPNet, gap *big.Int) []*net.IPNet {
+	if gap.Sign() <= 0 {
+		return []*net.IPNet{}
+	}
+
+	bits := AddressBits(network)
+	first, last := firstAndLast(network)
+	maxAddress := new(big.Int).Sub(blockSize(0, bits), big.NewInt(1))
+
+	padding := []*net.IPNet{}
+	if first.Sign() > 0 {
+		below := interval{first: maxInt(new(big.Int).Sub(first, gap), big.NewInt(0)), last: new(big.Int).Sub(first, big.NewInt(1))}
+		padding = append(padding, intervalToNetworks(below, bits)...)
+	}
+	if last.Cmp(maxAddress) < 0 {
+		above := interval{first: new(big.Int).Add(last, big.NewInt(1)), last: minInt(new(big.Int).Add(last, gap), maxAddress)}
+		padding = append(padding, intervalToNetworks(above, bits)...)
+	}
+	return padding
+}
+
+// ParseRange parses an address range in `start-end` notation (ex. 10.0.0.0-10.0.3.255) and returns the smallest set
+// of networks that covers exactly the addresses from start to end, in ascending order. Both addresses must be of
+// the same family, and start must not be after end.
+func ParseRange(s string) ([]*net.IPNet, error) {
+	startString, endString, ok := strings.Cut(s, "-")
+	if !ok {
+		return nil, fmt.Errorf("%q is not an address range in start-end notation", s)
+	}
+
+	start := net.ParseIP(strings.TrimSpace(startString))
+	end := net.ParseIP(strings.TrimSpace(endString))
+	if start == nil || end == nil {
+		return nil, fmt.Errorf("%q is not an address range in start-end notation", s)
+	}
+
+	bits := 128
+	if start.To4() != nil {
+		bits = 32
+	}
+	if (end.To4() != nil) != (bits == 32) {
+		return nil, fmt.Errorf("the start and end of %q are of different address families", s)
+	}
+
+	first, last := ipToInt(start), ipToInt(end)
+	if first.Cmp(last) > 0 {
+		return nil, fmt.Errorf("the start of %q is after its end", s)
+	}
+
+	return intervalToNetworks(interval{first: first, last: last}, bits), nil
+}
@@ -0,0 +1,257 @@
+package cidrutil
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestAggregate(t *testing.T) {
+	type testData struct {
+		name  string
+		cidrs []string
+		want  []string
+	}
+	tests := []testData{
+		{
+			name:  "adjacent siblings merge into their parent",
+			cidrs: []string{"10.0.0.0/25", "10.0.0.128/25"},
+			want:  []string{"10.0.0.0/24"},
+		},
+		{
+			name:  "merges repeatedly",
+			cidrs: []string{"10.0.3.0/24", "10.0.0.0/24", "10.0.2.0/24", "10.0.1.0/24"},
+			want:  []string{"10.0.0.0/22"},
+		},
+		{
+			name:  "adjacent blocks that aren't siblings stay separate",
+			cidrs: []string{"10.0.1.0/24", "10.0.2.0/24"},
+			want:  []string{"10.0.1.0/24", "10.0.2.0/24"},
+		},
+		{
+			name:  "contained and duplicate blocks are dropped",
+			cidrs: []string{"10.0.0.0/16", "10.0.1.0/24", "10.0.0.0/16"},
+			want:  []string{"10.0.0.0/16"},
+		},
+		{
+			name:  "partial overlap",
+			cidrs: []string{"10.0.0.0/23", "10.0.1.0/24", "10.0.2.0/23"},
+			want:  []string{"10.0.0.0/22"},
+		},
+		{
+			name:  "unaligned run is split into the largest blocks",
+			cidrs: []string{"10.0.1.0/24", "10.0.2.0/23", "10.0.4.0/24"},
+			want:  []string{"10.0.1.0/24", "10.0.2.0/23", "10.0.4.0/24"},
+		},
+		{
+			name:  "families are aggregated separately",
+			cidrs: []string{"fd00:0:0:1::/64", "10.0.0.128/25", "fd00::/64", "10.0.0.0/25"},
+			want:  []string{"10.0.0.0/24", "fd00::/63"},
+		},
+		{
+			name:  "whole address space",
+			cidrs: []string{"0.0.0.0/1", "128.0.0.0/1"},
+			want:  []string{"0.0.0.0/0"},
+		},
+		{
+			name:  "empty",
+			cidrs: []string{},
+			want:  []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Strings(Aggregate(mustParseCIDRs(t, tc.cidrs...)))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFree(t *testing.T) {
+	type testData struct {
+		name string
+		from string
+		used []string
+		want []string
+	}
+	tests := []testData{
+		{
+			name: "nothing used",
+			from: "10.0.0.0/16",
+			used: []string{},
+			want: []string{"10.0.0.0/16"},
+		},
+		{
+			name: "single used block splits the range into the largest blocks",
+			from: "10.0.0.0/22",
+			used: []string{"10.0.1.0/24"},
+			want: []string{"10.0.0.0/24", "10.0.2.0/23"},
+		},
+		{
+			name: "used block at the end",
+			from: "10.0.0.0/24",
+			used: []string{"10.0.0.192/26"},
+			want: []string{"10.0.0.0/25", "10.0.0.128/26"},
+		},
+		{
+			name: "overlapping and out of range used blocks",
+			from: "10.0.0.0/24",
+			used: []string{"10.0.0.0/26", "10.0.0.32/27", "10.1.0.0/16", "fd00::/64"},
+			want: []string{"10.0.0.64/26", "10.0.0.128/25"},
+		},
+		{
+			name: "fully used",
+			from: "10.0.0.0/24",
+			used: []string{"10.0.0.0/16"},
+			want: []string{},
+		},
+		{
+			name: "ipv6",
+			from: "fd00::/62",
+			used: []string{"fd00::/64"},
+			want: []string{"fd00:0:0:1::/64", "fd00:0:0:2::/63"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Strings(Free(mustParseCIDRs(t, tc.from)[0], mustParseCIDRs(t, tc.used...)))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPad(t *testing.T) {
+	type testData struct {
+		name    string
+		network string
+		gap     int64
+		want    []string
+	}
+	tests := []testData{
+		{
+			name:    "no gap",
+			network: "10.0.1.0/24",
+			gap:     0,
+			want:    []string{},
+		},
+		{
+			name:    "aligned gap",
+			network: "10.0.1.0/24",
+			gap:     256,
+			want:    []string{"10.0.0.0/24", "10.0.2.0/24"},
+		},
+		{
+			name:    "unaligned gap",
+			network: "10.0.1.0/24",
+			gap:     3,
+			want:    []string{"10.0.0.253/32", "10.0.0.254/31", "10.0.2.0/31", "10.0.2.2/32"},
+		},
+		{
+			name:    "clamped to the start of the address space",
+			network: "0.0.0.0/24",
+			gap:     16,
+			want:    []string{"0.0.1.0/28"},
+		},
+		{
+			name:    "clamped to the end of the address space",
+			network: "255.255.255.0/24",
+			gap:     16,
+			want:    []string{"255.255.254.240/28"},
+		},
+		{
+			name:    "ipv6",
+			network: "fd00:0:0:1::/64",
+			gap:     2,
+			want:    []string{"fd00::ffff:ffff:ffff:fffe/127", "fd00:0:0:2::/127"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Strings(Pad(mustParseCIDRs(t, tc.network)[0], big.NewInt(tc.gap)))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	type testData struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}
+	tests := []testData{
+		{
+			name:  "aligned range is a single network",
+			input: "10.0.0.0-10.0.3.255",
+			want:  []string{"10.0.0.0/22"},
+		},
+		{
+			name:  "unaligned range",
+			input: "10.0.0.1-10.0.0.6",
+			want:  []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/31", "10.0.0.6/32"},
+		},
+		{
+			name:  "single address",
+			input: "10.0.0.5-10.0.0.5",
+			want:  []string{"10.0.0.5/32"},
+		},
+		{
+			name:  "whitespace around addresses",
+			input: "10.0.0.0 - 10.0.0.255",
+			want:  []string{"10.0.0.0/24"},
+		},
+		{
+			name:  "ipv6",
+			input: "fd00::-fd00::1:ffff",
+			want:  []string{"fd00::/111"},
+		},
+		{
+			name:    "start after end",
+			input:   "10.0.1.0-10.0.0.0",
+			wantErr: true,
+		},
+		{
+			name:    "mixed families",
+			input:   "10.0.0.0-fd00::",
+			wantErr: true,
+		},
+		{
+			name:    "missing end",
+			input:   "10.0.0.0",
+			wantErr: true,
+		},
+		{
+			name:    "invalid address",
+			input:   "10.0.0.0-10.0.0.256",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseRange(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", Strings(got))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(Strings(got), tc.want) {
+				t.Errorf("got %v, want %v", Strings(got), tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package cidrutil
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"net"
+)
+
+// Errors returned by the Find functions, which callers can tell apart with errors.Is.
+var (
+	// ErrNoSpace is returned when every block of the requested size overlaps one of the used networks.
+	ErrNoSpace = errors.New("no available CIDR")
+	// ErrInvalidMask is returned when the mask can't be allocated out of the range at all, because it is of the other
+	// address family or larger than the range.
+	ErrInvalidMask = errors.New("invalid mask")
+	// ErrInvalidAlign is returned when the alignment is of the other address family or finer than the mask.
+	ErrInvalidAlign = errors.New("invalid alignment")
+)
+
+// findError is an error of one of the kinds above, with a message describing the particular failure.
+type findError struct {
+	kind    error
+	message string
+}
+
+func (e *findError) Error() string {
+	return e.message
+}
+
+func (e *findError) Unwrap() error {
+	return e.kind
+}
+
+// newFindError returns an error of the given kind, formatted like fmt.Errorf.
+func newFindError(kind error, format string, args ...interface{}) error {
+	return &findError{kind: kind, message: fmt.Sprintf(format, args...)}
+}
+
+// FindFirstAvailableCIDR returns the lowest block of size mask within from that does not overlap any of the used
+// networks. Rather than checking each candidate block in turn, it walks the gaps between the used networks and jumps
+// to the first aligned block in each one, so the search grows with the number of used networks instead of the
+// size of from.
+func FindFirstAvailableCIDR(from *net.IPNet, mask *net.IPMask, used []*net.IPNet) (*net.IPNet, error) {
+	return FindFirstAlignedCIDR(from, mask, mask, used)
+}
+
+// FindFirstAlignedCIDR is FindFirstAvailableCIDR, except that the block must start on a boundary of the align
+// mask, which may be coarser than mask (ex. a /28 that starts on a /26 boundary).
+func FindFirstAlignedCIDR(from *net.IPNet, mask *net.IPMask, align *net.IPMask, used []*net.IPNet) (*net.IPNet, error) {
+	ones, bits, err := checkMask(from, mask)
+	if err != nil {
+		return nil, err
+	}
+	alignOnes, err := checkAlign(ones, bits, align)
+	if err != nil {
+		return nil, err
+	}
+
+	size := blockSize(ones, bits)
+	step := blockSize(alignOnes, bits)
+
+	for _, gap := range freeIntervals(from, used) {
+		start := alignUp(gap.first, step)
+		end := new(big.Int).Add(start, size)
+		end.Sub(end, big.NewInt(1))
+		if end.Cmp(gap.last) <= 0 {
+			return &net.IPNet{IP: intToIP(start, bits), Mask: *mask}, nil
+		}
+	}
+
+	return nil, newFindError(ErrNoSpace, "no available /%d CIDR found in %s", ones, from)
+}
+
+// FindLastAvailableCIDR returns the highest block of size mask within from that does not overlap any of the
+// used networks. Like FindFirstAvailableCIDR it walks the gaps between the used networks, starting at the top of
+// from, and jumps to the last aligned block in each one.
+func FindLastAvailableCIDR(from *net.IPNet, mask *net.IPMask, used []*net.IPNet) (*net.IPNet, error) {
+	return FindLastAlignedCIDR(from, mask, mask, used)
+}
+
+// FindLastAlignedCIDR is FindLastAvailableCIDR, except that the block must start on a boundary of the align mask.
+func FindLastAlignedCIDR(from *net.IPNet, mask *net.IPMask, align *net.IPMask, used []*net.IPNet) (*net.IPNet, error) {
+	ones, bits, err := checkMask(from, mask)
+	if err != nil {
+		return nil, err
+	}
+	alignOnes, err := checkAlign(ones, bits, align)
+	if err != nil {
+		return nil, err
+	}
+
+	size := blockSize(ones, bits)
+	step := blockSize(alignOnes, bits)
+
+	gaps := freeIntervals(from, used)
+	for i := len(gaps) - 1; i >= 0; i-- {
+		gap := gaps[i]
+		if gap.size().Cmp(size) < 0 {
+			continue
+		}
+
+		start := new(big.Int).Add(gap.last, big.NewInt(1))
+		start = alignDown(start.Sub(start, size), step)
+		if start.Cmp(gap.first) >= 0 {
+			return &net.IPNet{IP: intToIP(start, bits), Mask: *mask}, nil
+		}
+	}
+
+	return nil, newFindError(ErrNoSpace, "no available /%d CIDR found in %s", ones, from)
+}
+
+// FindBestAvailableCIDR returns the block of size mask within from that leaves the smallest amount of free space
+// in the gap it is placed in, which keeps larger gaps intact for future allocations. Ties are broken by choosing
+// the lowest address so the result is deterministic. The number of addresses left free in the chosen gap is also
+// returned, so results from several ranges can be compared.
+func FindBestAvailableCIDR(from *net.IPNet, mask *net.IPMask, used []*net.IPNet) (*net.IPNet, *big.Int, error) {
+	return FindBestAlignedCIDR(from, mask, mask, used)
+}
+
+// FindBestAlignedCIDR is FindBestAvailableCIDR, except that the block must start on a boundary of the align mask.
+func FindBestAlignedCIDR(from *net.IPNet, mask *net.IPMask, align *net.IPMask, used []*net.IPNet) (*net.IPNet, *big.Int, error) {
+	ones, bits, err := checkMask(from, mask)
+	if err != nil {
+		return nil, nil, err
+	}
+	alignOnes, err := checkAlign(ones, bits, align)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	size := blockSize(ones, bits)
+	step := blockSize(alignOnes, bits)
+
+	var best *big.Int
+	var bestRemaining *big.Int
+	for _, gap := range freeIntervals(from, used) {
+		start := alignUp(gap.first, step)
+		end := new(big.Int).Add(start, size)
+		end.Sub(end, big.NewInt(1))
+		if end.Cmp(gap.last) > 0 {
+			continue
+		}
+
+		remaining := new(big.Int).Sub(gap.size(), size)
+		// Gaps are in ascending order, so only replacing on a strictly smaller remainder keeps the lowest
+		// address on a tie.
+		if best == nil || remaining.Cmp(bestRemaining) < 0 {
+			best = start
+			bestRemaining = remaining
+		}
+	}
+
+	if best == nil {
+		return nil, nil, newFindError(ErrNoSpace, "no available /%d CIDR found in %s", ones, from)
+	}
+
+	return &net.IPNet{IP: intToIP(best, bits), Mask: *mask}, bestRemaining, nil
+}
+
+// FindRandomAvailableCIDR returns a block of size mask within from that does not overlap any of the used networks,
+// chosen uniformly at random from all of the available blocks. The same rng state always produces the same result
+// for the same inputs.
+func FindRandomAvailableCIDR(from *net.IPNet, mask *net.IPMask, used []*net.IPNet, rng *rand.Rand) (*net.IPNet, error) {
+	return FindRandomAlignedCIDR(from, mask, mask, used, rng)
+}
+
+// FindRandomAlignedCIDR is FindRandomAvailableCIDR, except that the block must start on a boundary of the align
+// mask.
+func FindRandomAlignedCIDR(from *net.IPNet, mask *net.IPMask, align *net.IPMask, used []*net.IPNet, rng *rand.Rand) (*net.IPNet, error) {
+	ones, bits, err := checkMask(from, mask)
+	if err != nil {
+		return nil, err
+	}
+	alignOnes, err := checkAlign(ones, bits, align)
+	if err != nil {
+		return nil, err
+	}
+
+	size := blockSize(ones, bits)
+	step := blockSize(alignOnes, bits)
+
+	gaps := freeIntervals(from, used)
+	counts := make([]*big.Int, len(gaps))
+	total := new(big.Int)
+	for i, gap := range gaps {
+		counts[i] = alignedStarts(gap, size, step)
+		total.Add(total, counts[i])
+	}
+
+	if total.Sign() == 0 {
+		return nil, newFindError(ErrNoSpace, "no available /%d CIDR found in %s", ones, from)
+	}
+
+	index := new(big.Int).Rand(rng, total)
+	for i, gap := range gaps {
+		if index.Cmp(counts[i]) < 0 {
+			start := new(big.Int).Mul(index, step)
+			start.Add(start, alignUp(gap.first, step))
+			return &net.IPNet{IP: intToIP(start, bits), Mask: *mask}, nil
+		}
+		index.Sub(index, counts[i])
+	}
+
+	// unreachable, index is always less than the total number of blocks
+	return nil, newFindError(ErrNoSpace, "no available /%d CIDR found in %s", ones, from)
+}
+
+// checkMask ensures mask can be allocated out of from, returning the prefix length of the mask and the
+// number of address bits.
+func checkMask(from *net.IPNet, mask *net.IPMask) (int, int, error) {
+	fromOnes, bits := from.Mask.Size()
+	ones, maskBits := mask.Size()
+	if maskBits != bits {
+		return 0, 0, newFindError(ErrInvalidMask, "mask /%d is not valid for the address family of %s", ones, from)
+	}
+	if ones < fromOnes {
+		return 0, 0, newFindError(ErrInvalidMask, "requested /%d block is larger than the /%d source range %s", ones, fromOnes, from)
+	}
+	return ones, bits, nil
+}
+
+// checkAlign ensures align is no finer than a mask with the given prefix length, returning the prefix length of
+// align.
+func checkAlign(ones int, bits int, align *net.IPMask) (int, error) {
+	alignOnes, alignBits := align.Size()
+	if alignBits != bits {
+		return 0, newFindError(ErrInvalidAlign, "alignment /%d is not valid for a /%d mask", alignOnes, ones)
+	}
+	if alignOnes > ones {
+		return 0, newFindError(ErrInvalidAlign, "alignment /%d is smaller than the /%d mask", alignOnes, ones)
+	}
+	return alignOnes, nil
+}
+
+// overlapsAny reports whether the network overlaps any of the given networks.
+func overlapsAny(network *net.IPNet, others []*net.IPNet) bool {
+	for _, other := range others {
+		if Overlaps(network, other) {
+			return true
+		}
+	}
+	return false
+}
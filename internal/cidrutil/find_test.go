@@ -0,0 +1,511 @@
+package cidrutil
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"net"
+	"testing"
+)
+
+func mustParseCIDRs(t *testing.T, cidrs ...string) []*net.IPNet {
+	t.Helper()
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, c := range cidrs {
+		_, network, err := net.ParseCIDR(c)
+		if err != nil {
+			t.Fatalf("unable to parse %s: %s", c, err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+func TestFindFirstAvailableCIDR(t *testing.T) {
+	type testData struct {
+		name    string
+		from    string
+		used    []string
+		mask    int
+		want    string
+		wantErr bool
+	}
+	tests := []testData{
+		{
+			name: "empty range returns the lowest block",
+			from: "10.0.0.0/16",
+			used: []string{},
+			mask: 24,
+			want: "10.0.0.0/24",
+		},
+		{
+			name: "skips used blocks at the bottom",
+			from: "10.0.0.0/16",
+			used: []string{"10.0.0.0/24", "10.0.1.0/24"},
+			mask: 24,
+			want: "10.0.2.0/24",
+		},
+		{
+			name: "unsorted and overlapping used blocks",
+			from: "10.0.0.0/16",
+			used: []string{"10.0.4.0/22", "10.0.0.0/22", "10.0.2.0/23"},
+			mask: 24,
+			want: "10.0.8.0/24",
+		},
+		{
+			name: "gap too small once aligned is skipped",
+			from: "10.0.0.0/24",
+			// Leaves 10.0.0.32-10.0.0.95 free, which can't hold an aligned /26, and 10.0.0.192/26.
+			used: []string{"10.0.0.0/27", "10.0.0.96/27", "10.0.0.128/26"},
+			mask: 26,
+			want: "10.0.0.192/26",
+		},
+		{
+			name: "ipv6",
+			from: "fd00::/32",
+			used: []string{"fd00::/33"},
+			mask: 64,
+			want: "fd00:0:8000::/64",
+		},
+		{
+			name:    "full range",
+			from:    "10.0.0.0/24",
+			used:    []string{"10.0.0.0/25", "10.0.0.128/25"},
+			mask:    26,
+			wantErr: true,
+		},
+		{
+			name:    "range within a used block",
+			from:    "10.0.0.0/24",
+			used:    []string{"10.0.0.0/16"},
+			mask:    26,
+			wantErr: true,
+		},
+		{
+			name:    "mask larger than range",
+			from:    "10.0.0.0/24",
+			used:    []string{},
+			mask:    16,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			from := mustParseCIDRs(t, tc.from)[0]
+			mask := net.CIDRMask(tc.mask, AddressBits(from))
+			got, err := FindFirstAvailableCIDR(from, &mask, mustParseCIDRs(t, tc.used...))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFindLastAvailableCIDR(t *testing.T) {
+	type testData struct {
+		name    string
+		from    string
+		used    []string
+		mask    int
+		want    string
+		wantErr bool
+	}
+	tests := []testData{
+		{
+			name: "empty range returns the top block",
+			from: "10.0.0.0/16",
+			used: []string{},
+			mask: 24,
+			want: "10.0.255.0/24",
+		},
+		{
+			name: "skips used blocks at the top",
+			from: "10.0.0.0/16",
+			used: []string{"10.0.255.0/24", "10.0.254.0/23"},
+			mask: 24,
+			want: "10.0.253.0/24",
+		},
+		{
+			name: "larger used block covers many candidates",
+			from: "10.0.0.0/16",
+			used: []string{"10.0.128.0/17"},
+			mask: 24,
+			want: "10.0.127.0/24",
+		},
+		{
+			name: "ipv6",
+			from: "fd00::/56",
+			used: []string{"fd00:0:0:ff::/64"},
+			mask: 64,
+			want: "fd00:0:0:fe::/64",
+		},
+		{
+			name: "ipv6 with the upper half used",
+			from: "fd00::/32",
+			used: []string{"fd00:0:8000::/33"},
+			mask: 64,
+			want: "fd00:0:7fff:ffff::/64",
+		},
+		{
+			name: "gap too small once aligned is skipped",
+			from: "10.0.0.0/24",
+			// Leaves 10.0.0.0/26 and 10.0.0.160-10.0.0.223 free, which can't hold an aligned /26.
+			used: []string{"10.0.0.64/26", "10.0.0.128/27", "10.0.0.224/27"},
+			mask: 26,
+			want: "10.0.0.0/26",
+		},
+		{
+			name:    "full range",
+			from:    "10.0.0.0/24",
+			used:    []string{"10.0.0.0/25", "10.0.0.128/25"},
+			mask:    26,
+			wantErr: true,
+		},
+		{
+			name:    "mask larger than range",
+			from:    "10.0.0.0/24",
+			used:    []string{},
+			mask:    16,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			from := mustParseCIDRs(t, tc.from)[0]
+			mask := net.CIDRMask(tc.mask, AddressBits(from))
+			got, err := FindLastAvailableCIDR(from, &mask, mustParseCIDRs(t, tc.used...))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFindBestAvailableCIDR(t *testing.T) {
+	type testData struct {
+		name      string
+		from      string
+		used      []string
+		mask      int
+		want      string
+		remaining string
+		wantErr   bool
+	}
+	tests := []testData{
+		{
+			name: "empty range returns the lowest block",
+			from: "10.0.0.0/16",
+			used: []string{},
+			mask: 24,
+			want: "10.0.0.0/24",
+
+			remaining: "65280",
+		},
+		{
+			name: "prefers the smallest gap that fits",
+			from: "10.0.0.0/16",
+			// Leaves a /22 gap at 10.0.0.0, a /24 gap at 10.0.8.0 and the rest of the range free.
+			used: []string{"10.0.4.0/22", "10.0.9.0/24", "10.0.10.0/23", "10.0.12.0/22", "10.0.16.0/20"},
+			mask: 24,
+			want: "10.0.8.0/24",
+
+			remaining: "0",
+		},
+		{
+			name: "ties are broken by the lowest address",
+			from: "10.0.0.0/16",
+			// Leaves two /23 gaps at 10.0.2.0 and 10.0.6.0 and the upper half of the range free.
+			used: []string{"10.0.0.0/23", "10.0.4.0/23", "10.0.8.0/21", "10.0.16.0/20", "10.0.32.0/19", "10.0.64.0/18"},
+			mask: 24,
+			want: "10.0.2.0/24",
+
+			remaining: "256",
+		},
+		{
+			name: "gap too small once aligned is skipped",
+			from: "10.0.0.0/24",
+			// Leaves 10.0.0.32-10.0.0.95 free, which can't hold an aligned /26, and 10.0.0.192/26.
+			used: []string{"10.0.0.0/27", "10.0.0.96/27", "10.0.0.128/26"},
+			mask: 26,
+			want: "10.0.0.192/26",
+
+			remaining: "0",
+		},
+		{
+			name: "ipv6",
+			from: "fd00::/56",
+			used: []string{"fd00::/64", "fd00:0:0:2::/63", "fd00:0:0:4::/62", "fd00:0:0:8::/61"},
+			mask: 64,
+			want: "fd00:0:0:1::/64",
+
+			remaining: "0",
+		},
+		{
+			name:    "full range",
+			from:    "10.0.0.0/24",
+			used:    []string{"10.0.0.0/25", "10.0.0.128/25"},
+			mask:    26,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			from := mustParseCIDRs(t, tc.from)[0]
+			mask := net.CIDRMask(tc.mask, AddressBits(from))
+			got, remaining, err := FindBestAvailableCIDR(from, &mask, mustParseCIDRs(t, tc.used...))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+			if remaining.String() != tc.remaining {
+				t.Errorf("remaining: got %v, want %v", remaining, tc.remaining)
+			}
+		})
+	}
+}
+
+func TestFindRandomAvailableCIDR(t *testing.T) {
+	from := mustParseCIDRs(t, "10.0.0.0/16")[0]
+	used := mustParseCIDRs(t, "10.0.0.0/17", "10.0.192.0/18")
+	mask := net.CIDRMask(24, 32)
+
+	first, err := FindRandomAvailableCIDR(from, &mask, used, rand.New(rand.NewSource(42)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !mustParseCIDRs(t, "10.0.128.0/18")[0].Contains(first.IP) {
+		t.Errorf("got %v which is not in the free space", first)
+	}
+
+	second, err := FindRandomAvailableCIDR(from, &mask, used, rand.New(rand.NewSource(42)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if first.String() != second.String() {
+		t.Errorf("same seed returned different results: %v and %v", first, second)
+	}
+
+	full := mustParseCIDRs(t, "10.0.0.0/16")
+	if got, err := FindRandomAvailableCIDR(from, &mask, full, rand.New(rand.NewSource(42))); err == nil {
+		t.Errorf("expected error, got %v", got)
+	}
+}
+
+// A used network larger than the mask has to block every block it covers, which is what happens when the first tier
+// of allocations is passed as used when carving a second tier. Allocating until from is full checks that none of the
+// blocks within the used network are ever offered, whichever strategy is searching.
+func TestFindAvailableCIDRUsedLargerThanMask(t *testing.T) {
+	find := map[string]func(from *net.IPNet, mask *net.IPMask, used []*net.IPNet) (*net.IPNet, error){
+		"first": FindFirstAvailableCIDR,
+		"last":  FindLastAvailableCIDR,
+		"best": func(from *net.IPNet, mask *net.IPMask, used []*net.IPNet) (*net.IPNet, error) {
+			network, _, err := FindBestAvailableCIDR(from, mask, used)
+			return network, err
+		},
+		"random": func(from *net.IPNet, mask *net.IPMask, used []*net.IPNet) (*net.IPNet, error) {
+			return FindRandomAvailableCIDR(from, mask, used, rand.New(rand.NewSource(42)))
+		},
+	}
+
+	for name, f := range find {
+		t.Run(name, func(t *testing.T) {
+			from := mustParseCIDRs(t, "10.0.0.0/16")[0]
+			blocked := mustParseCIDRs(t, "10.0.32.0/20")[0]
+			mask := net.CIDRMask(24, 32)
+
+			used := []*net.IPNet{blocked}
+			// 256 /24s in the /16, less the 16 within the /20.
+			for i := 0; i < 240; i++ {
+				got, err := f(from, &mask, used)
+				if err != nil {
+					t.Fatalf("allocation %d: unexpected error: %s", i, err)
+				}
+				if blocked.Contains(got.IP) {
+					t.Fatalf("allocation %d: got %v which is within the used %v", i, got, blocked)
+				}
+				used = append(used, got)
+			}
+
+			if got, err := f(from, &mask, used); err == nil {
+				t.Errorf("expected error once from is full, got %v", got)
+			}
+		})
+	}
+
+	// A used network that covers all of from leaves nothing to allocate.
+	for name, f := range find {
+		t.Run(name+" covering from", func(t *testing.T) {
+			from := mustParseCIDRs(t, "10.0.0.0/16")[0]
+			mask := net.CIDRMask(24, 32)
+			if got, err := f(from, &mask, mustParseCIDRs(t, "10.0.0.0/8")); err == nil {
+				t.Errorf("expected error, got %v", got)
+			}
+		})
+	}
+}
+
+func TestFindAlignedCIDR(t *testing.T) {
+	find := map[string]func(from *net.IPNet, mask *net.IPMask, align *net.IPMask, used []*net.IPNet) (*net.IPNet, error){
+		"first": FindFirstAlignedCIDR,
+		"last":  FindLastAlignedCIDR,
+		"best": func(from *net.IPNet, mask *net.IPMask, align *net.IPMask, used []*net.IPNet) (*net.IPNet, error) {
+			network, _, err := FindBestAlignedCIDR(from, mask, align, used)
+			return network, err
+		},
+		"random": func(from *net.IPNet, mask *net.IPMask, align *net.IPMask, used []*net.IPNet) (*net.IPNet, error) {
+			return FindRandomAlignedCIDR(from, mask, align, used, rand.New(rand.NewSource(42)))
+		},
+	}
+	want := map[string]string{
+		"first": "10.0.0.64/28",
+		"last":  "10.0.0.192/28",
+		"best":  "10.0.0.64/28",
+	}
+
+	for name, f := range find {
+		t.Run(name, func(t *testing.T) {
+			from := mustParseCIDRs(t, "10.0.0.0/24")[0]
+			// Leaves 10.0.0.16-10.0.0.63 free, which holds /28s but none on a /26 boundary.
+			used := mustParseCIDRs(t, "10.0.0.0/28")
+			mask := net.CIDRMask(28, 32)
+			align := net.CIDRMask(26, 32)
+
+			// Only 10.0.0.64, 10.0.0.128 and 10.0.0.192 are free /26 boundaries.
+			for i := 0; i < 3; i++ {
+				got, err := f(from, &mask, &align, used)
+				if err != nil {
+					t.Fatalf("allocation %d: unexpected error: %s", i, err)
+				}
+				if i == 0 && want[name] != "" && got.String() != want[name] {
+					t.Errorf("expected %s, got %v", want[name], got)
+				}
+				if start := IPToInt(got.IP); new(big.Int).Mod(start, big.NewInt(64)).Sign() != 0 {
+					t.Fatalf("allocation %d: %v does not start on a /26 boundary", i, got)
+				}
+				used = append(used, got)
+			}
+
+			if got, err := f(from, &mask, &align, used); err == nil {
+				t.Errorf("expected error once every boundary is used, got %v", got)
+			}
+		})
+	}
+
+	t.Run("alignment smaller than mask", func(t *testing.T) {
+		from := mustParseCIDRs(t, "10.0.0.0/24")[0]
+		mask := net.CIDRMask(26, 32)
+		align := net.CIDRMask(28, 32)
+		if got, err := FindFirstAlignedCIDR(from, &mask, &align, nil); err == nil {
+			t.Errorf("expected error, got %v", got)
+		}
+	})
+}
+
+func TestFindErrorKinds(t *testing.T) {
+	tests := []struct {
+		name  string
+		from  string
+		used  []string
+		mask  net.IPMask
+		align net.IPMask
+		want  error
+	}{
+		{name: "no space", from: "10.0.0.0/24", used: []string{"10.0.0.0/25", "10.0.0.128/26"}, mask: net.CIDRMask(25, 32), align: net.CIDRMask(25, 32), want: ErrNoSpace},
+		{name: "mask larger than range", from: "10.0.0.0/24", mask: net.CIDRMask(16, 32), align: net.CIDRMask(16, 32), want: ErrInvalidMask},
+		{name: "mask of the other family", from: "10.0.0.0/24", mask: net.CIDRMask(64, 128), align: net.CIDRMask(64, 128), want: ErrInvalidMask},
+		{name: "alignment finer than mask", from: "10.0.0.0/24", mask: net.CIDRMask(26, 32), align: net.CIDRMask(28, 32), want: ErrInvalidAlign},
+		{name: "alignment of the other family", from: "10.0.0.0/24", mask: net.CIDRMask(26, 32), align: net.CIDRMask(26, 128), want: ErrInvalidAlign},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			from := mustParseCIDRs(t, tc.from)[0]
+			used := mustParseCIDRs(t, tc.used...)
+
+			errs := map[string]error{}
+			_, errs["first"] = FindFirstAlignedCIDR(from, &tc.mask, &tc.align, used)
+			_, errs["last"] = FindLastAlignedCIDR(from, &tc.mask, &tc.align, used)
+			_, _, errs["best"] = FindBestAlignedCIDR(from, &tc.mask, &tc.align, used)
+			_, errs["random"] = FindRandomAlignedCIDR(from, &tc.mask, &tc.align, used, rand.New(rand.NewSource(42)))
+
+			for name, err := range errs {
+				if !errors.Is(err, tc.want) {
+					t.Errorf("%s: expected %v, got %v", name, tc.want, err)
+				}
+			}
+		})
+	}
+}
+
+// The used networks cover half of a /32, which holds 2^31 /64 blocks, so checking candidates one at a time would
+// never finish. Jumping between gaps only has to look at the used networks.
+func BenchmarkFindFirstAvailableCIDR(b *testing.B) {
+	_, from, _ := net.ParseCIDR("fd00::/32")
+	_, used, _ := net.ParseCIDR("fd00::/33")
+	mask := net.CIDRMask(64, 128)
+	for i := 0; i < b.N; i++ {
+		if _, err := FindFirstAvailableCIDR(from, &mask, []*net.IPNet{used}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFindLastAvailableCIDR(b *testing.B) {
+	_, from, _ := net.ParseCIDR("fd00::/32")
+	_, used, _ := net.ParseCIDR("fd00:0:8000::/33")
+	mask := net.CIDRMask(64, 128)
+	for i := 0; i < b.N; i++ {
+		if _, err := FindLastAvailableCIDR(from, &mask, []*net.IPNet{used}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Thousands of used /64s at the start of the range, as in a busy network with a subnet per workload.
+func BenchmarkFindFirstAvailableCIDRManyUsed(b *testing.B) {
+	_, from, _ := net.ParseCIDR("fd00::/32")
+	mask := net.CIDRMask(64, 128)
+	start := IPToInt(from.IP)
+	size := blockSize(64, 128)
+	used := make([]*net.IPNet, 4096)
+	for i := range used {
+		address := new(big.Int).Mul(size, big.NewInt(int64(i)))
+		used[i] = &net.IPNet{IP: intToIP(address.Add(address, start), 128), Mask: mask}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := FindFirstAvailableCIDR(from, &mask, used); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
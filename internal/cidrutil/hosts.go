@@ -0,0 +1,64 @@
+package cidrutil
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// HostRange describes the notable addresses within a network.
+type HostRange struct {
+	// Network is the first address in the network.
+	Network net.IP
+	// Broadcast is the last address in an IPv4 network, nil for IPv4 /31 and /32 networks and for IPv6
+	// networks which have no broadcast address.
+	Broadcast net.IP
+	// First is the first usable host address.
+	First net.IP
+	// Last is the last usable host address.
+	Last net.IP
+}
+
+// Hosts returns the network, broadcast and usable host addresses of the network.
+//
+// For IPv4 the network and broadcast addresses are excluded from the usable hosts, except for /31 networks where
+// both addresses are usable (RFC 3021) and /32 networks which contain a single host. Every address of an IPv6
+// network is usable.
+func Hosts(network *net.IPNet) HostRange {
+	ones, bits := network.Mask.Size()
+	first, last := firstAndLast(network)
+
+	hosts := HostRange{
+		Network: intToIP(first, bits),
+		First:   intToIP(first, bits),
+		Last:    intToIP(last, bits),
+	}
+
+	if bits == 32 && ones <= 30 {
+		hosts.Broadcast = intToIP(last, bits)
+		hosts.First = intToIP(new(big.Int).Add(first, big.NewInt(1)), bits)
+		hosts.Last = intToIP(new(big.Int).Sub(last, big.NewInt(1)), bits)
+	}
+
+	return hosts
+}
+
+// Host returns the address at the given offset within the network. Negative offsets count back from the end of
+// the network, so -1 is the last address. An error is returned when the offset is outside of the network.
+func Host(network *net.IPNet, index int64) (net.IP, error) {
+	bits := AddressBits(network)
+	first, last := firstAndLast(network)
+
+	var address *big.Int
+	if index >= 0 {
+		address = new(big.Int).Add(first, big.NewInt(index))
+	} else {
+		address = new(big.Int).Add(last, big.NewInt(index+1))
+	}
+
+	if address.Cmp(first) < 0 || address.Cmp(last) > 0 {
+		return nil, fmt.Errorf("index %d is outside of %s, which contains %s addresses", index, network, AddressCount(network))
+	}
+
+	return intToIP(address, bits), nil
+}
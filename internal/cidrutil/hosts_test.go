@@ -0,0 +1,119 @@
+package cidrutil
+
+import (
+	"net"
+	"testing"
+)
+
+func TestHosts(t *testing.T) {
+	type testData struct {
+		cidr      string
+		network   string
+		broadcast string
+		first     string
+		last      string
+	}
+	tests := []testData{
+		{
+			cidr:      "10.0.1.0/24",
+			network:   "10.0.1.0",
+			broadcast: "10.0.1.255",
+			first:     "10.0.1.1",
+			last:      "10.0.1.254",
+		},
+		{
+			cidr:      "10.0.1.4/30",
+			network:   "10.0.1.4",
+			broadcast: "10.0.1.7",
+			first:     "10.0.1.5",
+			last:      "10.0.1.6",
+		},
+		{
+			cidr:    "10.0.1.4/31",
+			network: "10.0.1.4",
+			first:   "10.0.1.4",
+			last:    "10.0.1.5",
+		},
+		{
+			cidr:    "10.0.1.4/32",
+			network: "10.0.1.4",
+			first:   "10.0.1.4",
+			last:    "10.0.1.4",
+		},
+		{
+			cidr:    "fd00:0:0:1::/64",
+			network: "fd00:0:0:1::",
+			first:   "fd00:0:0:1::",
+			last:    "fd00::1:ffff:ffff:ffff:ffff",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.cidr, func(t *testing.T) {
+			_, network, err := net.ParseCIDR(tc.cidr)
+			if err != nil {
+				t.Fatalf("unable to parse %s: %s", tc.cidr, err)
+			}
+
+			got := Hosts(network)
+			if got.Network.String() != tc.network {
+				t.Errorf("network: got %v, want %v", got.Network, tc.network)
+			}
+			if tc.broadcast == "" && got.Broadcast != nil {
+				t.Errorf("broadcast: got %v, want nil", got.Broadcast)
+			}
+			if tc.broadcast != "" && got.Broadcast.String() != tc.broadcast {
+				t.Errorf("broadcast: got %v, want %v", got.Broadcast, tc.broadcast)
+			}
+			if got.First.String() != tc.first {
+				t.Errorf("first: got %v, want %v", got.First, tc.first)
+			}
+			if got.Last.String() != tc.last {
+				t.Errorf("last: got %v, want %v", got.Last, tc.last)
+			}
+		})
+	}
+}
+
+func TestHost(t *testing.T) {
+	type testData struct {
+		cidr    string
+		index   int64
+		want    string
+		wantErr bool
+	}
+	tests := []testData{
+		{cidr: "10.0.0.0/24", index: 0, want: "10.0.0.0"},
+		{cidr: "10.0.0.0/24", index: 5, want: "10.0.0.5"},
+		{cidr: "10.0.0.0/24", index: 255, want: "10.0.0.255"},
+		{cidr: "10.0.0.0/24", index: -1, want: "10.0.0.255"},
+		{cidr: "10.0.0.0/24", index: -256, want: "10.0.0.0"},
+		{cidr: "10.0.0.0/24", index: 256, wantErr: true},
+		{cidr: "10.0.0.0/24", index: -257, wantErr: true},
+		{cidr: "fd00::/64", index: -1, want: "fd00::ffff:ffff:ffff:ffff"},
+		{cidr: "fd00::/64", index: 16, want: "fd00::10"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.cidr, func(t *testing.T) {
+			_, network, err := net.ParseCIDR(tc.cidr)
+			if err != nil {
+				t.Fatalf("unable to parse %s: %s", tc.cidr, err)
+			}
+
+			got, err := Host(network, tc.index)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package cidrutil
+
+import (
+	"math/big"
+	"net"
+	"sort"
+)
+
+// interval is an inclusive range of addresses represented as integers.
+type interval struct {
+	first *big.Int
+	last  *big.Int
+}
+
+// size returns the number of addresses in the interval.
+func (i interval) size() *big.Int {
+	size := new(big.Int).Sub(i.last, i.first)
+	return size.Add(size, big.NewInt(1))
+}
+
+// freeIntervals returns the ranges of addresses within from that are not covered by any of the used networks,
+// in ascending order. Used networks of a different address family or outside of from are ignored.
+func freeIntervals(from *net.IPNet, used []*net.IPNet) []interval {
+	first, last := firstAndLast(from)
+
+	blocked := make([]interval, 0, len(used))
+	for _, u := range used {
+		if AddressBits(u) != AddressBits(from) || !Overlaps(from, u) {
+			continue
+		}
+		usedFirst, usedLast := firstAndLast(u)
+		blocked = append(blocked, interval{first: maxInt(usedFirst, first), last: minInt(usedLast, last)})
+	}
+	sort.Slice(blocked, func(i, j int) bool {
+		return blocked[i].first.Cmp(blocked[j].first) < 0
+	})
+
+	free := []interval{}
+	next := new(big.Int).Set(first)
+	for _, b := range blocked {
+		if b.first.Cmp(next) > 0 {
+			free = append(free, interval{first: next, last: new(big.Int).Sub(b.first, big.NewInt(1))})
+		}
+		if end := new(big.Int).Add(b.last, big.NewInt(1)); end.Cmp(next) > 0 {
+			next = end
+		}
+	}
+	if next.Cmp(last) <= 0 {
+		free = append(free, interval{first: next, last: last})
+	}
+
+	return free
+}
+
+// alignUp rounds address up to the next multiple of size.
+func alignUp(address *big.Int, size *big.Int) *big.Int {
+	aligned := new(big.Int).Add(address, size)
+	aligned.Sub(aligned, big.NewInt(1))
+	aligned.Div(aligned, size)
+	return aligned.Mul(aligned, size)
+}
+
+// alignDown rounds address down to the previous multiple of size.
+func alignDown(address *big.Int, size *big.Int) *big.Int {
+	aligned := new(big.Int).Div(address, size)
+	return aligned.Mul(aligned, size)
+}
+
+// alignedStarts returns the number of blocks of the given size that fit within the interval when each block must
+// start on a multiple of step, which is at least size.
+func alignedStarts(i interval, size *big.Int, step *big.Int) *big.Int {
+	// The last address a block may start at and still fit within the interval.
+	lastStart := new(big.Int).Add(i.last, big.NewInt(1))
+	lastStart.Sub(lastStart, size)
+	firstStart := alignUp(i.first, step)
+	if lastStart.Cmp(firstStart) < 0 {
+		return new(big.Int)
+	}
+	count := lastStart.Sub(lastStart, firstStart)
+	count.Div(count, step)
+	return count.Add(count, big.NewInt(1))
+}
+
+func maxInt(a *big.Int, b *big.Int) *big.Int {
+	if a.Cmp(b) > 0 {
+		return a
+	}
+	return b
+}
+
+func minInt(a *big.Int, b *big.Int) *big.Int {
+	if a.Cmp(b) < 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,332 @@
+package cidrutil
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"strings"
+)
+
+// AddressBits returns the number of bits in an address of the network's family (32 for IPv4, 128 for IPv6).
+func AddressBits(network *net.IPNet) int {
+	_, bits := network.Mask.Size()
+	return bits
+}
+
+// ipToInt converts an IP address to its integer representation.
+func ipToInt(ip net.IP) *big.Int {
+	if v4 := ip.To4(); v4 != nil {
+		return new(big.Int).SetBytes(v4)
+	}
+	return new(big.Int).SetBytes(ip.To16())
+}
+
+// intToIP converts an integer back into an IP address with the given number of address bits.
+func intToIP(i *big.Int, bits int) net.IP {
+	ip := make(net.IP, bits/8)
+	i.FillBytes(ip)
+	return ip
+}
+
+// IPToInt returns the integer representation of an IP address. IPv4 addresses, including IPv4-mapped IPv6
+// addresses, are converted as 32-bit values.
+func IPToInt(ip net.IP) *big.Int {
+	return ipToInt(ip)
+}
+
+// IntToIP converts an integer into an IP address of the given version (4 or 6), returning an error when the integer
+// is negative or too large for the version.
+func IntToIP(i *big.Int, version int) (net.IP, error) {
+	var bits int
+	switch version {
+	case 4:
+		bits = 32
+	case 6:
+		bits = 128
+	default:
+		return nil, fmt.Errorf("version must be 4 or 6, got %d", version)
+	}
+
+	if i.Sign() < 0 || i.BitLen() > bits {
+		return nil, fmt.Errorf("%s is outside of the range of IPv%d addresses (0 to %s)", i, version, new(big.Int).Sub(blockSize(0, bits), big.NewInt(1)))
+	}
+
+	return intToIP(i, bits), nil
+}
+
+// blockSize returns the number of addresses in a block with the given prefix length.
+func blockSize(prefixLen int, bits int) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(bits-prefixLen))
+}
+
+// firstAndLast returns the first and last addresses of the network as integers.
+func firstAndLast(network *net.IPNet) (*big.Int, *big.Int) {
+	ones, bits := network.Mask.Size()
+	first := ipToInt(network.IP.Mask(network.Mask))
+	last := new(big.Int).Add(first, blockSize(ones, bits))
+	return first, last.Sub(last, big.NewInt(1))
+}
+
+// Overlaps reports whether two networks share any addresses. Networks of different address families never overlap.
+func Overlaps(a *net.IPNet, b *net.IPNet) bool {
+	return AddressBits(a) == AddressBits(b) && (a.Contains(b.IP) || b.Contains(a.IP))
+}
+
+// AddressCount returns the total number of addresses in the network.
+func AddressCount(network *net.IPNet) *big.Int {
+	ones, bits := network.Mask.Size()
+	return blockSize(ones, bits)
+}
+
+// SaturatedInt64 returns i as an int64, saturating at math.MaxInt64 when i is too large to be represented.
+func SaturatedInt64(i *big.Int) int64 {
+	if !i.IsInt64() {
+		return math.MaxInt64
+	}
+	return i.Int64()
+}
+
+// ParseNetmask converts a netmask in address notation (ex. 255.255.255.0) into its prefix length, also returning the
+// number of address bits for the netmask's family.
+func ParseNetmask(netmask string) (int, int, error) {
+	ip := net.ParseIP(netmask)
+	if ip == nil {
+		return 0, 0, fmt.Errorf("%q is not a valid netmask", netmask)
+	}
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+
+	ones, bits := net.IPMask(ip).Size()
+	if bits == 0 {
+		return 0, 0, fmt.Errorf("%q is not a valid netmask, the ones must be contiguous", netmask)
+	}
+
+	return ones, bits, nil
+}
+
+// Wildcard returns the inverse of mask in address notation (ex. 255.255.255.0 becomes 0.0.0.255), as used by the
+// wildcard masks of some ACL syntaxes.
+func Wildcard(mask net.IPMask) net.IP {
+	wildcard := make(net.IP, len(mask))
+	for i, b := range mask {
+		wildcard[i] = ^b
+	}
+	return wildcard
+}
+
+// Contains reports whether inner lies entirely within outer.
+func Contains(outer *net.IPNet, inner *net.IPNet) bool {
+	outerOnes, outerBits := outer.Mask.Size()
+	innerOnes, innerBits := inner.Mask.Size()
+	return outerBits == innerBits && outerOnes <= innerOnes && outer.Contains(inner.IP)
+}
+
+// Outside returns the networks that aren't contained within any of the from networks.
+func Outside(from []*net.IPNet, networks []*net.IPNet) []*net.IPNet {
+	outside := []*net.IPNet{}
+	for _, network := range networks {
+		contained := false
+		for _, f := range from {
+			if Contains(f, network) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			outside = append(outside, network)
+		}
+	}
+	return outside
+}
+
+// Containing returns the first of the given CIDR ranges that network is within, as it was written. Ranges that
+// don't parse are skipped, and IPv4-mapped ranges are compared as the IPv4 ranges they cover.
+func Containing(network *net.IPNet, cidrs []string) (string, bool) {
+	for _, cidr := range cidrs {
+		_, container, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if Contains(UnmapIPv4(container), network) {
+			return cidr, true
+		}
+	}
+	return "", false
+}
+
+// Grow returns network enlarged to prefixLength with the same network address, along with the used networks that
+// overlap the addresses it gains. Used networks within network are already part of it and aren't returned.
+func Grow(network *net.IPNet, prefixLength int, used []*net.IPNet) (*net.IPNet, []*net.IPNet, error) {
+	ones, bits := network.Mask.Size()
+	if prefixLength >= ones {
+		return nil, nil, fmt.Errorf("a /%d is not larger than %s", prefixLength, network)
+	}
+
+	mask := net.CIDRMask(prefixLength, bits)
+	grown := &net.IPNet{IP: network.IP.Mask(mask), Mask: mask}
+	if !grown.IP.Equal(network.IP) {
+		return nil, nil, fmt.Errorf("%s can't grow to a /%d in place because it doesn't start on a /%d boundary, the /%d containing it is %s", network, prefixLength, prefixLength, prefixLength, grown)
+	}
+
+	var overlaps []*net.IPNet
+	for _, u := range used {
+		if Overlaps(grown, u) && !Contains(network, u) {
+			overlaps = append(overlaps, u)
+		}
+	}
+	return grown, overlaps, nil
+}
+
+// privateNetworks are the RFC 1918 IPv4 ranges and the IPv6 unique local range.
+var privateNetworks = []*net.IPNet{
+	{IP: net.IP{10, 0, 0, 0}, Mask: net.CIDRMask(8, 32)},
+	{IP: net.IP{172, 16, 0, 0}, Mask: net.CIDRMask(12, 32)},
+	{IP: net.IP{192, 168, 0, 0}, Mask: net.CIDRMask(16, 32)},
+	{IP: net.IP{0xfc, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Mask: net.CIDRMask(7, 128)},
+}
+
+// IsPrivate reports whether network lies entirely within the RFC 1918 ranges for IPv4, or the unique local range
+// fc00::/7 for IPv6. A network that only partly overlaps them (ex. 172.0.0.0/8) is not private.
+func IsPrivate(network *net.IPNet) bool {
+	for _, private := range privateNetworks {
+		if Contains(private, network) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIPv4Mapped reports whether network is an IPv4-mapped IPv6 network (ex. ::ffff:10.0.0.0/120). net.ParseCIDR
+// reads these as IPv6 networks with 128 bit masks, even though their addresses are IPv4 addresses.
+func IsIPv4Mapped(network *net.IPNet) bool {
+	return AddressBits(network) == 128 && network.IP.To4() != nil
+}
+
+// UnmapIPv4 returns the IPv4 network covering the same addresses as the IPv4-mapped IPv6 network (ex. 10.0.0.0/24
+// for ::ffff:10.0.0.0/120). Any other network is returned unchanged.
+func UnmapIPv4(network *net.IPNet) *net.IPNet {
+	if !IsIPv4Mapped(network) {
+		return network
+	}
+	ones, _ := network.Mask.Size()
+	return &net.IPNet{IP: network.IP.To4(), Mask: net.CIDRMask(ones-96, 32)}
+}
+
+// CommonSupernet returns the smallest network that contains both a and b, found from the longest common prefix of
+// their network addresses, limited to the shorter of their prefix lengths. An error is returned when a and b are of
+// different address families.
+func CommonSupernet(a *net.IPNet, b *net.IPNet) (*net.IPNet, error) {
+	aOnes, bits := a.Mask.Size()
+	bOnes, bBits := b.Mask.Size()
+	if bits != bBits {
+		return nil, fmt.Errorf("%s and %s are of different address families", a, b)
+	}
+
+	aFirst, _ := firstAndLast(a)
+	bFirst, _ := firstAndLast(b)
+	prefixLength := bits - new(big.Int).Xor(aFirst, bFirst).BitLen()
+	if aOnes < prefixLength {
+		prefixLength = aOnes
+	}
+	if bOnes < prefixLength {
+		prefixLength = bOnes
+	}
+
+	mask := net.CIDRMask(prefixLength, bits)
+	return &net.IPNet{IP: intToIP(aFirst, bits).Mask(mask), Mask: mask}, nil
+}
+
+// FirstSubnet returns the first block with the given prefix length within network.
+func FirstSubnet(network *net.IPNet, prefixLength int) *net.IPNet {
+	bits := AddressBits(network)
+	first, _ := firstAndLast(network)
+	return &net.IPNet{IP: intToIP(first, bits), Mask: net.CIDRMask(prefixLength, bits)}
+}
+
+// FirstSubnets returns the fewest networks covering the first count blocks with the given prefix length within
+// network, or all of network when it holds fewer blocks than count.
+func FirstSubnets(network *net.IPNet, prefixLength int, count int64) []*net.IPNet {
+	bits := AddressBits(network)
+	first, last := firstAndLast(network)
+	if count <= 0 {
+		return []*net.IPNet{}
+	}
+
+	end := new(big.Int).Mul(blockSize(prefixLength, bits), big.NewInt(count))
+	end.Add(end, first)
+	end.Sub(end, big.NewInt(1))
+	return intervalToNetworks(interval{first: first, last: minInt(end, last)}, bits)
+}
+
+// LastSubnet returns the last block with the given prefix length within network.
+func LastSubnet(network *net.IPNet, prefixLength int) *net.IPNet {
+	bits := AddressBits(network)
+	_, last := firstAndLast(network)
+	start := new(big.Int).Sub(last, blockSize(prefixLength, bits))
+	start.Add(start, big.NewInt(1))
+	return &net.IPNet{IP: intToIP(start, bits), Mask: net.CIDRMask(prefixLength, bits)}
+}
+
+// Before returns the fewest networks covering the addresses within network that come before address, in ascending
+// order. It is empty when address is at or before the start of network, and all of network when address is past its
+// end.
+func Before(network *net.IPNet, address net.IP) []*net.IPNet {
+	bits := AddressBits(network)
+	first, last := firstAndLast(network)
+	end := new(big.Int).Sub(ipToInt(address), big.NewInt(1))
+	if end.Cmp(first) < 0 {
+		return []*net.IPNet{}
+	}
+	return intervalToNetworks(interval{first: first, last: minInt(end, last)}, bits)
+}
+
+// SplitAt divides network at address, returning the fewest networks covering the addresses before address (lower)
+// and those from address to the end of network (upper), each in ascending order. lower is empty when address is the
+// first address of network. An error is returned when address isn't within network.
+func SplitAt(network *net.IPNet, address net.IP) ([]*net.IPNet, []*net.IPNet, error) {
+	if !network.Contains(address) {
+		return nil, nil, fmt.Errorf("%s is not within %s", address, network)
+	}
+
+	_, last := firstAndLast(network)
+	upper := intervalToNetworks(interval{first: ipToInt(address), last: last}, AddressBits(network))
+	return Before(network, address), upper, nil
+}
+
+// ExpandedString returns ip in its fully expanded form when it is an IPv6 address, with all eight groups written as
+// four lowercase hex digits (ex. 2001:0db8:0000:0000:0000:0000:0000:0001). IPv4 addresses are returned in their
+// usual dotted decimal form.
+func ExpandedString(ip net.IP) string {
+	if ip.To4() != nil || len(ip) != net.IPv6len {
+		return ip.String()
+	}
+
+	groups := make([]string, net.IPv6len/2)
+	for i := range groups {
+		groups[i] = fmt.Sprintf("%02x%02x", ip[2*i], ip[2*i+1])
+	}
+	return strings.Join(groups, ":")
+}
+
+// ParseCIDROrIP parses either CIDR notation or a bare IP address, which is treated as a network containing only
+// that address (ex. 10.0.0.1 becomes 10.0.0.1/32).
+func ParseCIDROrIP(s string) (*net.IPNet, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("%q is not a valid IP address or CIDR", s)
+		}
+		if v4 := ip.To4(); v4 != nil {
+			ip = v4
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}, nil
+	}
+
+	_, network, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid IP address or CIDR", s)
+	}
+	return network, nil
+}
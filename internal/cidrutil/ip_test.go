@@ -0,0 +1,559 @@
+package cidrutil
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAddressCount(t *testing.T) {
+	type testData struct {
+		cidr      string
+		want      string
+		saturated int64
+	}
+	tests := []testData{
+		{cidr: "10.0.0.0/24", want: "256", saturated: 256},
+		{cidr: "10.0.0.0/32", want: "1", saturated: 1},
+		{cidr: "0.0.0.0/1", want: "2147483648", saturated: 2147483648},
+		{cidr: "fd00::/64", want: "18446744073709551616", saturated: math.MaxInt64},
+		{cidr: "fd00::/66", want: "4611686018427387904", saturated: 4611686018427387904},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.cidr, func(t *testing.T) {
+			_, network, err := net.ParseCIDR(tc.cidr)
+			if err != nil {
+				t.Fatalf("unable to parse %s: %s", tc.cidr, err)
+			}
+
+			got := AddressCount(network)
+			if got.String() != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+			if saturated := SaturatedInt64(got); saturated != tc.saturated {
+				t.Errorf("saturated: got %v, want %v", saturated, tc.saturated)
+			}
+		})
+	}
+}
+
+func TestParseNetmask(t *testing.T) {
+	type testData struct {
+		netmask string
+		ones    int
+		bits    int
+		wantErr bool
+	}
+	tests := []testData{
+		{netmask: "255.255.255.0", ones: 24, bits: 32},
+		{netmask: "255.255.255.255", ones: 32, bits: 32},
+		{netmask: "255.240.0.0", ones: 12, bits: 32},
+		{netmask: "ffff:ffff:ffff:ffff::", ones: 64, bits: 128},
+		{netmask: "255.0.255.0", wantErr: true},
+		{netmask: "not-a-netmask", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.netmask, func(t *testing.T) {
+			ones, bits, err := ParseNetmask(tc.netmask)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got /%d", ones)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if ones != tc.ones || bits != tc.bits {
+				t.Errorf("got /%d of %d bits, want /%d of %d bits", ones, bits, tc.ones, tc.bits)
+			}
+		})
+	}
+}
+
+func TestWildcard(t *testing.T) {
+	type testData struct {
+		prefixLength int
+		bits         int
+		want         string
+	}
+	tests := []testData{
+		{prefixLength: 24, bits: 32, want: "0.0.0.255"},
+		{prefixLength: 20, bits: 32, want: "0.0.15.255"},
+		{prefixLength: 32, bits: 32, want: "0.0.0.0"},
+		{prefixLength: 0, bits: 32, want: "255.255.255.255"},
+		{prefixLength: 64, bits: 128, want: "::ffff:ffff:ffff:ffff"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := Wildcard(net.CIDRMask(tc.prefixLength, tc.bits)).String(); got != tc.want {
+				t.Errorf("Wildcard(/%d) = %s, want %s", tc.prefixLength, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	type testData struct {
+		outer string
+		inner string
+		want  bool
+	}
+	tests := []testData{
+		{outer: "10.0.0.0/16", inner: "10.0.1.0/24", want: true},
+		{outer: "10.0.0.0/16", inner: "10.0.0.0/16", want: true},
+		{outer: "10.0.0.0/16", inner: "10.0.0.0/8", want: false},
+		{outer: "10.0.0.0/16", inner: "10.1.0.0/24", want: false},
+		{outer: "fd00::/56", inner: "fd00:0:0:1::/64", want: true},
+		{outer: "::/0", inner: "10.0.0.0/24", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.outer+" "+tc.inner, func(t *testing.T) {
+			_, outer, _ := net.ParseCIDR(tc.outer)
+			_, inner, _ := net.ParseCIDR(tc.inner)
+			if got := Contains(outer, inner); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOutside(t *testing.T) {
+	from := mustParseCIDRs(t, "10.0.0.0/16", "fd00::/56")
+	networks := mustParseCIDRs(t, "10.0.1.0/24", "10.0.0.0/8", "10.1.0.0/24", "fd00:0:0:1::/64", "fd00:0:0:100::/64")
+
+	got := Strings(Outside(from, networks))
+	want := []string{"10.0.0.0/8", "10.1.0.0/24", "fd00:0:0:100::/64"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestContaining(t *testing.T) {
+	tests := []struct {
+		name    string
+		network string
+		cidrs   []string
+		want    string
+		wantOk  bool
+	}{
+		{name: "first match", network: "10.0.1.0/24", cidrs: []string{"10.1.0.0/16", "10.0.0.0/16", "10.0.0.0/8"}, want: "10.0.0.0/16", wantOk: true},
+		{name: "written form is kept", network: "10.0.1.0/24", cidrs: []string{"10.0.3.5/16"}, want: "10.0.3.5/16", wantOk: true},
+		{name: "ipv4-mapped", network: "10.0.1.0/24", cidrs: []string{"::ffff:10.0.0.0/112"}, want: "::ffff:10.0.0.0/112", wantOk: true},
+		{name: "malformed skipped", network: "10.0.1.0/24", cidrs: []string{"not-a-cidr", "10.0.0.0/16"}, want: "10.0.0.0/16", wantOk: true},
+		{name: "none", network: "10.0.1.0/24", cidrs: []string{"10.1.0.0/16"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := Containing(mustParseCIDRs(t, tc.network)[0], tc.cidrs)
+			if got != tc.want || ok != tc.wantOk {
+				t.Errorf("got %q, %v, want %q, %v", got, ok, tc.want, tc.wantOk)
+			}
+		})
+	}
+}
+
+func TestGrow(t *testing.T) {
+	tests := []struct {
+		name         string
+		result       string
+		prefixLength int
+		used         []string
+		want         string
+		wantOverlaps []string
+		wantErr      string
+	}{
+		{name: "free", result: "10.1.2.0/24", prefixLength: 23, used: []string{"10.1.0.0/23"}, want: "10.1.2.0/23"},
+		{name: "used within result", result: "10.1.2.0/24", prefixLength: 23, used: []string{"10.1.2.0/24", "10.1.2.128/25"}, want: "10.1.2.0/23"},
+		{name: "unaligned", result: "10.1.2.0/24", prefixLength: 22, wantErr: "doesn't start on a /22 boundary"},
+		{name: "additional addresses used", result: "10.1.0.0/24", prefixLength: 22, used: []string{"10.1.3.128/25", "10.2.0.0/16"}, want: "10.1.0.0/22", wantOverlaps: []string{"10.1.3.128/25"}},
+		{name: "not larger", result: "10.1.0.0/24", prefixLength: 24, wantErr: "not larger"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			grown, overlaps, err := Grow(mustParseCIDRs(t, tc.result)[0], tc.prefixLength, mustParseCIDRs(t, tc.used...))
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if grown.String() != tc.want {
+				t.Errorf("got %s, want %s", grown, tc.want)
+			}
+			if got := strings.Join(Strings(overlaps), ","); got != strings.Join(tc.wantOverlaps, ",") {
+				t.Errorf("got overlaps %s, want %s", got, strings.Join(tc.wantOverlaps, ","))
+			}
+		})
+	}
+}
+
+func TestIsPrivate(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want bool
+	}{
+		{cidr: "10.0.0.0/8", want: true},
+		{cidr: "10.255.255.255/32", want: true},
+		{cidr: "11.0.0.0/8", want: false},
+		{cidr: "172.15.0.0/16", want: false},
+		{cidr: "172.16.0.0/16", want: true},
+		{cidr: "172.31.255.0/24", want: true},
+		{cidr: "172.32.0.0/16", want: false},
+		{cidr: "172.0.0.0/8", want: false},
+		{cidr: "192.168.1.0/24", want: true},
+		{cidr: "192.169.0.0/16", want: false},
+		{cidr: "0.0.0.0/0", want: false},
+		{cidr: "fd00::/8", want: true},
+		{cidr: "fc00::/7", want: true},
+		{cidr: "fe80::/10", want: false},
+		{cidr: "2001:db8::/32", want: false},
+		{cidr: "::/0", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.cidr, func(t *testing.T) {
+			_, network, _ := net.ParseCIDR(tc.cidr)
+			if got := IsPrivate(network); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	type testData struct {
+		a    string
+		b    string
+		want bool
+	}
+	tests := []testData{
+		{a: "10.0.0.0/25", b: "10.0.0.128/25", want: false},
+		{a: "10.0.0.0/24", b: "10.0.0.128/25", want: true},
+		{a: "10.0.0.128/25", b: "10.0.0.0/16", want: true},
+		{a: "10.0.0.255/32", b: "10.0.1.0/32", want: false},
+		{a: "fd00::/64", b: "fd00:0:0:1::/64", want: false},
+		{a: "::ffff:0:0/96", b: "10.0.0.0/8", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			_, a, _ := net.ParseCIDR(tc.a)
+			_, b, _ := net.ParseCIDR(tc.b)
+			if got := Overlaps(a, b); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnmapIPv4(t *testing.T) {
+	tests := []struct {
+		cidr       string
+		wantMapped bool
+		want       string
+	}{
+		{cidr: "::ffff:10.0.0.0/120", wantMapped: true, want: "10.0.0.0/24"},
+		{cidr: "::ffff:10.1.2.3/128", wantMapped: true, want: "10.1.2.3/32"},
+		{cidr: "::ffff:0.0.0.0/96", wantMapped: true, want: "0.0.0.0/0"},
+		{cidr: "10.0.0.0/24", wantMapped: false, want: "10.0.0.0/24"},
+		{cidr: "fd00::/64", wantMapped: false, want: "fd00::/64"},
+		{cidr: "::/0", wantMapped: false, want: "::/0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.cidr, func(t *testing.T) {
+			_, network, err := net.ParseCIDR(tc.cidr)
+			if err != nil {
+				t.Fatalf("unable to parse %s: %s", tc.cidr, err)
+			}
+			if got := IsIPv4Mapped(network); got != tc.wantMapped {
+				t.Errorf("IsIPv4Mapped got %v, want %v", got, tc.wantMapped)
+			}
+			if got := UnmapIPv4(network).String(); got != tc.want {
+				t.Errorf("UnmapIPv4 got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCommonSupernet(t *testing.T) {
+	type testData struct {
+		a       string
+		b       string
+		want    string
+		wantErr bool
+	}
+	tests := []testData{
+		{a: "10.0.0.0/24", b: "10.0.1.0/24", want: "10.0.0.0/23"},
+		{a: "10.0.1.0/24", b: "10.0.0.0/24", want: "10.0.0.0/23"},
+		{a: "10.0.0.0/24", b: "10.0.2.0/24", want: "10.0.0.0/22"},
+		{a: "10.0.0.0/16", b: "10.0.1.0/24", want: "10.0.0.0/16"},
+		{a: "10.0.0.0/24", b: "10.0.0.0/24", want: "10.0.0.0/24"},
+		{a: "10.0.0.0/8", b: "192.168.0.0/16", want: "0.0.0.0/0"},
+		{a: "fd00::/64", b: "fd00:0:0:1::/64", want: "fd00::/63"},
+		{a: "10.0.0.0/24", b: "fd00::/64", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			_, a, _ := net.ParseCIDR(tc.a)
+			_, b, _ := net.ParseCIDR(tc.b)
+			got, err := CommonSupernet(a, b)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFirstAndLastSubnet(t *testing.T) {
+	type testData struct {
+		cidr         string
+		prefixLength int
+		first        string
+		last         string
+	}
+	tests := []testData{
+		{cidr: "10.0.1.0/24", prefixLength: 26, first: "10.0.1.0/26", last: "10.0.1.192/26"},
+		{cidr: "10.0.1.0/24", prefixLength: 24, first: "10.0.1.0/24", last: "10.0.1.0/24"},
+		{cidr: "fd00::/56", prefixLength: 64, first: "fd00::/64", last: "fd00:0:0:ff::/64"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.cidr, func(t *testing.T) {
+			_, network, _ := net.ParseCIDR(tc.cidr)
+			if got := FirstSubnet(network, tc.prefixLength); got.String() != tc.first {
+				t.Errorf("first: got %v, want %v", got, tc.first)
+			}
+			if got := LastSubnet(network, tc.prefixLength); got.String() != tc.last {
+				t.Errorf("last: got %v, want %v", got, tc.last)
+			}
+		})
+	}
+}
+
+func TestFirstSubnets(t *testing.T) {
+	type testData struct {
+		network      string
+		prefixLength int
+		count        int64
+		want         []string
+	}
+	tests := []testData{
+		{network: "10.0.0.0/16", prefixLength: 24, count: 0, want: []string{}},
+		{network: "10.0.0.0/16", prefixLength: 24, count: 1, want: []string{"10.0.0.0/24"}},
+		{network: "10.0.0.0/16", prefixLength: 24, count: 3, want: []string{"10.0.0.0/23", "10.0.2.0/24"}},
+		{network: "10.0.0.0/16", prefixLength: 24, count: 256, want: []string{"10.0.0.0/16"}},
+		{network: "10.0.0.0/16", prefixLength: 24, count: 1000, want: []string{"10.0.0.0/16"}},
+		{network: "fd00::/48", prefixLength: 64, count: 1 << 40, want: []string{"fd00::/48"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s %d", tc.network, tc.count), func(t *testing.T) {
+			_, network, _ := net.ParseCIDR(tc.network)
+			if got := Strings(FirstSubnets(network, tc.prefixLength, tc.count)); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBefore(t *testing.T) {
+	type testData struct {
+		network string
+		address string
+		want    []string
+	}
+	tests := []testData{
+		{network: "10.0.0.0/16", address: "10.0.0.0", want: []string{}},
+		{network: "10.0.0.0/16", address: "9.255.255.255", want: []string{}},
+		{network: "10.0.0.0/16", address: "10.0.10.0", want: []string{"10.0.0.0/21", "10.0.8.0/23"}},
+		{network: "10.0.0.0/16", address: "10.0.0.1", want: []string{"10.0.0.0/32"}},
+		{network: "10.0.0.0/16", address: "10.1.0.0", want: []string{"10.0.0.0/16"}},
+		{network: "fd00::/48", address: "fd00:0:0:8000::", want: []string{"fd00::/49"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s %s", tc.network, tc.address), func(t *testing.T) {
+			_, network, _ := net.ParseCIDR(tc.network)
+			if got := Strings(Before(network, net.ParseIP(tc.address))); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSplitAt(t *testing.T) {
+	type testData struct {
+		network   string
+		address   string
+		wantLower []string
+		wantUpper []string
+		wantErr   bool
+	}
+	tests := []testData{
+		{network: "10.0.0.0/24", address: "10.0.0.128", wantLower: []string{"10.0.0.0/25"}, wantUpper: []string{"10.0.0.128/25"}},
+		{network: "10.0.0.0/24", address: "10.0.0.0", wantLower: []string{}, wantUpper: []string{"10.0.0.0/24"}},
+		{network: "10.0.0.0/24", address: "10.0.0.255", wantLower: []string{"10.0.0.0/25", "10.0.0.128/26", "10.0.0.192/27", "10.0.0.224/28", "10.0.0.240/29", "10.0.0.248/30", "10.0.0.252/31", "10.0.0.254/32"}, wantUpper: []string{"10.0.0.255/32"}},
+		{network: "10.0.0.0/24", address: "10.0.0.100", wantLower: []string{"10.0.0.0/26", "10.0.0.64/27", "10.0.0.96/30"}, wantUpper: []string{"10.0.0.100/30", "10.0.0.104/29", "10.0.0.112/28", "10.0.0.128/25"}},
+		{network: "fd00::/64", address: "fd00::8000:0:0:0", wantLower: []string{"fd00::/65"}, wantUpper: []string{"fd00::8000:0:0:0/65"}},
+		{network: "10.0.0.0/24", address: "10.0.1.0", wantErr: true},
+		{network: "10.0.0.0/24", address: "fd00::", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s %s", tc.network, tc.address), func(t *testing.T) {
+			_, network, _ := net.ParseCIDR(tc.network)
+			lower, upper, err := SplitAt(network, net.ParseIP(tc.address))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v %v", lower, upper)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := Strings(lower); !reflect.DeepEqual(got, tc.wantLower) {
+				t.Errorf("lower got %v, want %v", got, tc.wantLower)
+			}
+			if got := Strings(upper); !reflect.DeepEqual(got, tc.wantUpper) {
+				t.Errorf("upper got %v, want %v", got, tc.wantUpper)
+			}
+		})
+	}
+}
+
+func TestParseCIDROrIP(t *testing.T) {
+	type testData struct {
+		input   string
+		want    string
+		wantErr bool
+	}
+	tests := []testData{
+		{input: "10.0.1.0/24", want: "10.0.1.0/24"},
+		{input: "10.0.1.5/24", want: "10.0.1.0/24"},
+		{input: "10.0.1.5", want: "10.0.1.5/32"},
+		{input: "fd00::1", want: "fd00::1/128"},
+		{input: "fd00::/64", want: "fd00::/64"},
+		{input: "10.0.1", wantErr: true},
+		{input: "10.0.1.0/33", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParseCIDROrIP(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExpandedString(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{ip: "2001:db8::1", want: "2001:0db8:0000:0000:0000:0000:0000:0001"},
+		{ip: "FD00::", want: "fd00:0000:0000:0000:0000:0000:0000:0000"},
+		{ip: "::", want: "0000:0000:0000:0000:0000:0000:0000:0000"},
+		{ip: "10.0.0.1", want: "10.0.0.1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.ip, func(t *testing.T) {
+			if got := ExpandedString(net.ParseIP(tc.ip)); got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIPToInt(t *testing.T) {
+	type testData struct {
+		ip      string
+		version int
+		want    string
+	}
+	tests := []testData{
+		{ip: "0.0.0.0", version: 4, want: "0"},
+		{ip: "10.0.0.1", version: 4, want: "167772161"},
+		{ip: "255.255.255.255", version: 4, want: "4294967295"},
+		{ip: "::1", version: 6, want: "1"},
+		{ip: "fd00::", version: 6, want: "336294682933583715844663186250927177728"},
+		{ip: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", version: 6, want: "340282366920938463463374607431768211455"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.ip, func(t *testing.T) {
+			got := IPToInt(net.ParseIP(tc.ip))
+			if got.String() != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+
+			ip, err := IntToIP(got, tc.version)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !ip.Equal(net.ParseIP(tc.ip)) {
+				t.Errorf("round trip: got %v, want %v", ip, tc.ip)
+			}
+		})
+	}
+}
+
+func TestIntToIPErrors(t *testing.T) {
+	type testData struct {
+		name    string
+		i       string
+		version int
+	}
+	tests := []testData{
+		{name: "negative", i: "-1", version: 4},
+		{name: "too large for ipv4", i: "4294967296", version: 4},
+		{name: "too large for ipv6", i: "340282366920938463463374607431768211456", version: 6},
+		{name: "invalid version", i: "1", version: 5},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			i, _ := new(big.Int).SetString(tc.i, 10)
+			if got, err := IntToIP(i, tc.version); err == nil {
+				t.Errorf("expected error, got %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package cidrutil
+
+import (
+	"net"
+)
+
+// Normalize masks each network down to its network address (ex. 10.0.3.5/24 becomes 10.0.3.0/24) and removes
+// exact duplicates, keeping the first occurrence of each network in its original order.
+func Normalize(networks []*net.IPNet) []*net.IPNet {
+	seen := make(map[string]bool, len(networks))
+	normalized := make([]*net.IPNet, 0, len(networks))
+	for _, network := range networks {
+		n := &net.IPNet{IP: network.IP.Mask(network.Mask), Mask: network.Mask}
+		if seen[n.String()] {
+			continue
+		}
+		seen[n.String()] = true
+		normalized = append(normalized, n)
+	}
+	return normalized
+}
+
+// Strings returns the CIDR notation of each network.
+func Strings(networks []*net.IPNet) []string {
+	strs := make([]string, len(networks))
+	for i, network := range networks {
+		strs[i] = network.String()
+	}
+	return strs
+}
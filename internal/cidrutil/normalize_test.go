@@ -0,0 +1,24 @@
+package cidrutil
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	networks := []*net.IPNet{
+		{IP: net.ParseIP("10.0.3.5").To4(), Mask: net.CIDRMask(24, 32)},
+		{IP: net.ParseIP("10.0.3.0").To4(), Mask: net.CIDRMask(24, 32)},
+		{IP: net.ParseIP("10.0.3.0").To4(), Mask: net.CIDRMask(25, 32)},
+		{IP: net.ParseIP("10.0.1.0").To4(), Mask: net.CIDRMask(24, 32)},
+		{IP: net.ParseIP("fd00::1"), Mask: net.CIDRMask(64, 128)},
+		{IP: net.ParseIP("fd00::"), Mask: net.CIDRMask(64, 128)},
+	}
+
+	got := Strings(Normalize(networks))
+	want := []string{"10.0.3.0/24", "10.0.3.0/25", "10.0.1.0/24", "fd00::/64"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
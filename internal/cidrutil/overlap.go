@@ -0,0 +1,57 @@
+package cidrutil
+
+import (
+	"net"
+	"sort"
+)
+
+// OverlappingPairs returns the indexes of every pair of networks that share any addresses. Each pair is ordered
+// so the lower index comes first and the pairs are sorted by index. Networks of different address families never
+// overlap.
+//
+// The networks are sorted by their first address so each network only needs to be compared with the networks
+// that start before it ends, which keeps the search at O(n log n) plus the number of overlapping pairs.
+func OverlappingPairs(networks []*net.IPNet) [][2]int {
+	type entry struct {
+		index int
+		bits  int
+		interval
+	}
+
+	entries := make([]entry, len(networks))
+	for i, network := range networks {
+		first, last := firstAndLast(network)
+		entries[i] = entry{index: i, bits: AddressBits(network), interval: interval{first: first, last: last}}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].bits != entries[j].bits {
+			return entries[i].bits < entries[j].bits
+		}
+		if c := entries[i].first.Cmp(entries[j].first); c != 0 {
+			return c < 0
+		}
+		return entries[i].index < entries[j].index
+	})
+
+	pairs := [][2]int{}
+	for i, a := range entries {
+		for _, b := range entries[i+1:] {
+			if b.bits != a.bits || b.first.Cmp(a.last) > 0 {
+				break
+			}
+			if a.index < b.index {
+				pairs = append(pairs, [2]int{a.index, b.index})
+			} else {
+				pairs = append(pairs, [2]int{b.index, a.index})
+			}
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+
+	return pairs
+}
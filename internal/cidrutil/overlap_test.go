@@ -0,0 +1,60 @@
+package cidrutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOverlappingPairs(t *testing.T) {
+	type testData struct {
+		name  string
+		cidrs []string
+		want  [][2]int
+	}
+	tests := []testData{
+		{
+			name:  "adjacent blocks don't overlap",
+			cidrs: []string{"10.0.0.0/25", "10.0.0.128/25", "10.0.1.0/24"},
+			want:  [][2]int{},
+		},
+		{
+			name:  "last address of one block is adjacent to the next",
+			cidrs: []string{"10.0.0.255/32", "10.0.1.0/32"},
+			want:  [][2]int{},
+		},
+		{
+			name:  "contained block",
+			cidrs: []string{"10.0.1.0/24", "10.0.0.0/16"},
+			want:  [][2]int{{0, 1}},
+		},
+		{
+			name:  "one block overlapping several",
+			cidrs: []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.0.0/23", "10.0.2.0/24"},
+			want:  [][2]int{{0, 2}, {1, 2}},
+		},
+		{
+			name:  "nested blocks",
+			cidrs: []string{"10.0.0.0/8", "10.0.0.0/16", "10.0.0.0/24"},
+			want:  [][2]int{{0, 1}, {0, 2}, {1, 2}},
+		},
+		{
+			name:  "duplicates",
+			cidrs: []string{"10.0.0.0/24", "10.0.0.0/24"},
+			want:  [][2]int{{0, 1}},
+		},
+		{
+			name:  "different families",
+			cidrs: []string{"0.0.0.0/0", "::/0", "fd00::/64"},
+			want:  [][2]int{{1, 2}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := OverlappingPairs(mustParseCIDRs(t, tc.cidrs...))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package cidrutil
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"net"
+)
+
+// Subnets splits network into count consecutive subnets, each newBits longer than the network's prefix.
+func Subnets(network *net.IPNet, newBits int, count int64) ([]*net.IPNet, error) {
+	ones, bits, available, err := checkNewBits(network, newBits)
+	if err != nil {
+		return nil, err
+	}
+
+	if count < 0 || big.NewInt(count).Cmp(available) > 0 {
+		return nil, fmt.Errorf("%s can only be split into %s /%d subnets, %d requested", network, available, ones+newBits, count)
+	}
+
+	first, _ := firstAndLast(network)
+	size := blockSize(ones+newBits, bits)
+	mask := net.CIDRMask(ones+newBits, bits)
+
+	subnets := make([]*net.IPNet, count)
+	start := new(big.Int).Set(first)
+	for i := range subnets {
+		subnets[i] = &net.IPNet{IP: intToIP(start, bits), Mask: mask}
+		start.Add(start, size)
+	}
+
+	return subnets, nil
+}
+
+// Subnet returns the subnet at index within network, where every subnet is newBits longer than the network's
+// prefix. Unlike Terraform's cidrsubnet, an index outside of the network is an error rather than wrapping around.
+func Subnet(network *net.IPNet, newBits int, index int64) (*net.IPNet, error) {
+	ones, bits, available, err := checkNewBits(network, newBits)
+	if err != nil {
+		return nil, err
+	}
+
+	if index < 0 || big.NewInt(index).Cmp(available) >= 0 {
+		return nil, fmt.Errorf("index %d is outside of %s, which only contains %s /%d subnets", index, network, available, ones+newBits)
+	}
+
+	first, _ := firstAndLast(network)
+	start := new(big.Int).Mul(blockSize(ones+newBits, bits), big.NewInt(index))
+	start.Add(start, first)
+
+	return &net.IPNet{IP: intToIP(start, bits), Mask: net.CIDRMask(ones+newBits, bits)}, nil
+}
+
+// CoalescedPrefixLength returns the prefix length of the smallest block that holds count subnets with the given
+// prefix length, which is negative when no block is large enough.
+func CoalescedPrefixLength(prefixLength int, count int) int {
+	return prefixLength - bits.Len(uint(count-1))
+}
+
+// checkNewBits validates adding newBits to the prefix of network, returning the network's prefix length, address
+// bits and the number of subnets of the new size that fit in it.
+func checkNewBits(network *net.IPNet, newBits int) (int, int, *big.Int, error) {
+	ones, bits := network.Mask.Size()
+	if newBits < 0 {
+		return 0, 0, nil, fmt.Errorf("new_bits must not be negative, got %d", newBits)
+	}
+	if ones+newBits > bits {
+		return 0, 0, nil, fmt.Errorf("adding %d bits to %s would exceed the %d bits in an address", newBits, network, bits)
+	}
+
+	return ones, bits, new(big.Int).Lsh(big.NewInt(1), uint(newBits)), nil
+}
@@ -0,0 +1,180 @@
+package cidrutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSubnets(t *testing.T) {
+	type testData struct {
+		name    string
+		network string
+		newBits int
+		count   int64
+		want    []string
+		wantErr bool
+	}
+	tests := []testData{
+		{
+			name:    "splits into equal subnets",
+			network: "10.0.0.0/16",
+			newBits: 2,
+			count:   4,
+			want:    []string{"10.0.0.0/18", "10.0.64.0/18", "10.0.128.0/18", "10.0.192.0/18"},
+		},
+		{
+			name:    "fewer than the maximum",
+			network: "10.0.0.0/16",
+			newBits: 8,
+			count:   3,
+			want:    []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24"},
+		},
+		{
+			name:    "zero new bits returns the network",
+			network: "10.0.0.0/16",
+			newBits: 0,
+			count:   1,
+			want:    []string{"10.0.0.0/16"},
+		},
+		{
+			name:    "ipv6",
+			network: "fd00::/48",
+			newBits: 16,
+			count:   2,
+			want:    []string{"fd00::/64", "fd00:0:0:1::/64"},
+		},
+		{
+			name:    "too many subnets",
+			network: "10.0.0.0/16",
+			newBits: 2,
+			count:   5,
+			wantErr: true,
+		},
+		{
+			name:    "prefix exceeds address bits",
+			network: "10.0.0.0/16",
+			newBits: 17,
+			count:   1,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Subnets(mustParseCIDRs(t, tc.network)[0], tc.newBits, tc.count)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(Strings(got), tc.want) {
+				t.Errorf("got %v, want %v", Strings(got), tc.want)
+			}
+		})
+	}
+}
+
+func TestSubnet(t *testing.T) {
+	type testData struct {
+		name    string
+		network string
+		newBits int
+		index   int64
+		want    string
+		wantErr bool
+	}
+	tests := []testData{
+		{
+			name:    "first subnet",
+			network: "10.0.0.0/16",
+			newBits: 8,
+			index:   0,
+			want:    "10.0.0.0/24",
+		},
+		{
+			name:    "nth subnet",
+			network: "10.0.0.0/16",
+			newBits: 8,
+			index:   5,
+			want:    "10.0.5.0/24",
+		},
+		{
+			name:    "last subnet",
+			network: "10.0.0.0/16",
+			newBits: 2,
+			index:   3,
+			want:    "10.0.192.0/18",
+		},
+		{
+			name:    "ipv6",
+			network: "fd00::/48",
+			newBits: 16,
+			index:   1,
+			want:    "fd00:0:0:1::/64",
+		},
+		{
+			name:    "index past the end doesn't wrap",
+			network: "10.0.0.0/16",
+			newBits: 2,
+			index:   4,
+			wantErr: true,
+		},
+		{
+			name:    "negative index",
+			network: "10.0.0.0/16",
+			newBits: 2,
+			index:   -1,
+			wantErr: true,
+		},
+		{
+			name:    "prefix exceeds address bits",
+			network: "10.0.0.0/16",
+			newBits: 17,
+			index:   0,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Subnet(mustParseCIDRs(t, tc.network)[0], tc.newBits, tc.index)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCoalescedPrefixLength(t *testing.T) {
+	tests := []struct {
+		prefixLength int
+		count        int
+		want         int
+	}{
+		{prefixLength: 24, count: 1, want: 24},
+		{prefixLength: 24, count: 2, want: 23},
+		{prefixLength: 24, count: 3, want: 22},
+		{prefixLength: 24, count: 4, want: 22},
+		{prefixLength: 24, count: 5, want: 21},
+		{prefixLength: 1, count: 5, want: -2},
+	}
+
+	for _, tc := range tests {
+		if got := CoalescedPrefixLength(tc.prefixLength, tc.count); got != tc.want {
+			t.Errorf("CoalescedPrefixLength(%d, %d) = %d, want %d", tc.prefixLength, tc.count, got, tc.want)
+		}
+	}
+}
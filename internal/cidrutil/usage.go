@@ -0,0 +1,119 @@
+package cidrutil
+
+import (
+	"math/big"
+	"net"
+)
+
+// Usage summarizes how much of the address space in a set of ranges is in use.
+type Usage struct {
+	// Total is the number of addresses across all of the ranges.
+	Total *big.Int
+	// Used is the number of addresses within the ranges that are covered by a used network. Addresses covered by
+	// more than one used network are only counted once.
+	Used *big.Int
+	// LargestGap is the number of addresses in the largest contiguous run of free addresses.
+	LargestGap *big.Int
+}
+
+// Utilization calculates the Usage of the from ranges given the used networks.
+func Utilization(from []*net.IPNet, used []*net.IPNet) Usage {
+	usage := Usage{
+		Total:      new(big.Int),
+		Used:       new(big.Int),
+		LargestGap: new(big.Int),
+	}
+
+	for _, f := range from {
+		total := AddressCount(f)
+		usage.Total.Add(usage.Total, total)
+
+		free := new(big.Int)
+		for _, gap := range freeIntervals(f, used) {
+			size := gap.size()
+			free.Add(free, size)
+			if size.Cmp(usage.LargestGap) > 0 {
+				usage.LargestGap = size
+			}
+		}
+		usage.Used.Add(usage.Used, total.Sub(total, free))
+	}
+
+	return usage
+}
+
+// AvailableBlocks counts the blocks of size mask, each starting on a boundary of align, that could still be
+// allocated from the ranges without overlapping the used networks or each other. Ranges that are smaller than mask
+// or of a different address family hold no blocks.
+func AvailableBlocks(from []*net.IPNet, mask *net.IPMask, align *net.IPMask, used []*net.IPNet) *big.Int {
+	count := new(big.Int)
+
+	for _, f := range from {
+		ones, bits, err := checkMask(f, mask)
+		if err != nil {
+			continue
+		}
+		alignOnes, err := checkAlign(ones, bits, align)
+		if err != nil {
+			continue
+		}
+
+		size := blockSize(ones, bits)
+		step := blockSize(alignOnes, bits)
+		for _, gap := range freeIntervals(f, used) {
+			count.Add(count, alignedStarts(gap, size, step))
+		}
+	}
+
+	return count
+}
+
+// FreeGaps counts the contiguous runs of free addresses within the ranges once the used networks are taken. The
+// searches examine one candidate block per run, so this is how much work a search of the ranges does.
+func FreeGaps(from []*net.IPNet, used []*net.IPNet) int {
+	count := 0
+	for _, f := range from {
+		count += len(freeIntervals(f, used))
+	}
+	return count
+}
+
+// Gap returns the first and last address of the contiguous run of free addresses within the ranges that contains
+// network. ok is false when network isn't entirely free within one of the ranges.
+func Gap(from []*net.IPNet, used []*net.IPNet, network *net.IPNet) (first net.IP, last net.IP, ok bool) {
+	networkFirst, networkLast := firstAndLast(network)
+	bits := AddressBits(network)
+
+	for _, f := range from {
+		if AddressBits(f) != bits || !Contains(f, network) {
+			continue
+		}
+		for _, gap := range freeIntervals(f, used) {
+			if gap.first.Cmp(networkFirst) <= 0 && gap.last.Cmp(networkLast) >= 0 {
+				return intToIP(gap.first, bits), intToIP(gap.last, bits), true
+			}
+		}
+	}
+
+	return nil, nil, false
+}
+
+// LargestFreeBlock returns the largest network within the ranges that doesn't overlap any of the used networks,
+// preferring the first one found in from order when several are the same size. nil is returned when nothing is free.
+func LargestFreeBlock(from []*net.IPNet, used []*net.IPNet) *net.IPNet {
+	var largest *net.IPNet
+	largestOnes := 0
+
+	for _, f := range from {
+		for _, gap := range freeIntervals(f, used) {
+			for _, network := range intervalToNetworks(gap, AddressBits(f)) {
+				ones, _ := network.Mask.Size()
+				if largest == nil || ones < largestOnes {
+					largest, largestOnes = network, ones
+				}
+			}
+		}
+	}
+
+	return largest
+}
@@ -0,0 +1,273 @@
+package cidrutil
+
+import (
+	"net"
+	"testing"
+)
+
+func TestUtilization(t *testing.T) {
+	type testData struct {
+		name       string
+		from       []string
+		used       []string
+		total      string
+		usedAddrs  string
+		largestGap string
+	}
+	tests := []testData{
+		{
+			name:       "empty",
+			from:       []string{"10.0.0.0/24"},
+			used:       []string{},
+			total:      "256",
+			usedAddrs:  "0",
+			largestGap: "256",
+		},
+		{
+			name:       "overlapping used are counted once",
+			from:       []string{"10.0.0.0/24"},
+			used:       []string{"10.0.0.0/25", "10.0.0.0/26", "10.0.0.192/26"},
+			total:      "256",
+			usedAddrs:  "192",
+			largestGap: "64",
+		},
+		{
+			name:       "used outside of from is ignored",
+			from:       []string{"10.0.0.0/24", "10.1.0.0/24"},
+			used:       []string{"10.0.0.0/25", "192.168.0.0/16"},
+			total:      "512",
+			usedAddrs:  "128",
+			largestGap: "256",
+		},
+		{
+			name:       "full",
+			from:       []string{"10.0.0.0/24"},
+			used:       []string{"10.0.0.0/16"},
+			total:      "256",
+			usedAddrs:  "256",
+			largestGap: "0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Utilization(mustParseCIDRs(t, tc.from...), mustParseCIDRs(t, tc.used...))
+			if got.Total.String() != tc.total {
+				t.Errorf("total: got %v, want %v", got.Total, tc.total)
+			}
+			if got.Used.String() != tc.usedAddrs {
+				t.Errorf("used: got %v, want %v", got.Used, tc.usedAddrs)
+			}
+			if got.LargestGap.String() != tc.largestGap {
+				t.Errorf("largest gap: got %v, want %v", got.LargestGap, tc.largestGap)
+			}
+		})
+	}
+}
+
+func TestAvailableBlocks(t *testing.T) {
+	type testData struct {
+		name  string
+		from  []string
+		used  []string
+		mask  int
+		align int
+		want  string
+	}
+	tests := []testData{
+		{
+			name:  "empty",
+			from:  []string{"10.0.0.0/24"},
+			used:  []string{},
+			mask:  26,
+			align: 26,
+			want:  "4",
+		},
+		{
+			name:  "gaps that can't hold an aligned block are skipped",
+			from:  []string{"10.0.0.0/24"},
+			used:  []string{"10.0.0.0/27", "10.0.0.96/27", "10.0.0.128/26"},
+			mask:  26,
+			align: 26,
+			want:  "1",
+		},
+		{
+			name:  "coarser alignment",
+			from:  []string{"10.0.0.0/24"},
+			used:  []string{"10.0.0.0/28"},
+			mask:  28,
+			align: 26,
+			want:  "3",
+		},
+		{
+			name:  "ranges smaller than the mask are ignored",
+			from:  []string{"10.0.0.0/24", "10.1.0.0/28"},
+			used:  []string{},
+			mask:  24,
+			align: 24,
+			want:  "1",
+		},
+		{
+			name:  "full",
+			from:  []string{"10.0.0.0/24"},
+			used:  []string{"10.0.0.0/16"},
+			mask:  26,
+			align: 26,
+			want:  "0",
+		},
+		{
+			name:  "larger than an int64",
+			from:  []string{"fd00::/8"},
+			used:  []string{},
+			mask:  128,
+			align: 128,
+			want:  "1329227995784915872903807060280344576",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			from := mustParseCIDRs(t, tc.from...)
+			_, bits := from[0].Mask.Size()
+			mask := net.CIDRMask(tc.mask, bits)
+			align := net.CIDRMask(tc.align, bits)
+
+			got := AvailableBlocks(from, &mask, &align, mustParseCIDRs(t, tc.used...))
+			if got.String() != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGap(t *testing.T) {
+	tests := []struct {
+		name      string
+		from      []string
+		used      []string
+		network   string
+		wantFirst string
+		wantLast  string
+		wantOk    bool
+	}{
+		{
+			name:      "between used networks",
+			from:      []string{"10.0.0.0/16"},
+			used:      []string{"10.0.0.0/24", "10.0.4.0/24"},
+			network:   "10.0.2.0/24",
+			wantFirst: "10.0.1.0",
+			wantLast:  "10.0.3.255",
+			wantOk:    true,
+		},
+		{
+			name:      "up to the end of the range",
+			from:      []string{"10.1.0.0/16", "10.0.0.0/16"},
+			used:      []string{"10.0.0.0/24"},
+			network:   "10.0.1.0/24",
+			wantFirst: "10.0.1.0",
+			wantLast:  "10.0.255.255",
+			wantOk:    true,
+		},
+		{
+			name:    "used network",
+			from:    []string{"10.0.0.0/16"},
+			used:    []string{"10.0.0.0/24"},
+			network: "10.0.0.0/25",
+		},
+		{
+			name:    "outside of the ranges",
+			from:    []string{"10.0.0.0/16"},
+			used:    []string{},
+			network: "10.1.0.0/24",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			first, last, ok := Gap(mustParseCIDRs(t, tc.from...), mustParseCIDRs(t, tc.used...), mustParseCIDRs(t, tc.network)[0])
+			if ok != tc.wantOk {
+				t.Fatalf("got ok %v, want %v", ok, tc.wantOk)
+			}
+			if !ok {
+				return
+			}
+			if first.String() != tc.wantFirst || last.String() != tc.wantLast {
+				t.Errorf("got %s-%s, want %s-%s", first, last, tc.wantFirst, tc.wantLast)
+			}
+		})
+	}
+}
+
+func TestFreeGaps(t *testing.T) {
+	type testData struct {
+		name string
+		from []string
+		used []string
+		want int
+	}
+	tests := []testData{
+		{name: "empty", from: []string{"10.0.0.0/16"}, used: []string{}, want: 1},
+		{name: "split by used", from: []string{"10.0.0.0/16"}, used: []string{"10.0.1.0/24", "10.0.3.0/24"}, want: 3},
+		{name: "adjacent used", from: []string{"10.0.0.0/16"}, used: []string{"10.0.1.0/24", "10.0.2.0/24"}, want: 2},
+		{name: "full", from: []string{"10.0.0.0/24"}, used: []string{"10.0.0.0/16"}, want: 0},
+		{name: "each range", from: []string{"10.0.0.0/24", "10.1.0.0/24"}, used: []string{"10.1.0.0/26"}, want: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := FreeGaps(mustParseCIDRs(t, tc.from...), mustParseCIDRs(t, tc.used...)); got != tc.want {
+				t.Errorf("got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLargestFreeBlock(t *testing.T) {
+	type testData struct {
+		name string
+		from []string
+		used []string
+		want string
+	}
+	tests := []testData{
+		{
+			name: "empty",
+			from: []string{"10.0.0.0/24"},
+			used: []string{},
+			want: "10.0.0.0/24",
+		},
+		{
+			name: "largest aligned block in the free space",
+			from: []string{"10.0.0.0/24"},
+			used: []string{"10.0.0.0/26"},
+			want: "10.0.0.128/25",
+		},
+		{
+			name: "unaligned free space",
+			from: []string{"10.0.0.0/24"},
+			used: []string{"10.0.0.0/27", "10.0.0.192/26"},
+			want: "10.0.0.64/26",
+		},
+		{
+			name: "first of equal blocks in from order",
+			from: []string{"10.1.0.0/24", "10.0.0.0/24"},
+			used: []string{"10.1.0.0/25", "10.0.0.0/25"},
+			want: "10.1.0.128/25",
+		},
+		{
+			name: "full",
+			from: []string{"10.0.0.0/24"},
+			used: []string{"10.0.0.0/16"},
+			want: "<nil>",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := LargestFreeBlock(mustParseCIDRs(t, tc.from...), mustParseCIDRs(t, tc.used...))
+			if got.String() != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
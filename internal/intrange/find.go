@@ -0,0 +1,24 @@
+package intrange
+
+// FindFirst returns the lowest integer in the earliest of the ranges that isn't one of the used values. Only the
+// used values are ever skipped, so the search is proportional to the number of used values rather than the size
+// of the ranges.
+func FindFirst(ranges []Range, used []int64) (int64, bool) {
+	isUsed := make(map[int64]bool, len(used))
+	for _, value := range used {
+		isUsed[value] = true
+	}
+
+	for _, r := range ranges {
+		for value := r.First; value <= r.Last; value++ {
+			if !isUsed[value] {
+				return value, true
+			}
+			// Stop before overflowing when the range ends at the largest int64.
+			if value == r.Last {
+				break
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,63 @@
+package intrange
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFindFirst(t *testing.T) {
+	type testData struct {
+		name   string
+		ranges []Range
+		used   []int64
+		want   int64
+		wantOk bool
+	}
+	tests := []testData{
+		{
+			name:   "empty range returns the first value",
+			ranges: []Range{{First: 30000, Last: 32767}},
+			used:   []int64{},
+			want:   30000,
+			wantOk: true,
+		},
+		{
+			name:   "skips used values",
+			ranges: []Range{{First: 30000, Last: 32767}},
+			used:   []int64{30001, 30000, 30003},
+			want:   30002,
+			wantOk: true,
+		},
+		{
+			name:   "moves on to the next range",
+			ranges: []Range{{First: 64512, Last: 64513}, {First: 4200000000, Last: 4294967294}},
+			used:   []int64{64512, 64513},
+			want:   4200000000,
+			wantOk: true,
+		},
+		{
+			name:   "range ending at the largest int64",
+			ranges: []Range{{First: math.MaxInt64, Last: math.MaxInt64}},
+			used:   []int64{math.MaxInt64},
+			wantOk: false,
+		},
+		{
+			name:   "full ranges",
+			ranges: []Range{{First: 1, Last: 2}, {First: 4, Last: 4}},
+			used:   []int64{1, 2, 4},
+			wantOk: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := FindFirst(tc.ranges, tc.used)
+			if ok != tc.wantOk {
+				t.Fatalf("got ok %v, want %v", ok, tc.wantOk)
+			}
+			if ok && got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package intrange
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Grid is the set of integers in a range that are a whole number of steps from its first value (ex. 0, 10, 20 for
+// the range 0-25 with a step of 10).
+type Grid struct {
+	Range Range
+	Step  int64
+}
+
+// NewGrid returns the grid of integers from first to last, inclusive, separated by step.
+func NewGrid(first int64, last int64, step int64) (Grid, error) {
+	if first > last {
+		return Grid{}, fmt.Errorf("the first value %d must not be greater than the last value %d", first, last)
+	}
+	if step < 1 {
+		return Grid{}, fmt.Errorf("the step %d must be at least 1", step)
+	}
+	// The distance between the first and last value wraps around when it doesn't fit in an int64.
+	if last-first < 0 {
+		return Grid{}, fmt.Errorf("the range %d-%d is too large", first, last)
+	}
+	return Grid{Range: Range{First: first, Last: last}, Step: step}, nil
+}
+
+// Count returns the number of integers on the grid.
+func (g Grid) Count() int64 {
+	return (g.Range.Last-g.Range.First)/g.Step + 1
+}
+
+// FindFirst returns the lowest integer on the grid that isn't one of the used values. Used values that aren't on
+// the grid are ignored.
+func (g Grid) FindFirst(used []int64) (int64, bool) {
+	index := int64(0)
+	for _, u := range g.usedIndexes(used) {
+		if u != index {
+			break
+		}
+		index++
+	}
+
+	if index >= g.Count() {
+		return 0, false
+	}
+	return g.value(index), true
+}
+
+// FindLast returns the highest integer on the grid that isn't one of the used values. Used values that aren't on
+// the grid are ignored.
+func (g Grid) FindLast(used []int64) (int64, bool) {
+	usedIndexes := g.usedIndexes(used)
+
+	index := g.Count() - 1
+	for i := len(usedIndexes) - 1; i >= 0; i-- {
+		if usedIndexes[i] != index {
+			break
+		}
+		index--
+	}
+
+	if index < 0 {
+		return 0, false
+	}
+	return g.value(index), true
+}
+
+// FindRandom returns an integer on the grid that isn't one of the used values, chosen uniformly at random from all
+// of the available integers. The same rng state always produces the same result for the same inputs. Used values
+// that aren't on the grid are ignored.
+func (g Grid) FindRandom(used []int64, rng *rand.Rand) (int64, bool) {
+	usedIndexes := g.usedIndexes(used)
+
+	free := g.Count() - int64(len(usedIndexes))
+	if free <= 0 {
+		return 0, false
+	}
+
+	// Pick the nth free index, then step over each used index at or below it to find where it lands on the grid.
+	index := rng.Int63n(free)
+	for _, u := range usedIndexes {
+		if u > index {
+			break
+		}
+		index++
+	}
+	return g.value(index), true
+}
+
+// usedIndexes returns the distinct grid indexes of the used values in ascending order, skipping values that aren't
+// on the grid.
+func (g Grid) usedIndexes(used []int64) []int64 {
+	seen := make(map[int64]bool, len(used))
+	indexes := make([]int64, 0, len(used))
+	for _, value := range used {
+		if !g.Range.Contains(value) || (value-g.Range.First)%g.Step != 0 {
+			continue
+		}
+		index := (value - g.Range.First) / g.Step
+		if seen[index] {
+			continue
+		}
+		seen[index] = true
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes
+}
+
+// value returns the integer at index on the grid.
+func (g Grid) value(index int64) int64 {
+	return g.Range.First + index*g.Step
+}
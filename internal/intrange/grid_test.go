@@ -0,0 +1,147 @@
+package intrange
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestNewGrid(t *testing.T) {
+	type testData struct {
+		name    string
+		first   int64
+		last    int64
+		step    int64
+		count   int64
+		wantErr bool
+	}
+	tests := []testData{
+		{name: "step of one", first: 1, last: 10, step: 1, count: 10},
+		{name: "last not on the grid", first: 0, last: 25, step: 10, count: 3},
+		{name: "single value", first: 5, last: 5, step: 3, count: 1},
+		{name: "negative values", first: -10, last: 10, step: 5, count: 5},
+		{name: "out of order", first: 10, last: 1, step: 1, wantErr: true},
+		{name: "zero step", first: 1, last: 10, step: 0, wantErr: true},
+		{name: "too large", first: math.MinInt64, last: math.MaxInt64, step: 1, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewGrid(tc.first, tc.last, tc.step)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if count := got.Count(); count != tc.count {
+				t.Errorf("got count %v, want %v", count, tc.count)
+			}
+		})
+	}
+}
+
+func TestGridFind(t *testing.T) {
+	type testData struct {
+		name   string
+		grid   Grid
+		used   []int64
+		first  int64
+		last   int64
+		wantOk bool
+	}
+	tests := []testData{
+		{
+			name:   "nothing used",
+			grid:   Grid{Range: Range{First: 1, Last: 10}, Step: 1},
+			used:   []int64{},
+			first:  1,
+			last:   10,
+			wantOk: true,
+		},
+		{
+			name:   "used at both ends",
+			grid:   Grid{Range: Range{First: 1, Last: 10}, Step: 1},
+			used:   []int64{10, 1, 2, 9},
+			first:  3,
+			last:   8,
+			wantOk: true,
+		},
+		{
+			name:   "used values off the grid are ignored",
+			grid:   Grid{Range: Range{First: 0, Last: 25}, Step: 10},
+			used:   []int64{5, 15, 25, 0},
+			first:  10,
+			last:   20,
+			wantOk: true,
+		},
+		{
+			name:   "duplicate and out of range used values",
+			grid:   Grid{Range: Range{First: 0, Last: 30}, Step: 10},
+			used:   []int64{0, 0, 30, 40, -10},
+			first:  10,
+			last:   20,
+			wantOk: true,
+		},
+		{
+			name:   "full grid",
+			grid:   Grid{Range: Range{First: 0, Last: 20}, Step: 10},
+			used:   []int64{0, 10, 20, 5},
+			wantOk: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			first, ok := tc.grid.FindFirst(tc.used)
+			if ok != tc.wantOk {
+				t.Fatalf("first: got ok %v, want %v", ok, tc.wantOk)
+			}
+			if ok && first != tc.first {
+				t.Errorf("first: got %v, want %v", first, tc.first)
+			}
+
+			last, ok := tc.grid.FindLast(tc.used)
+			if ok != tc.wantOk {
+				t.Fatalf("last: got ok %v, want %v", ok, tc.wantOk)
+			}
+			if ok && last != tc.last {
+				t.Errorf("last: got %v, want %v", last, tc.last)
+			}
+		})
+	}
+}
+
+func TestGridFindRandom(t *testing.T) {
+	grid := Grid{Range: Range{First: 0, Last: 100}, Step: 10}
+	used := []int64{0, 20, 40, 60, 80, 100, 55}
+
+	rng := rand.New(rand.NewSource(1))
+	seen := map[int64]bool{}
+	for i := 0; i < 200; i++ {
+		got, ok := grid.FindRandom(used, rng)
+		if !ok {
+			t.Fatalf("expected a result")
+		}
+		if got%20 != 10 {
+			t.Fatalf("got %v, which is used or off the grid", got)
+		}
+		seen[got] = true
+	}
+	if len(seen) != 5 {
+		t.Errorf("expected all 5 free values to be chosen, got %v", seen)
+	}
+
+	if got, ok := grid.FindRandom([]int64{0, 10, 20, 30, 40, 50, 60, 70, 80, 90, 100}, rng); ok {
+		t.Errorf("expected no result, got %v", got)
+	}
+
+	first, _ := grid.FindRandom(used, rand.New(rand.NewSource(42)))
+	second, _ := grid.FindRandom(used, rand.New(rand.NewSource(42)))
+	if first != second {
+		t.Errorf("expected the same seed to give the same result, got %v and %v", first, second)
+	}
+}
@@ -0,0 +1,60 @@
+package intrange
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Range is an inclusive range of integers.
+type Range struct {
+	First int64
+	Last  int64
+}
+
+// Parse parses an inclusive range in the form <first>-<last>. Both values must be between min and max, and first
+// must not be greater than last.
+func Parse(s string, min int64, max int64) (Range, error) {
+	bounds := strings.SplitN(s, "-", 2)
+	if len(bounds) != 2 {
+		return Range{}, fmt.Errorf("%q is not a range in the form <first>-<last>", s)
+	}
+
+	first, err := strconv.ParseInt(bounds[0], 10, 64)
+	if err != nil {
+		return Range{}, fmt.Errorf("%q is not a range in the form <first>-<last>", s)
+	}
+	last, err := strconv.ParseInt(bounds[1], 10, 64)
+	if err != nil {
+		return Range{}, fmt.Errorf("%q is not a range in the form <first>-<last>", s)
+	}
+
+	if first < min || last > max {
+		return Range{}, fmt.Errorf("%q must be within %d-%d", s, min, max)
+	}
+	if first > last {
+		return Range{}, fmt.Errorf("the first value of %q must not be greater than the last", s)
+	}
+
+	return Range{First: first, Last: last}, nil
+}
+
+// Size returns the number of integers in the range.
+func (r Range) Size() int64 {
+	return r.Last - r.First + 1
+}
+
+// Contains reports whether value is within the range.
+func (r Range) Contains(value int64) bool {
+	return value >= r.First && value <= r.Last
+}
+
+// Within reports whether the whole range is within other.
+func (r Range) Within(other Range) bool {
+	return other.Contains(r.First) && other.Contains(r.Last)
+}
+
+// String returns the range in the form <first>-<last>.
+func (r Range) String() string {
+	return fmt.Sprintf("%d-%d", r.First, r.Last)
+}
@@ -0,0 +1,65 @@
+package intrange
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	type testData struct {
+		input   string
+		min     int64
+		max     int64
+		want    Range
+		wantErr bool
+	}
+	tests := []testData{
+		{input: "30000-32767", min: 1, max: 65535, want: Range{First: 30000, Last: 32767}},
+		{input: "80-80", min: 1, max: 65535, want: Range{First: 80, Last: 80}},
+		{input: "4200000000-4294967294", min: 0, max: 4294967295, want: Range{First: 4200000000, Last: 4294967294}},
+		{input: "0-10", min: 1, max: 65535, wantErr: true},
+		{input: "1-65536", min: 1, max: 65535, wantErr: true},
+		{input: "10-1", min: 1, max: 65535, wantErr: true},
+		{input: "10", min: 1, max: 65535, wantErr: true},
+		{input: "a-b", min: 1, max: 65535, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := Parse(tc.input, tc.min, tc.max)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithin(t *testing.T) {
+	type testData struct {
+		r     Range
+		other Range
+		want  bool
+	}
+	tests := []testData{
+		{r: Range{First: 64512, Last: 65534}, other: Range{First: 64512, Last: 65534}, want: true},
+		{r: Range{First: 64600, Last: 64700}, other: Range{First: 64512, Last: 65534}, want: true},
+		{r: Range{First: 64000, Last: 64700}, other: Range{First: 64512, Last: 65534}, want: false},
+		{r: Range{First: 65000, Last: 65535}, other: Range{First: 64512, Last: 65534}, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.r.String()+" "+tc.other.String(), func(t *testing.T) {
+			if got := tc.r.Within(tc.other); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
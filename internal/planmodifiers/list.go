@@ -0,0 +1,74 @@
+package planmodifiers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// RequiresReplaceIfListChanged returns a plan modifier that triggers replacement when the elements of a list change.
+// The order of the elements is ignored, so re-ordering a list does not trigger replacement.
+func RequiresReplaceIfListChanged() planmodifier.List {
+	return requiresReplaceIfListChangedModifier{}
+}
+
+type requiresReplaceIfListChangedModifier struct{}
+
+func (r requiresReplaceIfListChangedModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	if req.State.Raw.IsNull() {
+		// if we're creating the resource, no need to delete and
+		// recreate it
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		// if we're deleting the resource, no need to delete and
+		// recreate it
+		return
+	}
+
+	// The new elements aren't known yet, so assume they will change.
+	if req.PlanValue.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	if sameElements(req.StateValue, req.PlanValue) {
+		return
+	}
+
+	resp.RequiresReplace = true
+}
+
+// Description returns a human-readable description of the plan modifier.
+func (r requiresReplaceIfListChangedModifier) Description(ctx context.Context) string {
+	return "If the elements of this attribute change, ignoring their order, Terraform will destroy and recreate the resource."
+}
+
+// MarkdownDescription returns a markdown description of the plan modifier.
+func (r requiresReplaceIfListChangedModifier) MarkdownDescription(ctx context.Context) string {
+	return "If the elements of this attribute change, ignoring their order, Terraform will destroy and recreate the resource."
+}
+
+// sameElements reports whether a and b contain the same elements the same number of times, in any order. A null
+// list is treated as empty.
+func sameElements(a, b types.List) bool {
+	aElements, bElements := a.Elements(), b.Elements()
+	if len(aElements) != len(bElements) {
+		return false
+	}
+
+	counts := make(map[string]int, len(aElements))
+	for _, element := range aElements {
+		counts[element.String()]++
+	}
+	for _, element := range bElements {
+		key := element.String()
+		if counts[key] == 0 {
+			return false
+		}
+		counts[key]--
+	}
+	return true
+}
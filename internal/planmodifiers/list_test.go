@@ -0,0 +1,104 @@
+package planmodifiers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRequiresReplaceIfListChanged(t *testing.T) {
+	type testData struct {
+		name  string
+		state types.List
+		plan  types.List
+		want  bool
+	}
+	tests := []testData{
+		{name: "unchanged", state: stringList("10.0.0.0/16", "10.1.0.0/16"), plan: stringList("10.0.0.0/16", "10.1.0.0/16"), want: false},
+		{name: "reordered", state: stringList("10.0.0.0/16", "10.1.0.0/16"), plan: stringList("10.1.0.0/16", "10.0.0.0/16"), want: false},
+		{name: "null to empty", state: types.ListNull(types.StringType), plan: stringList(), want: false},
+		{name: "changed", state: stringList("10.0.0.0/16", "10.1.0.0/16"), plan: stringList("10.0.0.0/16", "10.2.0.0/16"), want: true},
+		{name: "added", state: stringList("10.0.0.0/16"), plan: stringList("10.0.0.0/16", "10.1.0.0/16"), want: true},
+		{name: "removed", state: stringList("10.0.0.0/16", "10.1.0.0/16"), plan: stringList("10.1.0.0/16"), want: true},
+		{name: "duplicated", state: stringList("10.0.0.0/16", "10.1.0.0/16"), plan: stringList("10.0.0.0/16", "10.0.0.0/16"), want: true},
+		{name: "unknown", state: stringList("10.0.0.0/16"), plan: types.ListUnknown(types.StringType), want: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := planmodifier.ListRequest{
+				State:       tfsdk.State{Raw: existingResource()},
+				Plan:        tfsdk.Plan{Raw: existingResource()},
+				StateValue:  tc.state,
+				PlanValue:   tc.plan,
+				ConfigValue: tc.plan,
+			}
+			resp := &planmodifier.ListResponse{PlanValue: tc.plan}
+
+			RequiresReplaceIfListChanged().PlanModifyList(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %+v", resp.Diagnostics)
+			}
+			if resp.RequiresReplace != tc.want {
+				t.Errorf("got %v, want %v", resp.RequiresReplace, tc.want)
+			}
+		})
+	}
+}
+
+func TestRequiresReplaceIfListChangedCreateAndDestroy(t *testing.T) {
+	type testData struct {
+		name  string
+		state tftypes.Value
+		plan  tftypes.Value
+	}
+	tests := []testData{
+		{name: "create", state: tftypes.NewValue(resourceType, nil), plan: existingResource()},
+		{name: "destroy", state: existingResource(), plan: tftypes.NewValue(resourceType, nil)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := planmodifier.ListRequest{
+				State:      tfsdk.State{Raw: tc.state},
+				Plan:       tfsdk.Plan{Raw: tc.plan},
+				StateValue: stringList("10.0.0.0/16"),
+				PlanValue:  stringList("10.1.0.0/16"),
+			}
+			resp := &planmodifier.ListResponse{}
+
+			RequiresReplaceIfListChanged().PlanModifyList(context.Background(), req, resp)
+
+			if resp.RequiresReplace {
+				t.Errorf("expected no replacement")
+			}
+		})
+	}
+}
+
+var resourceType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"id": tftypes.String,
+	},
+}
+
+// existingResource returns a non-null resource value so the modifier treats the plan as an update.
+func existingResource() tftypes.Value {
+	return tftypes.NewValue(resourceType, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, "test"),
+	})
+}
+
+func stringList(values ...string) types.List {
+	elements := make([]attr.Value, len(values))
+	for i, value := range values {
+		elements[i] = types.StringValue(value)
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
@@ -0,0 +1,63 @@
+package planmodifiers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+// RequiresReplaceIfMapChanged returns a plan modifier that triggers replacement when any key of a map is added,
+// removed or has its value changed, including when a value is set to null. Unlike RequiresReplaceIfValuesNotNull it
+// doesn't accommodate state written by terraform-plugin-sdk, so it suits resources that were never built on the SDK.
+func RequiresReplaceIfMapChanged() planmodifier.Map {
+	return requiresReplaceIfMapChangedModifier{}
+}
+
+type requiresReplaceIfMapChangedModifier struct{}
+
+func (r requiresReplaceIfMapChangedModifier) PlanModifyMap(ctx context.Context, req planmodifier.MapRequest, resp *planmodifier.MapResponse) {
+	if req.State.Raw.IsNull() {
+		// if we're creating the resource, no need to delete and
+		// recreate it
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		// if we're deleting the resource, no need to delete and
+		// recreate it
+		return
+	}
+
+	// The new values aren't known yet, so assume they will change.
+	if req.ConfigValue.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	configElements := req.ConfigValue.Elements()
+	stateElements := req.StateValue.Elements()
+
+	// A null map and an empty map both have no keys, so switching between them is not a change.
+	if len(configElements) != len(stateElements) {
+		resp.RequiresReplace = true
+		return
+	}
+
+	for key, configValue := range configElements {
+		stateValue, ok := stateElements[key]
+		if !ok || !configValue.Equal(stateValue) {
+			resp.RequiresReplace = true
+			return
+		}
+	}
+}
+
+// Description returns a human-readable description of the plan modifier.
+func (r requiresReplaceIfMapChangedModifier) Description(ctx context.Context) string {
+	return "If any key of this attribute is added, removed or changed, Terraform will destroy and recreate the resource."
+}
+
+// MarkdownDescription returns a markdown description of the plan modifier.
+func (r requiresReplaceIfMapChangedModifier) MarkdownDescription(ctx context.Context) string {
+	return "If any key of this attribute is added, removed or changed, Terraform will destroy and recreate the resource."
+}
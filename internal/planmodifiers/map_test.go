@@ -0,0 +1,68 @@
+package planmodifiers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRequiresReplaceIfMapChanged(t *testing.T) {
+	type testData struct {
+		name   string
+		state  types.Map
+		config types.Map
+		want   bool
+	}
+	tests := []testData{
+		{name: "no-op", state: stringMap("a", "1", "b", "2"), config: stringMap("b", "2", "a", "1"), want: false},
+		{name: "null to empty", state: types.MapNull(types.StringType), config: stringMap(), want: false},
+		{name: "add key", state: stringMap("a", "1"), config: stringMap("a", "1", "b", "2"), want: true},
+		{name: "add key to null", state: types.MapNull(types.StringType), config: stringMap("a", "1"), want: true},
+		{name: "remove key", state: stringMap("a", "1", "b", "2"), config: stringMap("a", "1"), want: true},
+		{name: "remove all keys", state: stringMap("a", "1"), config: types.MapNull(types.StringType), want: true},
+		{name: "change value", state: stringMap("a", "1"), config: stringMap("a", "2"), want: true},
+		{
+			name:   "clear value",
+			state:  stringMap("a", "1"),
+			config: types.MapValueMust(types.StringType, map[string]attr.Value{"a": types.StringNull()}),
+			want:   true,
+		},
+		{name: "rename key", state: stringMap("a", "1"), config: stringMap("b", "1"), want: true},
+		{name: "unknown", state: stringMap("a", "1"), config: types.MapUnknown(types.StringType), want: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := planmodifier.MapRequest{
+				State:       tfsdk.State{Raw: existingResource()},
+				Plan:        tfsdk.Plan{Raw: existingResource()},
+				StateValue:  tc.state,
+				PlanValue:   tc.config,
+				ConfigValue: tc.config,
+			}
+			resp := &planmodifier.MapResponse{PlanValue: tc.config}
+
+			RequiresReplaceIfMapChanged().PlanModifyMap(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %+v", resp.Diagnostics)
+			}
+			if resp.RequiresReplace != tc.want {
+				t.Errorf("got %v, want %v", resp.RequiresReplace, tc.want)
+			}
+		})
+	}
+}
+
+// stringMap builds a map from alternating keys and values.
+func stringMap(keysAndValues ...string) types.Map {
+	elements := make(map[string]attr.Value, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		elements[keysAndValues[i]] = types.StringValue(keysAndValues[i+1])
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
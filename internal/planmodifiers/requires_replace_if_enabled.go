@@ -0,0 +1,124 @@
+package planmodifiers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// BoolRequiresReplaceIfEnabled returns a plan modifier that triggers replacement when the attribute changes, but
+// only when the boolean attribute at flag is configured as true.
+func BoolRequiresReplaceIfEnabled(flag path.Path) planmodifier.Bool {
+	return boolplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace, resp.Diagnostics = enabled(ctx, req.Config, flag)
+		},
+		requiresReplaceIfEnabledDescription(flag),
+		requiresReplaceIfEnabledDescription(flag),
+	)
+}
+
+// Int64RequiresReplaceIfEnabled returns a plan modifier that triggers replacement when the attribute changes, but
+// only when the boolean attribute at flag is configured as true.
+func Int64RequiresReplaceIfEnabled(flag path.Path) planmodifier.Int64 {
+	return int64planmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace, resp.Diagnostics = enabled(ctx, req.Config, flag)
+		},
+		requiresReplaceIfEnabledDescription(flag),
+		requiresReplaceIfEnabledDescription(flag),
+	)
+}
+
+// ListRequiresReplaceIfEnabled returns a plan modifier that triggers replacement when the elements of the attribute
+// change, but only when the boolean attribute at flag is configured as true. Like RequiresReplaceIfListChanged, the
+// order of the elements is ignored.
+func ListRequiresReplaceIfEnabled(flag path.Path) planmodifier.List {
+	return listRequiresReplaceIfEnabledModifier{flag: flag}
+}
+
+type listRequiresReplaceIfEnabledModifier struct {
+	flag path.Path
+}
+
+func (r listRequiresReplaceIfEnabledModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	replace, diags := enabled(ctx, req.Config, r.flag)
+	resp.Diagnostics.Append(diags...)
+	if !replace {
+		return
+	}
+
+	requiresReplaceIfListChangedModifier{}.PlanModifyList(ctx, req, resp)
+}
+
+// Description returns a human-readable description of the plan modifier.
+func (r listRequiresReplaceIfEnabledModifier) Description(ctx context.Context) string {
+	return requiresReplaceIfEnabledDescription(r.flag)
+}
+
+// MarkdownDescription returns a markdown description of the plan modifier.
+func (r listRequiresReplaceIfEnabledModifier) MarkdownDescription(ctx context.Context) string {
+	return requiresReplaceIfEnabledDescription(r.flag)
+}
+
+// MapRequiresReplaceIfEnabled returns a plan modifier that triggers replacement when any key of the attribute is
+// added, removed or changed, but only when the boolean attribute at flag is configured as true. It lets a resource
+// whose keepers use RequiresReplaceIfValuesNotNull opt into the stricter RequiresReplaceIfMapChanged.
+func MapRequiresReplaceIfEnabled(flag path.Path) planmodifier.Map {
+	return mapRequiresReplaceIfEnabledModifier{flag: flag}
+}
+
+type mapRequiresReplaceIfEnabledModifier struct {
+	flag path.Path
+}
+
+func (r mapRequiresReplaceIfEnabledModifier) PlanModifyMap(ctx context.Context, req planmodifier.MapRequest, resp *planmodifier.MapResponse) {
+	replace, diags := enabled(ctx, req.Config, r.flag)
+	resp.Diagnostics.Append(diags...)
+	if !replace {
+		return
+	}
+
+	requiresReplaceIfMapChangedModifier{}.PlanModifyMap(ctx, req, resp)
+}
+
+// Description returns a human-readable description of the plan modifier.
+func (r mapRequiresReplaceIfEnabledModifier) Description(ctx context.Context) string {
+	return requiresReplaceIfEnabledDescription(r.flag)
+}
+
+// MarkdownDescription returns a markdown description of the plan modifier.
+func (r mapRequiresReplaceIfEnabledModifier) MarkdownDescription(ctx context.Context) string {
+	return requiresReplaceIfEnabledDescription(r.flag)
+}
+
+// StringRequiresReplaceIfEnabled returns a plan modifier that triggers replacement when the attribute changes, but
+// only when the boolean attribute at flag is configured as true.
+func StringRequiresReplaceIfEnabled(flag path.Path) planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace, resp.Diagnostics = enabled(ctx, req.Config, flag)
+		},
+		requiresReplaceIfEnabledDescription(flag),
+		requiresReplaceIfEnabledDescription(flag),
+	)
+}
+
+// enabled reports whether the boolean attribute at flag is configured as true.
+func enabled(ctx context.Context, config tfsdk.Config, flag path.Path) (bool, diag.Diagnostics) {
+	var value types.Bool
+	diags := config.GetAttribute(ctx, flag, &value)
+	return value.ValueBool(), diags
+}
+
+func requiresReplaceIfEnabledDescription(flag path.Path) string {
+	return fmt.Sprintf("If the value of this attribute changes and %s is true, Terraform will destroy and recreate the resource.", flag)
+}
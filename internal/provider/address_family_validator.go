@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ConfigValidator = sameAddressFamilyValidator{}
+
+// sameAddressFamily returns a resource validator which ensures that every CIDR across the list attributes at
+// paths is of a single address family. Mixing IPv4 and IPv6 ranges in one resource is almost always a copy-paste
+// error, so it is caught during plan rather than surfacing as a stray used CIDR or a failed allocation.
+func sameAddressFamily(paths ...path.Path) resource.ConfigValidator {
+	return sameAddressFamilyValidator{paths: paths}
+}
+
+type sameAddressFamilyValidator struct {
+	paths []path.Path
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v sameAddressFamilyValidator) Description(ctx context.Context) string {
+	names := make([]string, len(v.paths))
+	for i, p := range v.paths {
+		names[i] = p.String()
+	}
+	return fmt.Sprintf("every CIDR in %s must be of the same address family", strings.Join(names, ", "))
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v sameAddressFamilyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource performs the validation. Unknown lists and elements are skipped, and the family is detected from
+// the presence of a colon so that malformed elements are left to the attribute validators and very large lists stay
+// cheap to check.
+func (v sameAddressFamilyValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var firstPath path.Path
+	var first string
+
+	for _, p := range v.paths {
+		var list types.List
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &list)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if list.IsNull() || list.IsUnknown() {
+			continue
+		}
+
+		for _, element := range list.Elements() {
+			cidr, ok := element.(types.String)
+			if !ok || cidr.IsNull() || cidr.IsUnknown() {
+				continue
+			}
+
+			if first == "" {
+				firstPath, first = p, cidr.ValueString()
+				continue
+			}
+
+			if isIPv6CidrString(first) != isIPv6CidrString(cidr.ValueString()) {
+				summary := "Mixed address families in " + firstPath.String()
+				if !p.Equal(firstPath) {
+					summary += " and " + p.String()
+				}
+				resp.Diagnostics.AddAttributeError(
+					p,
+					summary,
+					fmt.Sprintf("All CIDRs must be either IPv4 or IPv6, but the %s entry %s and the %s entry %s are of different families",
+						firstPath, first, p, cidr.ValueString()),
+				)
+				return
+			}
+		}
+	}
+}
+
+// isIPv6CidrString reports whether cidr is written as an IPv6 range. IPv4-mapped IPv6 ranges count as IPv4, since they
+// are either normalized to IPv4 or rejected with a clearer message during plan.
+func isIPv6CidrString(cidr string) bool {
+	if !strings.Contains(cidr, ":") {
+		return false
+	}
+	_, network, err := net.ParseCIDR(cidr)
+	return err != nil || !cidrutil.IsIPv4Mapped(network)
+}
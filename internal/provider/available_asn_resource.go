@@ -0,0 +1,285 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/intrange"
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/planmodifiers"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &AvailableAsnResource{}
+var _ resource.ResourceWithConfigure = &AvailableAsnResource{}
+var _ resource.ResourceWithImportState = &AvailableAsnResource{}
+var _ resource.ResourceWithValidateConfig = &AvailableAsnResource{}
+
+// The range of 32-bit ASNs.
+const (
+	minAsn = 0
+	maxAsn = 4294967295
+)
+
+// privateAsnRanges are the ASNs reserved for private use by RFC 6996.
+var privateAsnRanges = []intrange.Range{
+	{First: 64512, Last: 65534},
+	{First: 4200000000, Last: 4294967294},
+}
+
+func NewAvailableAsnResource() resource.Resource {
+	return &AvailableAsnResource{}
+}
+
+// AvailableAsnResource defines the resource implementation.
+type AvailableAsnResource struct{}
+
+// AvailableAsnResourceModel describes the resource data model.
+type AvailableAsnResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Keepers     types.Map    `tfsdk:"keepers"`
+	FromRanges  types.List   `tfsdk:"from_ranges"`
+	UsedAsns    types.List   `tfsdk:"used_asns"`
+	AllowPublic types.Bool   `tfsdk:"allow_public"`
+	Result      types.Int64  `tfsdk:"result"`
+}
+
+func (r *AvailableAsnResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_available_asn"
+}
+
+func (r *AvailableAsnResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Given ranges of ASNs to search over and a list of already used ASNs, find an unused ASN.\n\n" +
+			"The `result` is kept stable once it is allocated, since it is typically used to configure BGP peering, so changing " +
+			"the inputs used to find it has no effect. To conditionally allocate a new ASN, change the `keepers`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ASN Identifier. The value will be identical to the `result` field.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"from_ranges": schema.ListAttribute{
+				MarkdownDescription: "A list containing the inclusive range(s) of ASNs from which to search for an available ASN, in the form `<first>-<last>` (ex. `64512-65534`). The ranges are searched in order. Changing this value after creation **HAS NO EFFECT**.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(rangeRegex, "Must be an ASN range in the form <first>-<last>")),
+				},
+				Required: true,
+			},
+			"used_asns": schema.ListAttribute{
+				MarkdownDescription: "A list containing the ASNs that are already used within the `from_ranges` which should be avoided to prevent collisions. Changing this value after creation **HAS NO EFFECT**.",
+				ElementType:         types.Int64Type,
+				Validators: []validator.List{
+					listvalidator.ValueInt64sAre(int64validator.Between(minAsn, maxAsn)),
+				},
+				Required: true,
+			},
+			"allow_public": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, the `from_ranges` may include public ASNs. By default they must be within the private ASN ranges `64512-65534` and `4200000000-4294967294`. Defaults to `false`. Changing this value after creation **HAS NO EFFECT**.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"keepers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, will trigger re-creation of resource. This field works the same as the `keepers` field in the [`Random` provider](https://registry.terraform.io/providers/hashicorp/random/latest/docs#resource-keepers). Numbers and bools are converted to strings by Terraform (ex. a `version = 3` counter is stored as `\"3\"`), so they don't need `tostring()`. Adding, removing or changing any key, including setting its value to `null`, triggers re-creation.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					planmodifiers.RequiresReplaceIfMapChanged(),
+				},
+			},
+			"result": schema.Int64Attribute{
+				MarkdownDescription: "The available ASN that was found.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig catches from_ranges that are out of order or include public ASNs during plan, rather than waiting
+// for the allocation to fail during apply.
+func (r *AvailableAsnResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var fromRanges types.List
+	var allowPublic types.Bool
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("from_ranges"), &fromRanges)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("allow_public"), &allowPublic)...)
+	if resp.Diagnostics.HasError() || fromRanges.IsNull() || fromRanges.IsUnknown() || allowPublic.IsUnknown() {
+		return
+	}
+
+	for i, element := range fromRanges.Elements() {
+		fromRange, ok := element.(types.String)
+		// Malformed ranges are already reported by the attribute validator.
+		if !ok || fromRange.IsNull() || fromRange.IsUnknown() || !rangeRegex.MatchString(fromRange.ValueString()) {
+			continue
+		}
+
+		if _, err := parseAsnRange(fromRange.ValueString(), allowPublic.ValueBool()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("from_ranges").AtListIndex(i),
+				"Invalid from_ranges",
+				err.Error(),
+			)
+		}
+	}
+}
+
+func (r *AvailableAsnResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (r *AvailableAsnResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AvailableAsnResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fromRangesStrings := make([]string, len(data.FromRanges.Elements()))
+	resp.Diagnostics.Append(data.FromRanges.ElementsAs(ctx, &fromRangesStrings, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fromRanges := make([]intrange.Range, len(fromRangesStrings))
+	for i, from := range fromRangesStrings {
+		fromRange, err := parseAsnRange(from, data.AllowPublic.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error parsing from_ranges",
+				err.Error(),
+			)
+			return
+		}
+		fromRanges[i] = fromRange
+	}
+
+	usedAsns := make([]int64, len(data.UsedAsns.Elements()))
+	resp.Diagnostics.Append(data.UsedAsns.ElementsAs(ctx, &usedAsns, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, ok := intrange.FindFirst(fromRanges, usedAsns)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"No available ASN found",
+			fmt.Sprintf("All of the ASNs in %s are used", strings.Join(fromRangesStrings, ", ")),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(strconv.FormatInt(result, 10))
+	data.Result = types.Int64Value(result)
+
+	tflog.Trace(ctx, "found available asn: "+data.Id.ValueString())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AvailableAsnResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AvailableAsnResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update ensures the plan value is copied to the state to complete the update.
+func (r *AvailableAsnResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AvailableAsnResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete does not need to explicitly call resp.State.RemoveResource() as this is automatically handled by the
+// [framework](https://github.com/hashicorp/terraform-plugin-framework/pull/301).
+func (r *AvailableAsnResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// ImportState accepts any ASN, including public ones, since the ASN may have been allocated with allow_public set.
+func (r *AvailableAsnResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	asn, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil || asn < minAsn || asn > maxAsn {
+		resp.Diagnostics.AddError(
+			"Malformed resource ID (ASN)",
+			fmt.Sprintf("The ID that was given must be an ASN between %d and %d", int64(minAsn), int64(maxAsn)),
+		)
+		return
+	}
+
+	state := AvailableAsnResourceModel{
+		FromRanges:  types.ListNull(types.StringType),
+		UsedAsns:    types.ListNull(types.Int64Type),
+		AllowPublic: types.BoolValue(false),
+		Keepers:     types.MapNull(types.StringType),
+		Id:          types.StringValue(strconv.FormatInt(asn, 10)),
+		Result:      types.Int64Value(asn),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// parseAsnRange parses a range of ASNs, ensuring it is within one of the private ASN ranges unless allowPublic is
+// set.
+func parseAsnRange(s string, allowPublic bool) (intrange.Range, error) {
+	asnRange, err := intrange.Parse(s, minAsn, maxAsn)
+	if err != nil || allowPublic {
+		return asnRange, err
+	}
+
+	for _, private := range privateAsnRanges {
+		if asnRange.Within(private) {
+			return asnRange, nil
+		}
+	}
+
+	privateRanges := make([]string, len(privateAsnRanges))
+	for i, private := range privateAsnRanges {
+		privateRanges[i] = private.String()
+	}
+	return intrange.Range{}, fmt.Errorf("%q is not within the private ASN ranges (%s), set allow_public to allocate public ASNs", s, strings.Join(privateRanges, ", "))
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAvailableAsnResourceSchema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	NewAvailableAsnResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	if diagnostics := schemaResponse.Schema.ValidateImplementation(ctx); diagnostics.HasError() {
+		t.Fatalf("schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestAccAvailableAsnResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAvailableAsnResourceConfig(`["64512-65534"]`, "[64512, 64513]", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_asn.test", "result", "64514"),
+					resource.TestCheckResourceAttr("utility_available_asn.test", "id", "64514"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "utility_available_asn.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"from_ranges", "used_asns"},
+			},
+			// Changing the inputs keeps the result stable
+			{
+				Config: testAccAvailableAsnResourceConfig(`["65000-65534"]`, "[64514]", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_asn.test", "result", "64514"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAvailableAsnResourceMultipleRanges(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAvailableAsnResourceConfig(`["65533-65534", "4200000000-4294967294"]`, "[65533, 65534]", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_asn.test", "result", "4200000000"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAvailableAsnResourcePublic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAvailableAsnResourceConfig(`["64000-64600"]`, "[]", false),
+				ExpectError: regexp.MustCompile("not within the private ASN ranges"),
+			},
+			{
+				Config: testAccAvailableAsnResourceConfig(`["64000-64600"]`, "[]", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_asn.test", "result", "64000"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAvailableAsnResourceExhausted(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAvailableAsnResourceConfig(`["65533-65534"]`, "[65533, 65534]", false),
+				ExpectError: regexp.MustCompile("No available ASN found"),
+			},
+		},
+	})
+}
+
+func testAccAvailableAsnResourceConfig(fromRanges string, usedAsns string, allowPublic bool) string {
+	return fmt.Sprintf(`
+resource "utility_available_asn" "test" {
+  from_ranges  = %s
+  used_asns    = %s
+  allow_public = %v
+}
+`, fromRanges, usedAsns, allowPublic)
+}
@@ -0,0 +1,1214 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/big"
+	"math/rand"
+	"net"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// allocationDecision is the JSON encoded in allocation_json, describing how the result was chosen.
+type allocationDecision struct {
+	Result        string         `json:"result"`
+	Strategy      string         `json:"strategy"`
+	Preferred     bool           `json:"preferred"`
+	SearchedCidrs []string       `json:"searched_cidrs"`
+	Gap           *allocationGap `json:"gap"`
+}
+
+// allocationGap is the run of free addresses that the result was placed in.
+type allocationGap struct {
+	First string `json:"first"`
+	Last  string `json:"last"`
+}
+
+// allocateResults finds the available CIDRs for the inputs in data and fills in the computed attributes that
+// describe them. It is used both to preview the result during plan and to allocate it during apply, and always
+// chooses the same CIDRs for the same inputs, so the planned result matches the applied one.
+func (r *AvailableCidrResource) allocateResults(ctx context.Context, data *AvailableCidrResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	start := time.Now()
+
+	// The CIDRs covering the from_ranges are searched along with the from_cidrs.
+	fromCidrsList, fromDiags := effectiveFromCidrs(data.FromCidrs, data.FromRanges)
+	diags.Append(fromDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// The used_cidrs_json entries follow the used_cidrs.
+	usedCidrsList, usedDiags := effectiveUsedCidrs(data.UsedCidrs, data.UsedCidrsJSON)
+	diags.Append(usedDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	fromCidrsStrings := make([]string, len(fromCidrsList.Elements()))
+	usedCidrsStrings := make([]string, len(usedCidrsList.Elements()))
+
+	diags.Append(fromCidrsList.ElementsAs(ctx, &fromCidrsStrings, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// An unset used_cidrs means nothing is used yet.
+	if !usedCidrsList.IsNull() {
+		diags.Append(usedCidrsList.ElementsAs(ctx, &usedCidrsStrings, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	fromCidrs := make([]*net.IPNet, len(fromCidrsStrings))
+	for i, from := range fromCidrsStrings {
+		_, fromCidr, parseErr := net.ParseCIDR(from)
+		if parseErr != nil {
+			diags.AddAttributeError(
+				data.fromCidrPath(i),
+				"Error parsing from_cidrs",
+				fmt.Sprintf("Unable to parse %q: %s", from, parseErr.Error()),
+			)
+			return diags
+		}
+		fromCidr, parseErr = checkIPv4Mapped(r.ipv4MappedCidrs, from, fromCidr)
+		if parseErr != nil {
+			diags.AddAttributeError(
+				data.fromCidrPath(i),
+				"IPv4-mapped IPv6 CIDR in from_cidrs",
+				parseErr.Error(),
+			)
+			return diags
+		}
+		fromCidrs[i] = fromCidr
+	}
+
+	// The from_cidrs are checked again since they may not have been known during plan.
+	for i, fromCidr := range fromCidrs {
+		if err := checkAddressFamily(r.addressFamily, fromCidr); err != nil {
+			diags.AddAttributeError(
+				data.fromCidrPath(i),
+				"Address family not allowed",
+				err.Error(),
+			)
+			return diags
+		}
+	}
+
+	// The address family of the first from_cidr determines the mask length, so every
+	// other from_cidr must be of the same family.
+	_, addrBits := fromCidrs[0].Mask.Size()
+	for i, fromCidr := range fromCidrs {
+		if _, bits := fromCidr.Mask.Size(); bits != addrBits {
+			diags.AddAttributeError(
+				data.fromCidrPath(i),
+				"Mixed address families in from_cidrs",
+				fmt.Sprintf("All from_cidrs must be either IPv4 or IPv6, but %s and %s are of different families", fromCidrsStrings[0], fromCidrsStrings[i]),
+			)
+			return diags
+		}
+	}
+
+	// With masks, each of the from_cidrs is searched with its own mask. The masks are looked up by range once the
+	// from_cidrs are normalized, so a repeated range keeps the first mask given for it.
+	var rangePrefixes map[string]int
+	if !data.Masks.IsNull() {
+		prefixLengths, err := rangePrefixLengths(int(data.Mask.ValueInt64()), data.Masks, fromCidrs)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("masks"),
+				"Invalid masks",
+				err.Error(),
+			)
+			return diags
+		}
+		if err := checkRangeAlignTo(data.AlignTo, prefixLengths, fromCidrs); err != nil {
+			diags.AddAttributeError(
+				path.Root("align_to"),
+				"Invalid align_to",
+				err.Error(),
+			)
+			return diags
+		}
+
+		rangePrefixes = make(map[string]int, len(fromCidrs))
+		for i, fromCidr := range fromCidrs {
+			if _, ok := rangePrefixes[fromCidr.String()]; !ok {
+				rangePrefixes[fromCidr.String()] = prefixLengths[i]
+			}
+		}
+	}
+
+	// net.ParseCIDR already drops any host bits (ex. 10.5.3.7/16 is searched as 10.5.0.0/16), so normalizing only
+	// removes duplicates, but the ranges that are searched are recorded in normalized_from_cidrs.
+	configuredFromCidrs := fromCidrs
+	fromCidrs = cidrutil.Normalize(fromCidrs)
+	tflog.Trace(ctx, "normalized from cidrs", map[string]interface{}{
+		"from_cidrs":            fromCidrsStrings,
+		"normalized_from_cidrs": cidrutil.Strings(fromCidrs),
+	})
+
+	if data.DedupeFromCidrs.ValueBool() {
+		fromCidrs = cidrutil.Aggregate(fromCidrs)
+		tflog.Trace(ctx, "deduplicated from cidrs", map[string]interface{}{
+			"from_cidrs": cidrutil.Strings(fromCidrs),
+		})
+	}
+
+	// netmask is only an input when mask isn't set. Otherwise it describes the result, and may hold the size of a
+	// block that was previewed during plan (ex. a coalesced block) rather than the requested size.
+	prefixLength := int(data.Mask.ValueInt64())
+	if data.Mask.IsNull() && !data.Netmask.IsNull() && !data.Netmask.IsUnknown() {
+		ones, bits, err := cidrutil.ParseNetmask(data.Netmask.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("netmask"),
+				"Error parsing netmask",
+				err.Error(),
+			)
+			return diags
+		}
+		if bits != addrBits {
+			diags.AddAttributeError(
+				path.Root("netmask"),
+				"Mismatched netmask address family",
+				fmt.Sprintf("The netmask %s is not the same address family as from_cidrs", data.Netmask.ValueString()),
+			)
+			return diags
+		}
+		prefixLength = ones
+	}
+
+	// mask may not have been known during plan, so its bound is checked again.
+	if prefixLength < 0 || prefixLength > addrBits {
+		diags.AddError(
+			"Invalid mask",
+			fmt.Sprintf("mask must be between 0 and %d for %s from_cidrs, got %d", addrBits, addressFamilyName(addrBits), prefixLength),
+		)
+		return diags
+	}
+
+	// from_cidrs may not have been known during plan, so a mask that doesn't fit any of them is reported here
+	// rather than as a failed search. The masks were already checked against their ranges.
+	if detail := maskTooLargeDetail(prefixLength, fromCidrs); detail != "" && rangePrefixes == nil {
+		diags.AddError(
+			"Mask too large for from_cidrs",
+			detail,
+		)
+		return diags
+	}
+
+	mask := net.CIDRMask(prefixLength, addrBits)
+
+	// Without align_to, blocks are aligned to their own size.
+	align := mask
+	if !data.AlignTo.IsNull() {
+		alignTo := int(data.AlignTo.ValueInt64())
+		if alignTo > prefixLength && rangePrefixes == nil {
+			diags.AddError(
+				"Invalid align_to",
+				fmt.Sprintf("align_to /%d must not be smaller than the /%d being allocated", alignTo, prefixLength),
+			)
+			return diags
+		}
+		align = net.CIDRMask(alignTo, addrBits)
+	}
+
+	// With coalesce, a single block that is large enough to hold every mask sized subnet is allocated instead and
+	// split into the results afterwards.
+	allocationCount := int(data.AllocationCount.ValueInt64())
+	blockCount, blockPrefixLength, blockMask, blockAlign := allocationCount, prefixLength, mask, align
+	coalesce := data.Coalesce.ValueBool() && allocationCount > 1
+	if coalesce {
+		blockPrefixLength = cidrutil.CoalescedPrefixLength(prefixLength, allocationCount)
+		if blockPrefixLength < 0 {
+			diags.AddError(
+				"Invalid coalesce",
+				fmt.Sprintf("%d /%d subnets don't fit in a single %s block", allocationCount, prefixLength, addressFamilyName(addrBits)),
+			)
+			return diags
+		}
+		if detail := maskTooLargeDetail(blockPrefixLength, fromCidrs); detail != "" {
+			diags.AddError(
+				"Coalesced block too large for from_cidrs",
+				fmt.Sprintf("%d /%d subnets are coalesced into a /%d block. %s", allocationCount, prefixLength, blockPrefixLength, detail),
+			)
+			return diags
+		}
+
+		blockCount = 1
+		blockMask = net.CIDRMask(blockPrefixLength, addrBits)
+		// The block is aligned to its own size, unless align_to is coarser still.
+		if alignOnes, _ := align.Size(); alignOnes > blockPrefixLength {
+			blockAlign = blockMask
+		}
+	}
+
+	// Some of the from_cidrs can hold the block, since maskTooLargeDetail passed, but the rest are skipped by the
+	// search. Naming them here saves working out why they were never used from a later "No available CIDR found".
+	if rangePrefixes == nil {
+		diags.Append(data.fromCidrsTooSmallWarnings(blockPrefixLength, configuredFromCidrs, fromCidrs)...)
+	}
+
+	// The mask and alignment that each of the from_cidrs is searched with, when masks sets them per range.
+	var rangeMasks, rangeAligns []net.IPMask
+	if rangePrefixes != nil {
+		for _, fromCidr := range fromCidrs {
+			rangeMask := net.CIDRMask(rangePrefixes[fromCidr.String()], addrBits)
+			rangeAlign := rangeMask
+			if !data.AlignTo.IsNull() {
+				rangeAlign = align
+			}
+			rangeMasks = append(rangeMasks, rangeMask)
+			rangeAligns = append(rangeAligns, rangeAlign)
+		}
+	}
+
+	usedCidrs := make([]*net.IPNet, len(usedCidrsStrings))
+	for i, used := range usedCidrsStrings {
+		_, usedCidr, parseErr := net.ParseCIDR(used)
+		if parseErr != nil {
+			diags.AddAttributeError(
+				data.usedCidrPath(i),
+				"Error parsing used_cidrs",
+				fmt.Sprintf("Unable to parse %q: %s", used, parseErr.Error()),
+			)
+			return diags
+		}
+		usedCidr, parseErr = checkIPv4Mapped(r.ipv4MappedCidrs, used, usedCidr)
+		if parseErr != nil {
+			diags.AddAttributeError(
+				data.usedCidrPath(i),
+				"IPv4-mapped IPv6 CIDR in used_cidrs",
+				parseErr.Error(),
+			)
+			return diags
+		}
+		usedCidrs[i] = usedCidr
+	}
+
+	// The used_cidrs are checked again since they may not have been known during plan. Without strict_used_cidrs
+	// the stray entries were already reported as a warning during validation.
+	if data.StrictUsedCidrs.ValueBool() {
+		if stray := cidrutil.Outside(fromCidrs, usedCidrs); len(stray) > 0 {
+			diags.AddAttributeError(
+				path.Root("used_cidrs"),
+				"used_cidrs outside of from_cidrs",
+				fmt.Sprintf("These used_cidrs aren't within any of the from_cidrs: %s", strings.Join(cidrutil.Strings(stray), ", ")),
+			)
+			return diags
+		}
+	}
+
+	// Reserved CIDRs are avoided in exactly the same way as used CIDRs.
+	if !data.ReservedCidrs.IsNull() {
+		reservedCidrsStrings := make([]string, len(data.ReservedCidrs.Elements()))
+		diags.Append(data.ReservedCidrs.ElementsAs(ctx, &reservedCidrsStrings, false)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		for i, reserved := range reservedCidrsStrings {
+			_, reservedCidr, parseErr := net.ParseCIDR(reserved)
+			if parseErr != nil {
+				diags.AddAttributeError(
+					path.Root("reserved_cidrs").AtListIndex(i),
+					"Error parsing reserved_cidrs",
+					fmt.Sprintf("Unable to parse %q: %s", reserved, parseErr.Error()),
+				)
+				return diags
+			}
+			reservedCidr, parseErr = checkIPv4Mapped(r.ipv4MappedCidrs, reserved, reservedCidr)
+			if parseErr != nil {
+				diags.AddAttributeError(
+					path.Root("reserved_cidrs").AtListIndex(i),
+					"IPv4-mapped IPv6 CIDR in reserved_cidrs",
+					parseErr.Error(),
+				)
+				return diags
+			}
+			usedCidrs = append(usedCidrs, reservedCidr)
+		}
+	}
+
+	// Normalize to network addresses and drop duplicates so noisy inputs (ex. 10.0.3.5/24 alongside 10.0.3.0/24)
+	// result in a single used block.
+	usedCidrs = cidrutil.Normalize(usedCidrs)
+	tflog.Trace(ctx, "normalized used cidrs", map[string]interface{}{
+		"used_cidrs": cidrutil.Strings(usedCidrs),
+	})
+
+	// Each used block is surrounded by a guard band of min_gap addresses, which is treated as used as well, so that
+	// nothing is allocated closer to it than that.
+	if gap := data.MinGap.ValueInt64(); gap > 0 {
+		guards := []*net.IPNet{}
+		for _, used := range usedCidrs {
+			guards = append(guards, cidrutil.Pad(used, big.NewInt(gap))...)
+		}
+		usedCidrs = append(usedCidrs, guards...)
+		tflog.Trace(ctx, "padded used cidrs", map[string]interface{}{
+			"min_gap":      gap,
+			"guard_blocks": len(guards),
+		})
+	}
+
+	// Everything outside of the allow_cidrs windows is treated as used, which keeps the search, prefer_cidr and
+	// the remaining capacity within the windows without any special handling.
+	if !data.AllowCidrs.IsNull() {
+		allowCidrsStrings := make([]string, len(data.AllowCidrs.Elements()))
+		diags.Append(data.AllowCidrs.ElementsAs(ctx, &allowCidrsStrings, false)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		allowCidrs := make([]*net.IPNet, len(allowCidrsStrings))
+		for i, allow := range allowCidrsStrings {
+			_, allowCidr, parseErr := net.ParseCIDR(allow)
+			if parseErr != nil {
+				diags.AddAttributeError(
+					path.Root("allow_cidrs").AtListIndex(i),
+					"Error parsing allow_cidrs",
+					fmt.Sprintf("Unable to parse %q: %s", allow, parseErr.Error()),
+				)
+				return diags
+			}
+			allowCidr, parseErr = checkIPv4Mapped(r.ipv4MappedCidrs, allow, allowCidr)
+			if parseErr != nil {
+				diags.AddAttributeError(
+					path.Root("allow_cidrs").AtListIndex(i),
+					"IPv4-mapped IPv6 CIDR in allow_cidrs",
+					parseErr.Error(),
+				)
+				return diags
+			}
+			allowCidrs[i] = allowCidr
+		}
+
+		// The allow_cidrs are checked again since they may not have been known during plan.
+		if stray := cidrutil.Outside(fromCidrs, allowCidrs); len(stray) > 0 {
+			diags.AddAttributeError(
+				path.Root("allow_cidrs"),
+				"allow_cidrs outside of from_cidrs",
+				fmt.Sprintf("These allow_cidrs aren't within any of the from_cidrs: %s", strings.Join(cidrutil.Strings(stray), ", ")),
+			)
+			return diags
+		}
+
+		for _, fromCidr := range fromCidrs {
+			usedCidrs = append(usedCidrs, cidrutil.Free(fromCidr, allowCidrs)...)
+		}
+		tflog.Trace(ctx, "restricted to allow cidrs", map[string]interface{}{
+			"allow_cidrs": allowCidrsStrings,
+		})
+	}
+
+	if !data.StartOffset.IsNull() {
+		if err := checkStartOffset(data.StartOffset.ValueInt64(), prefixLength, fromCidrs); err != nil {
+			diags.AddAttributeError(
+				path.Root("start_offset"),
+				"Invalid start_offset",
+				err.Error(),
+			)
+			return diags
+		}
+	}
+
+	// Everything before the after_cidr anchor is treated as used, so only blocks starting at or after it are found.
+	if !data.AfterCidr.IsNull() {
+		_, afterCidr, err := net.ParseCIDR(data.AfterCidr.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("after_cidr"),
+				"Error parsing after_cidr",
+				fmt.Sprintf("Unable to parse %q: %s", data.AfterCidr.ValueString(), err.Error()),
+			)
+			return diags
+		}
+		if len(cidrutil.Outside(fromCidrs, []*net.IPNet{afterCidr})) > 0 {
+			diags.AddAttributeError(
+				path.Root("after_cidr"),
+				"after_cidr outside of from_cidrs",
+				fmt.Sprintf("The after_cidr %s isn't within any of the from_cidrs, so it can't anchor the search.", afterCidr),
+			)
+			return diags
+		}
+		for _, fromCidr := range fromCidrs {
+			usedCidrs = append(usedCidrs, cidrutil.Before(fromCidr, afterCidr.IP)...)
+		}
+	}
+
+	// Boundary subnets and the blocks skipped by start_offset are avoided by treating them as used. A from_cidr
+	// that is smaller than the mask has no subnets of that size to exclude. With masks, the boundary subnets are
+	// those of the range's own mask.
+	for _, fromCidr := range fromCidrs {
+		subnetPrefixLength := prefixLength
+		if rangePrefixes != nil {
+			subnetPrefixLength = rangePrefixes[fromCidr.String()]
+		}
+		if fromPrefixLength, _ := fromCidr.Mask.Size(); fromPrefixLength > subnetPrefixLength {
+			continue
+		}
+		if offset := data.StartOffset.ValueInt64(); offset > 0 {
+			usedCidrs = append(usedCidrs, cidrutil.FirstSubnets(fromCidr, subnetPrefixLength, offset)...)
+		}
+		if data.ExcludeFirst.ValueBool() {
+			usedCidrs = append(usedCidrs, cidrutil.FirstSubnet(fromCidr, subnetPrefixLength))
+		}
+		if data.ExcludeLast.ValueBool() {
+			usedCidrs = append(usedCidrs, cidrutil.LastSubnet(fromCidr, subnetPrefixLength))
+		}
+	}
+
+	// The size being searched for, as it is described in the errors below.
+	blockSize := fmt.Sprintf("a /%d", blockPrefixLength)
+	if rangePrefixes != nil {
+		blockSize = "a masks sized"
+	}
+
+	// The search examines one candidate block for each run of free addresses, so a heavily fragmented search space is
+	// refused up front rather than walked.
+	gaps := cidrutil.FreeGaps(fromCidrs, usedCidrs)
+	if limit := data.MaxSearchBlocks.ValueInt64(); int64(gaps) > limit {
+		diags.AddAttributeError(
+			path.Root("max_search_blocks"),
+			"Search space too large",
+			fmt.Sprintf("Searching for %s block would examine %d candidate blocks, one for each run of free addresses that the used_cidrs, reserved_cidrs "+
+				"and excluded blocks leave in the from_cidrs, which is more than max_search_blocks (%d). Check the inputs for a mistake (ex. used_cidrs that "+
+				"split the from_cidrs into single addresses), or raise max_search_blocks.", blockSize, gaps, limit),
+		)
+		return diags
+	}
+
+	strategy := data.Strategy.ValueString()
+	rng := rand.New(rand.NewSource(randomSeed(r.deterministicSeed, data.Keepers, data.Id)))
+	results := make([]*net.IPNet, 0, blockCount)
+
+	// The used blocks include the reserved_cidrs and the blocks excluded by allow_cidrs, after_cidr and the boundary
+	// options, since they are all avoided in the same way.
+	tflog.Debug(ctx, "searching for available cidrs", map[string]interface{}{
+		"from_cidrs":       len(fromCidrs),
+		"used_blocks":      len(usedCidrs),
+		"candidate_blocks": gaps,
+		"strategy":         strategy,
+		"count":            blockCount,
+	})
+
+	// A free prefer_cidr is allocated first, ahead of the strategy, so existing ranges can be adopted as-is.
+	usedPreferCidr := false
+	if !data.PreferCidr.IsNull() {
+		var preferred *net.IPNet
+		var reason string
+		if rangeMasks != nil {
+			preferred, reason = preferredRangeCidr(data.PreferCidr.ValueString(), fromCidrs, rangeMasks, rangeAligns, usedCidrs)
+		} else {
+			preferred, reason = preferredCidr(data.PreferCidr.ValueString(), fromCidrs, &blockMask, &blockAlign, usedCidrs)
+		}
+		if reason != "" {
+			tflog.Trace(ctx, "not using prefer_cidr: "+reason)
+		} else {
+			results = append(results, preferred)
+			usedCidrs = append(usedCidrs, preferred)
+			usedPreferCidr = true
+		}
+	}
+
+	// Without masks, every range is searched for a block of the same size and alignment.
+	searchMasks, searchAligns := rangeMasks, rangeAligns
+	if searchMasks == nil {
+		searchMasks = make([]net.IPMask, len(fromCidrs))
+		searchAligns = make([]net.IPMask, len(fromCidrs))
+		for i := range fromCidrs {
+			searchMasks[i], searchAligns[i] = blockMask, blockAlign
+		}
+	}
+
+	spread := data.Spread.ValueBool() && blockCount > 1
+	var findErr error
+	for len(results) < blockCount {
+		var result *net.IPNet
+		var err error
+		if spread {
+			result, err = allocateSpread(len(results), strategy, rng, fromCidrs, searchMasks, searchAligns, usedCidrs)
+		} else {
+			result, err = allocateRanges(strategy, rng, fromCidrs, searchMasks, searchAligns, usedCidrs)
+		}
+		if err != nil {
+			findErr = err
+			break
+		}
+
+		// Mark the allocation as used so the next iteration doesn't return it again.
+		results = append(results, result)
+		usedCidrs = append(usedCidrs, result)
+	}
+
+	if findErr != nil {
+		tflog.Debug(ctx, "search for available cidrs stopped", map[string]interface{}{
+			"found":       len(results),
+			"count":       blockCount,
+			"error":       findErr.Error(),
+			"duration_ms": time.Since(start).Milliseconds(),
+		})
+	}
+
+	if len(results) == 0 && findErr != nil && !errors.Is(findErr, cidrutil.ErrNoSpace) {
+		diags.AddError(
+			allocationErrorSummary(findErr),
+			fmt.Sprintf("Unable to search the from_cidrs for %s CIDR.\n\n%s", blockSize, findErr.Error()),
+		)
+		return diags
+	}
+
+	if len(results) == 0 && findErr != nil {
+		usage := cidrutil.Utilization(fromCidrs, usedCidrs)
+		if rangePrefixes != nil {
+			diags.AddError(
+				"No available CIDR found",
+				fmt.Sprintf(
+					"Unable to find an available CIDR of the mask given in masks in any of the from_cidrs. The from_cidrs contain %s addresses, "+
+						"%s of which are used, and the largest contiguous free gap is %s addresses.\n\n%s",
+					usage.Total,
+					usage.Used,
+					usage.LargestGap,
+					findErr.Error(),
+				),
+			)
+			return diags
+		}
+		diags.AddError(
+			"No available CIDR found",
+			fmt.Sprintf(
+				"Unable to find an available /%d CIDR (%s addresses). The from_cidrs contain %s addresses, %s of which are used, "+
+					"and the largest contiguous free gap is %s addresses.\n\n%s",
+				blockPrefixLength,
+				cidrutil.AddressCount(&net.IPNet{IP: fromCidrs[0].IP, Mask: blockMask}),
+				usage.Total,
+				usage.Used,
+				usage.LargestGap,
+				findErr.Error(),
+			),
+		)
+		return diags
+	}
+
+	if len(results) < blockCount {
+		diags.AddError(
+			"Not enough available CIDRs found",
+			fmt.Sprintf("Requested %d CIDRs but only %d were available", allocationCount, len(results)),
+		)
+		return diags
+	}
+
+	// The results were appended to usedCidrs as they were allocated, so the CIDRs before them are what the first
+	// result was placed among.
+	result := results[0]
+	usedBeforeResults := usedCidrs[:len(usedCidrs)-len(results)]
+
+	if coalesce {
+		subnets, err := cidrutil.Subnets(result, prefixLength-blockPrefixLength, int64(allocationCount))
+		if err != nil {
+			diags.AddError(
+				"Error splitting coalesced block",
+				err.Error(),
+			)
+			return diags
+		}
+		results = subnets
+	}
+
+	// Only the order of the results changes, result stays the first allocation.
+	if data.SortResults.ValueBool() {
+		sort.SliceStable(results, func(i, j int) bool {
+			return cidrutil.IPToInt(results[i].IP).Cmp(cidrutil.IPToInt(results[j].IP)) < 0
+		})
+	}
+
+	resultStrings := make([]string, len(results))
+	for i, result := range results {
+		resultStrings[i] = result.String()
+	}
+
+	resultsList, listDiags := types.ListValueFrom(ctx, types.StringType, resultStrings)
+	diags.Append(listDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	usedCidrsNext, listDiags := types.ListValueFrom(ctx, types.StringType, append(append([]string{}, usedCidrsStrings...), resultStrings...))
+	diags.Append(listDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	normalizedFromCidrs, listDiags := types.ListValueFrom(ctx, types.StringType, cidrutil.Strings(fromCidrs))
+	diags.Append(listDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	decision := allocationDecision{
+		Result:        result.String(),
+		Strategy:      strategy,
+		Preferred:     usedPreferCidr,
+		SearchedCidrs: cidrutil.Strings(fromCidrs),
+	}
+	if first, last, ok := cidrutil.Gap(fromCidrs, usedBeforeResults, result); ok {
+		decision.Gap = &allocationGap{First: first.String(), Last: last.String()}
+	}
+	allocationJson, err := json.Marshal(decision)
+	if err != nil {
+		diags.AddError(
+			"Error encoding allocation_json",
+			fmt.Sprintf("Unable to encode the allocation decision: %s", err.Error()),
+		)
+		return diags
+	}
+
+	data.Id = types.StringValue(result.String())
+	data.Result = types.StringValue(result.String())
+	data.Results = resultsList
+	data.UsedCidrsNext = usedCidrsNext
+	data.NormalizedFromCidrs = normalizedFromCidrs
+	data.FromCidr = types.StringNull()
+	if from, ok := cidrutil.Containing(result, fromCidrsStrings); ok {
+		data.FromCidr = types.StringValue(from)
+	}
+	data.AllocationJson = types.StringValue(string(allocationJson))
+	data.setResultAttributes(result)
+	data.Subnets = types.ListNull(types.StringType)
+	if !data.EnumerateBits.IsNull() {
+		subnets, err := enumerateSubnets(result, int(data.EnumerateBits.ValueInt64()), data.EnumerateLimit.ValueInt64())
+		if err != nil {
+			diags.AddError(
+				"Unable to enumerate subnets",
+				err.Error(),
+			)
+			return diags
+		}
+		data.Subnets = stringListValue(cidrutil.Strings(subnets))
+	}
+	// usedCidrs already includes the results, so this is the capacity left after the allocation. A coalesced block
+	// is used in full, even when it holds more subnets than allocation_count.
+	data.setRemainingCapacity(fromCidrs, usedCidrs, &mask, &align)
+	if rangeMasks != nil {
+		blocks := new(big.Int)
+		for i, fromCidr := range fromCidrs {
+			blocks.Add(blocks, cidrutil.AvailableBlocks([]*net.IPNet{fromCidr}, &rangeMasks[i], &rangeAligns[i], usedCidrs))
+		}
+		data.RemainingBlocks = types.Int64Value(cidrutil.SaturatedInt64(blocks))
+	}
+
+	data.formatIPv6()
+
+	ctx = tflog.SetField(ctx, "id", data.Id.ValueString())
+	tflog.Debug(ctx, "allocated available cidrs", map[string]interface{}{
+		"results":     len(results),
+		"from_cidr":   data.FromCidr.ValueString(),
+		"preferred":   usedPreferCidr,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+	tflog.Trace(ctx, "found available cidrs: "+strings.Join(resultStrings, ", "))
+
+	return diags
+}
+
+// maskTooLargeDetail describes why a block with the given prefix length can't be allocated when it is larger than
+// every one of the fromCidrs, naming the prefix of each range. It returns an empty string when any range can hold
+// the block.
+func maskTooLargeDetail(mask int, fromCidrs []*net.IPNet) string {
+	tooSmall := make([]string, 0, len(fromCidrs))
+	for _, fromCidr := range fromCidrs {
+		prefixLength, _ := fromCidr.Mask.Size()
+		if mask >= prefixLength {
+			return ""
+		}
+		tooSmall = append(tooSmall, fmt.Sprintf("%s (/%d)", fromCidr, prefixLength))
+	}
+
+	if len(tooSmall) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("The requested /%d block is larger than every source range in from_cidrs, so it can never be allocated: %s", mask, strings.Join(tooSmall, ", "))
+}
+
+// fromCidrsTooSmallWarnings warns about each of the configured fromCidrs that is smaller than a block with the given
+// prefix length, since no allocation can ever be made from it. A range that dedupe_from_cidrs merged into one of the
+// searchedCidrs that is large enough is still used, so it isn't warned about. Each warning is reported against the
+// element it came from.
+func (m *AvailableCidrResourceModel) fromCidrsTooSmallWarnings(prefixLength int, fromCidrs []*net.IPNet, searchedCidrs []*net.IPNet) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for i, fromCidr := range fromCidrs {
+		if ones, _ := fromCidr.Mask.Size(); ones <= prefixLength {
+			continue
+		}
+		usable := false
+		for _, searched := range searchedCidrs {
+			if ones, _ := searched.Mask.Size(); ones <= prefixLength && cidrutil.Contains(searched, fromCidr) {
+				usable = true
+				break
+			}
+		}
+		if !usable {
+			diags.AddAttributeWarning(
+				m.fromCidrPath(i),
+				"from_cidr too small for mask",
+				fmt.Sprintf("from_cidr %s is too small for a /%d allocation, so it is skipped when searching for the result", fromCidr, prefixLength),
+			)
+		}
+	}
+	return diags
+}
+
+// preferredCidr returns the network of prefer if it can be allocated, which is when it is the size of mask, lies
+// within one of the fromCidrs, starts on a boundary of align and doesn't overlap any of the usedCidrs. Otherwise
+// it returns the reason it can't be used.
+func preferredCidr(prefer string, fromCidrs []*net.IPNet, mask *net.IPMask, align *net.IPMask, usedCidrs []*net.IPNet) (*net.IPNet, string) {
+	_, network, err := net.ParseCIDR(prefer)
+	if err != nil {
+		return nil, err.Error()
+	}
+
+	ones, bits := network.Mask.Size()
+	maskOnes, maskBits := mask.Size()
+	if ones != maskOnes || bits != maskBits {
+		return nil, fmt.Sprintf("%s is not a /%d", prefer, maskOnes)
+	}
+
+	alignOnes, _ := align.Size()
+	if !network.IP.Equal(network.IP.Mask(net.CIDRMask(alignOnes, bits))) {
+		return nil, fmt.Sprintf("%s does not start on a /%d boundary", prefer, alignOnes)
+	}
+
+	if len(cidrutil.Outside(fromCidrs, []*net.IPNet{network})) > 0 {
+		return nil, fmt.Sprintf("%s is not within any of the from_cidrs", prefer)
+	}
+
+	for _, used := range usedCidrs {
+		if cidrutil.Overlaps(network, used) {
+			return nil, fmt.Sprintf("%s overlaps %s", prefer, used)
+		}
+	}
+
+	return network, ""
+}
+
+// preferredRangeCidr is preferredCidr for when each of the fromCidrs has its own mask and alignment, so prefer is
+// used when it fits in any of the ranges as a block of that range's size. The reason returned is the one for the last
+// range that was tried.
+func preferredRangeCidr(prefer string, fromCidrs []*net.IPNet, masks []net.IPMask, aligns []net.IPMask, usedCidrs []*net.IPNet) (*net.IPNet, string) {
+	reason := fmt.Sprintf("%s is not within any of the from_cidrs", prefer)
+	for i, fromCidr := range fromCidrs {
+		var preferred *net.IPNet
+		preferred, reason = preferredCidr(prefer, []*net.IPNet{fromCidr}, &masks[i], &aligns[i], usedCidrs)
+		if reason == "" {
+			return preferred, ""
+		}
+	}
+	return nil, reason
+}
+
+// rangePrefixLengths returns the prefix length to allocate from each of the fromCidrs, which is the matching entry of
+// masks, or prefixLength for a null entry. An error names the first entry that doesn't fit its range.
+func rangePrefixLengths(prefixLength int, masks types.List, fromCidrs []*net.IPNet) ([]int, error) {
+	elements := masks.Elements()
+	if len(elements) != len(fromCidrs) {
+		return nil, fmt.Errorf("masks has %d entries, but there are %d from_cidrs. There must be exactly one mask for each of the from_cidrs, in the same order", len(elements), len(fromCidrs))
+	}
+
+	prefixLengths := make([]int, len(fromCidrs))
+	for i, element := range elements {
+		prefixLengths[i] = prefixLength
+		if m, ok := element.(types.Int64); ok && !m.IsNull() && !m.IsUnknown() {
+			prefixLengths[i] = int(m.ValueInt64())
+		}
+
+		ones, bits := fromCidrs[i].Mask.Size()
+		if prefixLengths[i] > bits {
+			return nil, fmt.Errorf("masks entry %d must be between 0 and %d for %s from_cidrs, got %d", i, bits, addressFamilyName(bits), prefixLengths[i])
+		}
+		if prefixLengths[i] < ones {
+			return nil, fmt.Errorf("masks entry %d is /%d, which is larger than the from_cidrs entry %s it is allocated from", i, prefixLengths[i], fromCidrs[i])
+		}
+	}
+	return prefixLengths, nil
+}
+
+// checkStartOffset returns an error if skipping offset blocks of the given prefix length at the start of each of the
+// fromCidrs would leave nothing to search, because the largest of them doesn't hold more than offset blocks.
+func checkStartOffset(offset int64, prefixLength int, fromCidrs []*net.IPNet) error {
+	largest := new(big.Int)
+	for _, fromCidr := range fromCidrs {
+		ones, bits := fromCidr.Mask.Size()
+		if ones > prefixLength || prefixLength > bits {
+			continue
+		}
+		if blocks := new(big.Int).Lsh(big.NewInt(1), uint(prefixLength-ones)); blocks.Cmp(largest) > 0 {
+			largest = blocks
+		}
+	}
+
+	if big.NewInt(offset).Cmp(largest) < 0 {
+		return nil
+	}
+	return fmt.Errorf("a start_offset of %d skips every /%d block in the from_cidrs, the largest of which only holds %s", offset, prefixLength, largest)
+}
+
+// checkEnumerateLimit returns an error if splitting a network into subnets enumerateBits longer than its prefix
+// produces more than limit subnets.
+func checkEnumerateLimit(enumerateBits int, limit int64) error {
+	if enumerateBits < 63 && int64(1)<<enumerateBits <= limit {
+		return nil
+	}
+	return fmt.Errorf("an enumerate_bits of %d produces 2^%d subnets, which is more than the enumerate_limit of %d", enumerateBits, enumerateBits, limit)
+}
+
+// enumerateSubnets returns every subnet of result that is enumerateBits longer than its prefix, as long as there are
+// no more than limit of them.
+func enumerateSubnets(result *net.IPNet, enumerateBits int, limit int64) ([]*net.IPNet, error) {
+	ones, bits := result.Mask.Size()
+	if ones+enumerateBits > bits {
+		return nil, fmt.Errorf("an enumerate_bits of %d is too large for the /%d result %s, which only has %d host bits", enumerateBits, ones, result, bits-ones)
+	}
+	if err := checkEnumerateLimit(enumerateBits, limit); err != nil {
+		return nil, err
+	}
+	return cidrutil.Subnets(result, enumerateBits, int64(1)<<enumerateBits)
+}
+
+// growResults grows the result in prior to the mask in data without changing its network address, and fills in the
+// computed attributes of data to match. Growth that isn't possible is an error rather than a reason to move the
+// result, since the point of growing is that everything already using the result keeps working.
+func (r *AvailableCidrResource) growResults(ctx context.Context, data *AvailableCidrResourceModel, prior *AvailableCidrResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if count := len(prior.Results.Elements()); count != 1 || data.AllocationCount.ValueInt64() != 1 {
+		diags.AddAttributeError(
+			path.Root("allow_grow"),
+			"Unable to grow result in place",
+			fmt.Sprintf("Only a single result can be grown in place, but there are %d results and allocation_count is %d", count, data.AllocationCount.ValueInt64()),
+		)
+		return diags
+	}
+
+	_, result, err := net.ParseCIDR(prior.Result.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error parsing result",
+			fmt.Sprintf("Unable to parse the stored result %q: %s", prior.Result.ValueString(), err.Error()),
+		)
+		return diags
+	}
+
+	usedCidrs, usedDiags := effectiveUsedCidrs(data.UsedCidrs, data.UsedCidrsJSON)
+	diags.Append(usedDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	var usedCidrsStrings, reservedCidrsStrings []string
+	if !usedCidrs.IsNull() {
+		diags.Append(usedCidrs.ElementsAs(ctx, &usedCidrsStrings, false)...)
+	}
+	if !data.ReservedCidrs.IsNull() {
+		diags.Append(data.ReservedCidrs.ElementsAs(ctx, &reservedCidrsStrings, false)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	// Malformed entries are skipped here and reported when the grown result is allocated.
+	var blocked []*net.IPNet
+	for _, cidr := range append(append([]string{}, usedCidrsStrings...), reservedCidrsStrings...) {
+		if _, network, err := net.ParseCIDR(cidr); err == nil {
+			blocked = append(blocked, network)
+		}
+	}
+
+	grown, overlaps, err := cidrutil.Grow(result, int(data.Mask.ValueInt64()), blocked)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("mask"),
+			"Unable to grow result in place",
+			err.Error(),
+		)
+		return diags
+	}
+	if len(overlaps) > 0 {
+		diags.AddAttributeError(
+			path.Root("mask"),
+			"Unable to grow result in place",
+			fmt.Sprintf("Growing %s to %s would overlap these used_cidrs or reserved_cidrs: %s. Free the space, or set replace_on_input_change to allocate a new CIDR instead.",
+				result, grown, strings.Join(cidrutil.Strings(overlaps), ", ")),
+		)
+		return diags
+	}
+
+	// The grown block is allocated as the prefer_cidr so that from_cidrs, allow_cidrs and the excluded blocks are
+	// checked exactly as they are for any other allocation. The used_cidrs within the current result are left out,
+	// since they are already part of it. The used_cidrs_json entries are merged into the used_cidrs for the same reason.
+	grow := *data
+	grow.PreferCidr = types.StringValue(grown.String())
+	grow.UsedCidrs = cidrsOutsideOf(result, usedCidrs, usedCidrsStrings)
+	grow.UsedCidrsJSON = types.StringNull()
+	grow.ReservedCidrs = cidrsOutsideOf(result, data.ReservedCidrs, reservedCidrsStrings)
+	grow.setResultsUnknown()
+	diags.Append(r.allocateResults(ctx, &grow)...)
+	if diags.HasError() {
+		return diags
+	}
+	if grow.Result.ValueString() != formatIPv6Cidr(grown.String(), data.Ipv6Format.ValueString()) {
+		diags.AddAttributeError(
+			path.Root("mask"),
+			"Unable to grow result in place",
+			fmt.Sprintf("Growing %s to %s would leave the from_cidrs or allow_cidrs, or take a block excluded by exclude_first_subnet, exclude_last_subnet, start_offset or after_cidr.", result, grown),
+		)
+		return diags
+	}
+
+	tflog.Debug(ctx, "growing result in place", map[string]interface{}{
+		"result": result.String(),
+		"grown":  grown.String(),
+	})
+	data.keepResults(&grow)
+	data.UsedCidrsNext = stringListValue(append(usedCidrsStrings, grown.String()))
+	data.formatIPv6()
+	return diags
+}
+
+// cidrsOutsideOf returns list without the entries of cidrs (its elements) that are within network, leaving a null
+// list as-is.
+func cidrsOutsideOf(network *net.IPNet, list types.List, cidrs []string) types.List {
+	if list.IsNull() {
+		return list
+	}
+
+	outside := []string{}
+	for _, cidr := range cidrs {
+		if _, parsed, err := net.ParseCIDR(cidr); err == nil && cidrutil.Contains(network, parsed) {
+			continue
+		}
+		outside = append(outside, cidr)
+	}
+	return stringListValue(outside)
+}
+
+// setRemainingCapacity populates the computed attributes describing how much of fromCidrs is still free once
+// usedCidrs are taken.
+func (m *AvailableCidrResourceModel) setRemainingCapacity(fromCidrs []*net.IPNet, usedCidrs []*net.IPNet, mask *net.IPMask, align *net.IPMask) {
+	usage := cidrutil.Utilization(fromCidrs, usedCidrs)
+	remaining := new(big.Int).Sub(usage.Total, usage.Used)
+
+	m.RemainingBlocks = types.Int64Value(cidrutil.SaturatedInt64(cidrutil.AvailableBlocks(fromCidrs, mask, align, usedCidrs)))
+	m.RemainingAddresses = types.Int64Value(cidrutil.SaturatedInt64(remaining))
+}
+
+// maxSearchWorkers bounds how many of the from_cidrs are searched at the same time.
+var maxSearchWorkers = runtime.GOMAXPROCS(0)
+
+// candidate is the result of searching a single from_cidr for an available block.
+type candidate struct {
+	network *net.IPNet
+	// remaining is the number of addresses left free in the gap the network was placed in. It is only set by the
+	// best_fit strategy.
+	remaining *big.Int
+	err       error
+}
+
+// allocate searches each of the fromCidrs for an available block of size mask, as allocateRanges does when every range
+// has the same mask and alignment.
+func allocate(strategy string, rng *rand.Rand, fromCidrs []*net.IPNet, mask *net.IPMask, align *net.IPMask, usedCidrs []*net.IPNet) (*net.IPNet, error) {
+	masks := make([]net.IPMask, len(fromCidrs))
+	aligns := make([]net.IPMask, len(fromCidrs))
+	for i := range fromCidrs {
+		masks[i], aligns[i] = *mask, *align
+	}
+	return allocateRanges(strategy, rng, fromCidrs, masks, aligns, usedCidrs)
+}
+
+// allocateRanges searches each of the fromCidrs for an available block and uses the strategy to choose between them.
+// first_fit returns the block from the earliest range in fromCidrs that has space, last_fit returns the highest
+// block, best_fit returns the block that leaves the smallest gap, preferring the lowest address, and compact returns
+// the lowest block. The ranges are searched in parallel, but the choice only depends on the candidates and their
+// order in fromCidrs, so the result is the same regardless of which search finishes first. A range without space isn't fatal as long as another
+// range has space, so an error is only returned when none of the ranges yield a result, and it describes why each
+// range failed. Each range is searched for a block of the matching entry of masks, starting on a boundary of the
+// matching entry of aligns, which is the mask itself unless a coarser alignment is wanted.
+func allocateRanges(strategy string, rng *rand.Rand, fromCidrs []*net.IPNet, masks []net.IPMask, aligns []net.IPMask, usedCidrs []*net.IPNet) (*net.IPNet, error) {
+	// The random strategy draws from a shared rng, so the ranges are searched in order to keep the draws, and
+	// therefore the result, deterministic.
+	if strategy == strategyRandom {
+		errs := make([]error, 0, len(fromCidrs))
+		for i, fromCidr := range fromCidrs {
+			result, err := cidrutil.FindRandomAlignedCIDR(fromCidr, &masks[i], &aligns[i], usedCidrs, rng)
+			if err == nil && result != nil {
+				return result, nil
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return nil, allocationError(errs)
+	}
+
+	// Each search writes to its own index, so the candidates stay in the order of fromCidrs.
+	candidates := make([]candidate, len(fromCidrs))
+	var group errgroup.Group
+	group.SetLimit(maxSearchWorkers)
+	for i, fromCidr := range fromCidrs {
+		i, fromCidr := i, fromCidr
+		group.Go(func() error {
+			candidates[i] = findAvailableCIDR(strategy, fromCidr, &masks[i], &aligns[i], usedCidrs)
+			return nil
+		})
+	}
+	// Failed searches are recorded on their candidate rather than returned, so every range always reports.
+	_ = group.Wait()
+
+	var best *candidate
+	errs := make([]error, 0, len(fromCidrs))
+	for i := range candidates {
+		c := &candidates[i]
+		if c.err != nil || c.network == nil {
+			if c.err != nil {
+				errs = append(errs, c.err)
+			}
+			continue
+		}
+		if best == nil || c.preferredTo(best, strategy) {
+			best = c
+		}
+	}
+
+	if best == nil {
+		return nil, allocationError(errs)
+	}
+	return best.network, nil
+}
+
+// allocateSpread allocates the turn-th block for spread, from the range whose turn it is. The ranges take turns in
+// the order of fromCidrs, wrapping around, and a range without space passes its turn to the next, so the error is
+// only returned when none of the ranges have space. The strategy only chooses where within the range the block goes.
+func allocateSpread(turn int, strategy string, rng *rand.Rand, fromCidrs []*net.IPNet, masks []net.IPMask, aligns []net.IPMask, usedCidrs []*net.IPNet) (*net.IPNet, error) {
+	errs := make([]error, 0, len(fromCidrs))
+	for offset := range fromCidrs {
+		i := (turn + offset) % len(fromCidrs)
+		result, err := allocateRanges(strategy, rng, fromCidrs[i:i+1], masks[i:i+1], aligns[i:i+1], usedCidrs)
+		if err == nil {
+			return result, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, allocationError(errs)
+}
+
+// allocationError combines the errors from searching each range, one per line, so that errors.Is matches the
+// cidrutil error kinds of any of them.
+func allocationError(errs []error) error {
+	if len(errs) == 0 {
+		return fmt.Errorf("%w: there are no ranges to search", cidrutil.ErrNoSpace)
+	}
+	return errors.Join(errs...)
+}
+
+// allocationErrorSummary returns the diagnostic summary for an error from allocate, so that running out of space
+// reads differently from inputs that can never be allocated. Ranges can fail for different reasons, and any range
+// that is merely full means the inputs are valid, so running out of space takes precedence.
+func allocationErrorSummary(err error) string {
+	switch {
+	case errors.Is(err, cidrutil.ErrNoSpace):
+		return "No available CIDR found"
+	case errors.Is(err, cidrutil.ErrInvalidMask):
+		return "Invalid mask for from_cidrs"
+	case errors.Is(err, cidrutil.ErrInvalidAlign):
+		return "Invalid alignment for mask"
+	default:
+		return "Unexpected error allocating CIDR"
+	}
+}
+
+// preferredTo reports whether c should be chosen over other, a candidate from an earlier range. Ties keep the
+// earlier range.
+func (c *candidate) preferredTo(other *candidate, strategy string) bool {
+	switch strategy {
+	case strategyLastFit:
+		return cidrutil.IPToInt(c.network.IP).Cmp(cidrutil.IPToInt(other.network.IP)) > 0
+	case strategyCompact:
+		return cidrutil.IPToInt(c.network.IP).Cmp(cidrutil.IPToInt(other.network.IP)) < 0
+	case strategyBestFit:
+		if cmp := c.remaining.Cmp(other.remaining); cmp != 0 {
+			return cmp < 0
+		}
+		return cidrutil.IPToInt(c.network.IP).Cmp(cidrutil.IPToInt(other.network.IP)) < 0
+	default:
+		return false
+	}
+}
+
+// findAvailableCIDR searches fromCidr for a block of size mask that doesn't overlap usedCidrs, using the given
+// allocation strategy to choose between the available blocks in the range that start on a boundary of align.
+func findAvailableCIDR(strategy string, fromCidr *net.IPNet, mask *net.IPMask, align *net.IPMask, usedCidrs []*net.IPNet) candidate {
+	switch strategy {
+	case strategyLastFit:
+		network, err := cidrutil.FindLastAlignedCIDR(fromCidr, mask, align, usedCidrs)
+		return candidate{network: network, err: err}
+	case strategyBestFit:
+		network, remaining, err := cidrutil.FindBestAlignedCIDR(fromCidr, mask, align, usedCidrs)
+		return candidate{network: network, remaining: remaining, err: err}
+	default:
+		network, err := cidrutil.FindFirstAlignedCIDR(fromCidr, mask, align, usedCidrs)
+		return candidate{network: network, err: err}
+	}
+}
+
+// randomSeed returns the provider's deterministic_seed when it is set, and otherwise derives the seed from the
+// keepers, or the id when there are none.
+func randomSeed(deterministicSeed *int64, keepers types.Map, id types.String) int64 {
+	if deterministicSeed != nil {
+		return *deterministicSeed
+	}
+	return keepersSeed(keepers, id)
+}
+
+// keepersSeed derives a seed for the random strategy by hashing the keepers, so that the same keepers always
+// result in the same allocation. Empty keepers produce a constant seed. Null keepers fall back to hashing the id,
+// as for an imported resource, but only once the id is known: a new resource has no id until it is allocated, so it
+// gets the same constant seed as empty keepers.
+func keepersSeed(keepers types.Map, id types.String) int64 {
+	if keepers.IsNull() && !id.IsNull() && !id.IsUnknown() {
+		hash := fnv.New64a()
+		fmt.Fprintf(hash, "id=%s\n", id.ValueString())
+		return int64(hash.Sum64())
+	}
+
+	elements := keepers.Elements()
+
+	keys := make([]string, 0, len(elements))
+	for key := range elements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := fnv.New64a()
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%q=%s\n", key, elements[key].String())
+	}
+
+	return int64(hash.Sum64())
+}
@@ -0,0 +1,565 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestCheckStartOffset(t *testing.T) {
+	tests := []struct {
+		name         string
+		offset       int64
+		prefixLength int
+		fromCidrs    []string
+		wantErr      bool
+	}{
+		{name: "zero", offset: 0, prefixLength: 24, fromCidrs: []string{"10.1.0.0/16"}},
+		{name: "last block", offset: 255, prefixLength: 24, fromCidrs: []string{"10.1.0.0/16"}},
+		{name: "every block", offset: 256, prefixLength: 24, fromCidrs: []string{"10.1.0.0/16"}, wantErr: true},
+		{name: "largest from_cidr decides", offset: 16, prefixLength: 24, fromCidrs: []string{"10.1.0.0/20", "10.2.0.0/16"}},
+		{name: "from_cidrs smaller than the mask hold nothing", offset: 0, prefixLength: 24, fromCidrs: []string{"10.1.0.0/25"}, wantErr: true},
+		{name: "ipv6", offset: 1 << 40, prefixLength: 64, fromCidrs: []string{"fd00::/16"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fromCidrs := make([]*net.IPNet, len(tc.fromCidrs))
+			for i, from := range tc.fromCidrs {
+				_, fromCidrs[i], _ = net.ParseCIDR(from)
+			}
+
+			err := checkStartOffset(tc.offset, tc.prefixLength, fromCidrs)
+			if tc.wantErr && err == nil {
+				t.Errorf("expected error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestEnumerateSubnets(t *testing.T) {
+	tests := []struct {
+		name          string
+		result        string
+		enumerateBits int
+		limit         int64
+		want          []string
+		wantErr       string
+	}{
+		{name: "zero bits is the result", result: "10.1.0.0/24", enumerateBits: 0, limit: 1, want: []string{"10.1.0.0/24"}},
+		{name: "splits the result", result: "10.1.0.0/24", enumerateBits: 2, limit: 4, want: []string{"10.1.0.0/26", "10.1.0.64/26", "10.1.0.128/26", "10.1.0.192/26"}},
+		{name: "over the limit", result: "10.1.0.0/24", enumerateBits: 3, limit: 4, wantErr: "more than the enumerate_limit of 4"},
+		{name: "more bits than the result has", result: "10.1.0.0/24", enumerateBits: 9, limit: 1024, wantErr: "only has 8 host bits"},
+		{name: "huge ipv6 split", result: "fd00::/48", enumerateBits: 80, limit: 1024, wantErr: "produces 2^80 subnets"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, result, err := net.ParseCIDR(tc.result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := enumerateSubnets(result, tc.enumerateBits, tc.limit)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if strings.Join(cidrutil.Strings(got), ",") != strings.Join(tc.want, ",") {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAllocateCompact(t *testing.T) {
+	parse := func(cidrs ...string) []*net.IPNet {
+		networks := make([]*net.IPNet, len(cidrs))
+		for i, c := range cidrs {
+			_, networks[i], _ = net.ParseCIDR(c)
+		}
+		return networks
+	}
+	fromCidrs := parse("10.2.0.0/16", "10.1.0.0/16")
+	used := parse("10.1.0.0/24", "10.1.2.0/24", "10.2.0.0/24")
+	mask := net.CIDRMask(24, 32)
+
+	want := map[string]string{
+		strategyFirstFit: "10.2.1.0/24",
+		strategyCompact:  "10.1.1.0/24",
+	}
+	for strategy, result := range want {
+		got, err := allocate(strategy, nil, fromCidrs, &mask, &mask, used)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", strategy, err)
+		}
+		if got.String() != result {
+			t.Errorf("%s: got %v, want %v", strategy, got, result)
+		}
+	}
+
+	// Once the freed block is reused, the high end of the lowest range is extended next.
+	used = append(used, parse("10.1.1.0/24")...)
+	got, err := allocate(strategyCompact, nil, fromCidrs, &mask, &mask, used)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got.String() != "10.1.3.0/24" {
+		t.Errorf("got %v, want 10.1.3.0/24", got)
+	}
+}
+
+func TestAllocateIsDeterministic(t *testing.T) {
+	fromCidrs := make([]*net.IPNet, 0, 16)
+	for i := 0; i < 16; i++ {
+		_, fromCidr, _ := net.ParseCIDR(fmt.Sprintf("10.%d.0.0/16", i))
+		fromCidrs = append(fromCidrs, fromCidr)
+	}
+	_, used, _ := net.ParseCIDR("10.0.0.0/16")
+	mask := net.CIDRMask(24, 32)
+
+	want := map[string]string{
+		strategyFirstFit: "10.1.0.0/24",
+		strategyLastFit:  "10.15.255.0/24",
+		strategyBestFit:  "10.1.0.0/24",
+		strategyCompact:  "10.1.0.0/24",
+	}
+	for strategy, result := range want {
+		for i := 0; i < 50; i++ {
+			got, err := allocate(strategy, nil, fromCidrs, &mask, &mask, []*net.IPNet{used})
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %s", strategy, err)
+			}
+			if got.String() != result {
+				t.Fatalf("%s: got %v, want %v", strategy, got, result)
+			}
+		}
+	}
+}
+
+func TestKeepersSeed(t *testing.T) {
+	keepers := func(value string) types.Map {
+		return types.MapValueMust(types.StringType, map[string]attr.Value{"key": types.StringValue(value)})
+	}
+	empty := types.MapValueMust(types.StringType, map[string]attr.Value{})
+	null := types.MapNull(types.StringType)
+
+	if keepersSeed(keepers("one"), types.StringNull()) != keepersSeed(keepers("one"), types.StringValue("10.0.1.0/24")) {
+		t.Error("keepersSeed() depends on the id when there are keepers")
+	}
+	if keepersSeed(keepers("one"), types.StringNull()) == keepersSeed(keepers("two"), types.StringNull()) {
+		t.Error("keepersSeed() is the same for different keepers")
+	}
+
+	// A new resource has no id yet, so without keepers it always gets the same seed.
+	if keepersSeed(null, types.StringUnknown()) != keepersSeed(empty, types.StringUnknown()) {
+		t.Error("keepersSeed() with null keepers and an unknown id differs from empty keepers")
+	}
+
+	// An imported resource without keepers is seeded from its id.
+	if keepersSeed(null, types.StringValue("10.0.1.0/24")) == keepersSeed(null, types.StringUnknown()) {
+		t.Error("keepersSeed() with null keepers doesn't fall back to the id")
+	}
+	if keepersSeed(null, types.StringValue("10.0.1.0/24")) == keepersSeed(null, types.StringValue("10.0.2.0/24")) {
+		t.Error("keepersSeed() is the same for different ids")
+	}
+	if keepersSeed(empty, types.StringValue("10.0.1.0/24")) != keepersSeed(empty, types.StringUnknown()) {
+		t.Error("keepersSeed() with empty keepers depends on the id")
+	}
+}
+
+func TestFromCidrsTooSmallWarnings(t *testing.T) {
+	type warning struct {
+		path   path.Path
+		detail string
+	}
+
+	tests := []struct {
+		name         string
+		prefixLength int
+		fromCidrs    []string
+		dedupe       bool
+		want         []warning
+	}{
+		{name: "all large enough", prefixLength: 24, fromCidrs: []string{"10.0.0.0/16", "10.1.0.0/24"}},
+		{
+			name:         "single host",
+			prefixLength: 24,
+			fromCidrs:    []string{"10.0.0.5/32", "10.1.0.0/16"},
+			want:         []warning{{path.Root("from_cidrs").AtListIndex(0), "from_cidr 10.0.0.5/32 is too small for a /24 allocation"}},
+		},
+		{
+			name:         "each small range is named",
+			prefixLength: 24,
+			fromCidrs:    []string{"10.0.0.0/25", "10.1.0.0/16", "10.2.0.0/26"},
+			want: []warning{
+				{path.Root("from_cidrs").AtListIndex(0), "from_cidr 10.0.0.0/25 is too small"},
+				{path.Root("from_cidrs").AtListIndex(2), "from_cidr 10.2.0.0/26 is too small"},
+			},
+		},
+		{
+			name:         "duplicates are each named",
+			prefixLength: 24,
+			fromCidrs:    []string{"10.0.0.0/25", "10.1.0.0/16", "10.0.0.0/25"},
+			want: []warning{
+				{path.Root("from_cidrs").AtListIndex(0), "from_cidr 10.0.0.0/25 is too small"},
+				{path.Root("from_cidrs").AtListIndex(2), "from_cidr 10.0.0.0/25 is too small"},
+			},
+		},
+		{
+			name:         "merged by dedupe_from_cidrs",
+			prefixLength: 24,
+			fromCidrs:    []string{"10.0.0.0/25", "10.0.0.128/25", "10.1.0.0/26"},
+			dedupe:       true,
+			want:         []warning{{path.Root("from_cidrs").AtListIndex(2), "from_cidr 10.1.0.0/26 is too small"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data := AvailableCidrResourceModel{FromCidrs: stringListValue(tc.fromCidrs)}
+			fromCidrs := make([]*net.IPNet, len(tc.fromCidrs))
+			for i, from := range tc.fromCidrs {
+				_, fromCidrs[i], _ = net.ParseCIDR(from)
+			}
+			searchedCidrs := cidrutil.Normalize(fromCidrs)
+			if tc.dedupe {
+				searchedCidrs = cidrutil.Aggregate(searchedCidrs)
+			}
+
+			diags := data.fromCidrsTooSmallWarnings(tc.prefixLength, fromCidrs, searchedCidrs)
+			if diags.HasError() {
+				t.Fatalf("expected only warnings, got %+v", diags)
+			}
+			if len(diags) != len(tc.want) {
+				t.Fatalf("expected %d warnings, got %+v", len(tc.want), diags)
+			}
+			for i, want := range tc.want {
+				withPath, ok := diags[i].(diag.DiagnosticWithPath)
+				if !ok || !withPath.Path().Equal(want.path) {
+					t.Errorf("warning %d: expected path %s, got %+v", i, want.path, diags[i])
+				}
+				if !strings.Contains(diags[i].Detail(), want.detail) {
+					t.Errorf("warning %d: expected %q in %q", i, want.detail, diags[i].Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestAllocationErrorSummary(t *testing.T) {
+	parse := func(cidrs ...string) []*net.IPNet {
+		networks := make([]*net.IPNet, len(cidrs))
+		for i, c := range cidrs {
+			_, networks[i], _ = net.ParseCIDR(c)
+		}
+		return networks
+	}
+
+	tests := []struct {
+		name  string
+		from  []*net.IPNet
+		used  []*net.IPNet
+		mask  int
+		align int
+		want  string
+	}{
+		{name: "out of space", from: parse("10.0.0.0/24"), used: parse("10.0.0.0/24"), mask: 26, align: 26, want: "No available CIDR found"},
+		{name: "mask larger than every range", from: parse("10.0.0.0/24", "10.0.1.0/25"), mask: 16, align: 16, want: "Invalid mask for from_cidrs"},
+		{name: "alignment finer than mask", from: parse("10.0.0.0/24"), mask: 26, align: 28, want: "Invalid alignment for mask"},
+		{name: "a full range outranks one that is too small", from: parse("10.0.0.0/28", "10.0.1.0/24"), used: parse("10.0.1.0/24"), mask: 26, align: 26, want: "No available CIDR found"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, strategy := range []string{strategyFirstFit, strategyLastFit, strategyBestFit, strategyRandom, strategyCompact} {
+				mask := net.CIDRMask(tc.mask, 32)
+				align := net.CIDRMask(tc.align, 32)
+				result, err := allocate(strategy, rand.New(rand.NewSource(1)), tc.from, &mask, &align, tc.used)
+				if err == nil {
+					t.Fatalf("%s: expected an error, got %v", strategy, result)
+				}
+				if got := allocationErrorSummary(err); got != tc.want {
+					t.Errorf("%s: got %q, want %q (%s)", strategy, got, tc.want, err)
+				}
+			}
+		})
+	}
+
+	if got := allocationErrorSummary(errors.New("unexpected")); got != "Unexpected error allocating CIDR" {
+		t.Errorf("got %q for an unclassified error", got)
+	}
+}
+
+func TestRangePrefixLengths(t *testing.T) {
+	_, v4Large, _ := net.ParseCIDR("10.0.0.0/16")
+	_, v4Small, _ := net.ParseCIDR("10.1.0.0/20")
+	fromCidrs := []*net.IPNet{v4Large, v4Small}
+
+	masks := func(values ...attr.Value) types.List {
+		return types.ListValueMust(types.Int64Type, values)
+	}
+
+	tests := []struct {
+		name    string
+		masks   types.List
+		want    []int
+		wantErr string
+	}{
+		{name: "per range", masks: masks(types.Int64Value(20), types.Int64Value(26)), want: []int{20, 26}},
+		{name: "null uses mask", masks: masks(types.Int64Null(), types.Int64Value(26)), want: []int{24, 26}},
+		{name: "whole range", masks: masks(types.Int64Value(16), types.Int64Value(20)), want: []int{16, 20}},
+		{name: "too few", masks: masks(types.Int64Value(24)), wantErr: "masks has 1 entries"},
+		{name: "larger than range", masks: masks(types.Int64Value(24), types.Int64Value(19)), wantErr: "masks entry 1 is /19"},
+		{name: "beyond address", masks: masks(types.Int64Value(33), types.Int64Value(24)), wantErr: "between 0 and 32"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rangePrefixLengths(24, tt.masks, fromCidrs)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("rangePrefixLengths() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("rangePrefixLengths() returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rangePrefixLengths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllocateRanges(t *testing.T) {
+	_, v4Large, _ := net.ParseCIDR("10.0.0.0/16")
+	_, v4Small, _ := net.ParseCIDR("10.1.0.0/20")
+	fromCidrs := []*net.IPNet{v4Large, v4Small}
+	masks := []net.IPMask{net.CIDRMask(24, 32), net.CIDRMask(26, 32)}
+	used := []*net.IPNet{v4Large}
+
+	for _, strategy := range []string{strategyFirstFit, strategyLastFit, strategyBestFit, strategyRandom, strategyCompact} {
+		result, err := allocateRanges(strategy, rand.New(rand.NewSource(1)), fromCidrs, masks, masks, used)
+		if err != nil {
+			t.Fatalf("%s: allocateRanges() returned error: %v", strategy, err)
+		}
+		if ones, _ := result.Mask.Size(); ones != 26 || !v4Small.Contains(result.IP) {
+			t.Errorf("%s: allocateRanges() = %s, want a /26 within %s", strategy, result, v4Small)
+		}
+	}
+}
+
+func TestAllocateSpread(t *testing.T) {
+	_, first, _ := net.ParseCIDR("10.1.0.0/24")
+	_, second, _ := net.ParseCIDR("10.2.0.0/24")
+	fromCidrs := []*net.IPNet{first, second}
+	mask := net.CIDRMask(26, 32)
+	masks := []net.IPMask{mask, mask}
+
+	for _, strategy := range []string{strategyFirstFit, strategyLastFit, strategyBestFit, strategyRandom, strategyCompact} {
+		t.Run(strategy, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			used := []*net.IPNet{}
+			counts := map[string]int{}
+			for turn := 0; turn < 6; turn++ {
+				result, err := allocateSpread(turn, strategy, rng, fromCidrs, masks, masks, used)
+				if err != nil {
+					t.Fatalf("allocateSpread(%d) returned error: %v", turn, err)
+				}
+				used = append(used, result)
+				for _, from := range fromCidrs {
+					if from.Contains(result.IP) {
+						counts[from.String()]++
+					}
+				}
+			}
+			// Each range holds four /26s, so six allocations are split evenly.
+			if counts[first.String()] != 3 || counts[second.String()] != 3 {
+				t.Errorf("allocateSpread() distributed %v, want 3 in each range", counts)
+			}
+
+			// Two more allocations fill both ranges, after which there is no space left in either.
+			for turn := 6; turn < 8; turn++ {
+				result, err := allocateSpread(turn, strategy, rng, fromCidrs, masks, masks, used)
+				if err != nil {
+					t.Fatalf("allocateSpread(%d) returned error: %v", turn, err)
+				}
+				used = append(used, result)
+			}
+			if _, err := allocateSpread(8, strategy, rng, fromCidrs, masks, masks, used); !errors.Is(err, cidrutil.ErrNoSpace) {
+				t.Errorf("allocateSpread() on full ranges error = %v, want ErrNoSpace", err)
+			}
+		})
+	}
+}
+
+func TestAllocateResults(t *testing.T) {
+	tests := []struct {
+		name            string
+		fromCidrs       []string
+		usedCidrs       []string
+		mask            int64
+		allocationCount int64
+		strategy        string
+		want            []string
+		wantErr         string
+	}{
+		{name: "first fit", fromCidrs: []string{"10.0.0.0/16"}, usedCidrs: []string{"10.0.0.0/24", "10.0.2.0/24"}, mask: 24, allocationCount: 1, strategy: strategyFirstFit, want: []string{"10.0.1.0/24"}},
+		{name: "last fit", fromCidrs: []string{"10.0.0.0/16"}, usedCidrs: []string{"10.0.255.0/24"}, mask: 24, allocationCount: 1, strategy: strategyLastFit, want: []string{"10.0.254.0/24"}},
+		{name: "best fit", fromCidrs: []string{"10.0.0.0/16"}, usedCidrs: []string{"10.0.2.0/24", "10.0.4.0/24"}, mask: 24, allocationCount: 1, strategy: strategyBestFit, want: []string{"10.0.3.0/24"}},
+		{name: "next range", fromCidrs: []string{"10.0.0.0/24", "10.1.0.0/24"}, usedCidrs: []string{"10.0.0.0/24"}, mask: 25, allocationCount: 1, strategy: strategyFirstFit, want: []string{"10.1.0.0/25"}},
+		{name: "allocation count", fromCidrs: []string{"10.0.0.0/16"}, usedCidrs: []string{"10.0.1.0/24"}, mask: 24, allocationCount: 2, strategy: strategyFirstFit, want: []string{"10.0.0.0/24", "10.0.2.0/24"}},
+		{name: "full", fromCidrs: []string{"10.0.0.0/24"}, usedCidrs: []string{"10.0.0.0/24"}, mask: 26, allocationCount: 1, strategy: strategyFirstFit, wantErr: "No available CIDR"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data := AvailableCidrResourceModel{
+				FromCidrs:       stringListValue(tc.fromCidrs),
+				UsedCidrs:       stringListValue(tc.usedCidrs),
+				Mask:            types.Int64Value(tc.mask),
+				AllocationCount: types.Int64Value(tc.allocationCount),
+				Strategy:        types.StringValue(tc.strategy),
+				MaxSearchBlocks: types.Int64Value(defaultMaxSearchBlocks),
+			}
+			r := &AvailableCidrResource{}
+			diags := r.allocateResults(context.Background(), &data)
+			if tc.wantErr != "" {
+				if !diags.HasError() || !strings.Contains(diags.Errors()[0].Summary(), tc.wantErr) {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("allocateResults() returned errors: %v", diags)
+			}
+
+			var results []string
+			if diags := data.Results.ElementsAs(context.Background(), &results, false); diags.HasError() {
+				t.Fatalf("unable to read results: %v", diags)
+			}
+			if !reflect.DeepEqual(results, tc.want) {
+				t.Errorf("got results %v, want %v", results, tc.want)
+			}
+			if data.Result.ValueString() != tc.want[0] || data.Id.ValueString() != tc.want[0] {
+				t.Errorf("got result %s and id %s, want %s", data.Result, data.Id, tc.want[0])
+			}
+		})
+	}
+}
+
+func TestAllocateResultsLogging(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	data := AvailableCidrResourceModel{
+		FromCidrs:       stringListValue([]string{"10.0.0.0/16", "10.1.0.0/16"}),
+		UsedCidrs:       stringListValue([]string{"10.0.0.0/16"}),
+		Mask:            types.Int64Value(24),
+		AllocationCount: types.Int64Value(1),
+		Strategy:        types.StringValue(strategyFirstFit),
+		MaxSearchBlocks: types.Int64Value(defaultMaxSearchBlocks),
+	}
+	r := &AvailableCidrResource{}
+	if diags := r.allocateResults(ctx, &data); diags.HasError() {
+		t.Fatalf("allocateResults() returned errors: %v", diags)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unable to decode log output: %s", err)
+	}
+	messages := map[string]map[string]interface{}{}
+	for _, entry := range entries {
+		messages[entry["@message"].(string)] = entry
+	}
+
+	searching, ok := messages["searching for available cidrs"]
+	if !ok {
+		t.Fatalf("no search entry in %v", entries)
+	}
+	if searching["from_cidrs"] != float64(2) || searching["used_blocks"] != float64(1) || searching["candidate_blocks"] != float64(1) {
+		t.Errorf("unexpected search entry: %v", searching)
+	}
+
+	allocated, ok := messages["allocated available cidrs"]
+	if !ok {
+		t.Fatalf("no allocation entry in %v", entries)
+	}
+	if allocated["id"] != "10.1.0.0/24" || allocated["from_cidr"] != "10.1.0.0/16" {
+		t.Errorf("unexpected allocation entry: %v", allocated)
+	}
+	if _, ok := allocated["duration_ms"]; !ok {
+		t.Errorf("allocation entry has no duration_ms: %v", allocated)
+	}
+}
+
+func TestPreferredCidr(t *testing.T) {
+	parse := func(cidrs ...string) []*net.IPNet {
+		networks := make([]*net.IPNet, len(cidrs))
+		for i, c := range cidrs {
+			_, network, err := net.ParseCIDR(c)
+			if err != nil {
+				t.Fatalf("unable to parse %s: %s", c, err)
+			}
+			networks[i] = network
+		}
+		return networks
+	}
+
+	tests := []struct {
+		name   string
+		prefer string
+		align  int
+		want   string
+		reason string
+	}{
+		{name: "free", prefer: "10.1.7.0/24", align: 24, want: "10.1.7.0/24"},
+		{name: "host bits are dropped", prefer: "10.1.7.9/24", align: 24, want: "10.1.7.0/24"},
+		{name: "wrong size", prefer: "10.1.6.0/23", align: 24, reason: "is not a /24"},
+		{name: "outside from_cidrs", prefer: "10.2.0.0/24", align: 24, reason: "is not within any of the from_cidrs"},
+		{name: "used", prefer: "10.1.0.0/24", align: 24, reason: "overlaps 10.1.0.0/24"},
+		{name: "not aligned", prefer: "10.1.7.0/24", align: 23, reason: "does not start on a /23 boundary"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mask := net.CIDRMask(24, 32)
+			align := net.CIDRMask(tc.align, 32)
+			got, reason := preferredCidr(tc.prefer, parse("10.1.0.0/16"), &mask, &align, parse("10.1.0.0/24"))
+			if tc.reason != "" {
+				if got != nil || !strings.Contains(reason, tc.reason) {
+					t.Fatalf("expected reason %q, got %v %q", tc.reason, got, reason)
+				}
+				return
+			}
+			if reason != "" || got.String() != tc.want {
+				t.Fatalf("expected %s, got %v %q", tc.want, got, reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &AvailableCidrDataSource{}
+
+func NewAvailableCidrDataSource() datasource.DataSource {
+	return &AvailableCidrDataSource{}
+}
+
+// AvailableCidrDataSource defines the data source implementation.
+type AvailableCidrDataSource struct{}
+
+// AvailableCidrDataSourceModel describes the data source data model.
+type AvailableCidrDataSourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	FromCidrs types.List   `tfsdk:"from_cidrs"`
+	UsedCidrs types.List   `tfsdk:"used_cidrs"`
+	Mask      types.Int64  `tfsdk:"mask"`
+	Result    types.String `tfsdk:"result"`
+	Found     types.Bool   `tfsdk:"found"`
+}
+
+func (d *AvailableCidrDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_available_cidr"
+}
+
+func (d *AvailableCidrDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Given CIDR range(s) to search over (ex. a Network) and a list of already used CIDR ranges (ex. a list of subnets) " +
+			"find an unused, non-conflicting CIDR range of specified size.\n\n" +
+			"Unlike the `utility_available_cidr` resource, the `result` is recomputed on every plan and **WILL CHANGE** whenever the inputs change. " +
+			"Use the resource instead if the `result` is used to create a network/subnet and must remain stable.\n\n" +
+			"Running out of space isn't an error, so that a module can branch on `found` (ex. to provision a new supernet when the current one is full). " +
+			"Inputs that can never be allocated, such as a `mask` larger than the `from_cidrs`, are still an error.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "CIDR Identifier. The value will be identical to the `result` field.",
+			},
+			"from_cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing the CIDR range(s) from which to search for available CIDR ranges.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				Required: true,
+			},
+			"used_cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing the CIDR ranges that are already used within the `from_cidrs` block(s) which should be avoided to prevent overlaps and/or collisions.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				Required: true,
+			},
+			"mask": schema.Int64Attribute{
+				MarkdownDescription: "Desired mask (network/subnet size) to find that is available.",
+				Required:            true,
+			},
+			"result": schema.StringAttribute{
+				MarkdownDescription: "The available CIDR that was found. This value may change whenever the inputs change. This is null when `found` is `false`.",
+				Computed:            true,
+			},
+			"found": schema.BoolAttribute{
+				MarkdownDescription: "Whether an available CIDR was found. When the `from_cidrs` have no space left for a `mask` sized CIDR this is `false` and `result` is null, rather than failing the plan.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AvailableCidrDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AvailableCidrDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fromCidrs, diags := parseCidrs(ctx, data.FromCidrs, "from_cidrs")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	usedCidrs, diags := parseCidrs(ctx, data.UsedCidrs, "used_cidrs")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addrBits := cidrutil.AddressBits(fromCidrs[0])
+	for _, fromCidr := range fromCidrs {
+		if cidrutil.AddressBits(fromCidr) != addrBits {
+			resp.Diagnostics.AddError(
+				"Mixed address families in from_cidrs",
+				fmt.Sprintf("All from_cidrs must be either IPv4 or IPv6, but %s and %s are of different families", fromCidrs[0], fromCidr),
+			)
+			return
+		}
+	}
+
+	mask := net.CIDRMask(int(data.Mask.ValueInt64()), addrBits)
+
+	result, err := allocate(strategyFirstFit, nil, fromCidrs, &mask, &mask, cidrutil.Normalize(usedCidrs))
+	if errors.Is(err, cidrutil.ErrNoSpace) {
+		tflog.Trace(ctx, "no available cidr: "+err.Error())
+
+		data.Id = types.StringNull()
+		data.Result = types.StringNull()
+		data.Found = types.BoolValue(false)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			allocationErrorSummary(err),
+			err.Error(),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(result.String())
+	data.Result = types.StringValue(result.String())
+	data.Found = types.BoolValue(true)
+
+	tflog.Trace(ctx, "found an available cidr: "+result.String())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAvailableCidrDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAvailableCidrDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_available_cidr.test", "result", "10.1.1.0/24"),
+					resource.TestCheckResourceAttr("data.utility_available_cidr.test", "id", "10.1.1.0/24"),
+					resource.TestCheckResourceAttr("data.utility_available_cidr.test", "found", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAvailableCidrDataSourceExhausted(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "utility_available_cidr" "test" {
+  from_cidrs = ["10.1.0.0/24"]
+  used_cidrs = ["10.1.0.0/24"]
+  mask       = 26
+}
+
+output "next" {
+  value = data.utility_available_cidr.test.found ? data.utility_available_cidr.test.result : "10.2.0.0/26"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_available_cidr.test", "found", "false"),
+					resource.TestCheckNoResourceAttr("data.utility_available_cidr.test", "result"),
+					resource.TestCheckNoResourceAttr("data.utility_available_cidr.test", "id"),
+					resource.TestCheckOutput("next", "10.2.0.0/26"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAvailableCidrDataSourceErrors(t *testing.T) {
+	for _, tc := range []struct {
+		from    string
+		used    string
+		mask    int
+		wantErr string
+	}{
+		{from: "10.1.0.0/24", used: "10.1.0.0/26", mask: 16, wantErr: "Invalid mask for from_cidrs"},
+	} {
+		resource.Test(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(`
+data "utility_available_cidr" "test" {
+  from_cidrs = [%q]
+  used_cidrs = [%q]
+  mask       = %d
+}
+`, tc.from, tc.used, tc.mask),
+					ExpectError: regexp.MustCompile(tc.wantErr),
+				},
+			},
+		})
+	}
+}
+
+const testAccAvailableCidrDataSourceConfig = `
+data "utility_available_cidr" "test" {
+  from_cidrs = ["10.1.0.0/16"]
+  used_cidrs = ["10.1.0.0/24"]
+  mask       = 24
+}
+`
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ImportState accepts either the result CIDR on its own, or the result followed by the from_cidrs and used_cidrs it
+// was allocated with, in the form `<result>;<from_cidr>,<from_cidr>;<used_cidr>,<used_cidr>`. Without the extra
+// segments from_cidrs and used_cidrs are left null, since there is no way to recover them.
+func (r *AvailableCidrResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	segments := strings.Split(req.ID, ";")
+	if len(segments) != 1 && len(segments) != 3 {
+		resp.Diagnostics.AddError(
+			"Malformed resource ID (CIDR)",
+			fmt.Sprintf("The ID that was given must be a CIDR range, or in the form <result>;<from_cidrs>;<used_cidrs> with comma separated CIDR ranges, got: %s", req.ID),
+		)
+		return
+	}
+	id := segments[0]
+
+	// The address family is detected from the address so the prefix length can be checked against the right
+	// number of bits, rather than assuming IPv4.
+	address, prefix, found := strings.Cut(id, "/")
+	ip := net.ParseIP(address)
+	if !found || ip == nil {
+		resp.Diagnostics.AddError(
+			"Malformed resource ID (CIDR)",
+			fmt.Sprintf("The ID that was given must be a valid IPv4 or IPv6 CIDR range, got: %s", id),
+		)
+		return
+	}
+
+	bits := 128
+	if ip.To4() != nil && !strings.Contains(address, ":") {
+		bits = 32
+	}
+
+	mask, err := strconv.Atoi(prefix)
+	if err != nil || mask < 0 || mask > bits {
+		resp.Diagnostics.AddError(
+			"Malformed resource ID (CIDR)",
+			fmt.Sprintf("The prefix length of the %s CIDR range %s must be between 0 and %d", addressFamilyName(bits), id, bits),
+		)
+		return
+	}
+
+	_, result, err := net.ParseCIDR(id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing resource ID",
+			fmt.Sprintf("Unable to parse CIDR: %s", err.Error()),
+		)
+		return
+	}
+
+	fromCidrs := types.ListNull(types.StringType)
+	fromCidr := types.StringNull()
+	usedCidrs := types.ListNull(types.StringType)
+	usedCidrsNext := types.ListNull(types.StringType)
+	var fromNetworks, usedNetworks []*net.IPNet
+	if len(segments) == 3 {
+		fromCidrsStrings, err := parseImportCidrs(segments[1])
+		if err != nil || len(fromCidrsStrings) == 0 {
+			resp.Diagnostics.AddError(
+				"Error parsing resource ID",
+				fmt.Sprintf("The from_cidrs segment must be a comma separated list of at least one CIDR range: %s", segments[1]),
+			)
+			return
+		}
+
+		usedCidrsStrings, err := parseImportCidrs(segments[2])
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error parsing resource ID",
+				fmt.Sprintf("The used_cidrs segment must be a comma separated list of CIDR ranges: %s", err.Error()),
+			)
+			return
+		}
+
+		from, ok := cidrutil.Containing(result, fromCidrsStrings)
+		if !ok {
+			resp.Diagnostics.AddError(
+				"Error parsing resource ID",
+				fmt.Sprintf("The result %s is not within any of the from_cidrs (%s)", result.String(), strings.Join(fromCidrsStrings, ", ")),
+			)
+			return
+		}
+		fromCidr = types.StringValue(from)
+
+		var diags diag.Diagnostics
+		fromCidrs, diags = types.ListValueFrom(ctx, types.StringType, fromCidrsStrings)
+		resp.Diagnostics.Append(diags...)
+		usedCidrs, diags = types.ListValueFrom(ctx, types.StringType, usedCidrsStrings)
+		resp.Diagnostics.Append(diags...)
+		usedCidrsNext, diags = types.ListValueFrom(ctx, types.StringType, append(append([]string{}, usedCidrsStrings...), id))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// The strings were already validated by parseImportCidrs.
+		for _, from := range fromCidrsStrings {
+			_, network, _ := net.ParseCIDR(from)
+			fromNetworks = append(fromNetworks, network)
+		}
+		for _, used := range usedCidrsStrings {
+			_, network, _ := net.ParseCIDR(used)
+			usedNetworks = append(usedNetworks, network)
+		}
+		usedNetworks = append(usedNetworks, result)
+	}
+
+	state := AvailableCidrResourceModel{
+		FromCidrs:          fromCidrs,
+		FromRanges:         types.ListNull(types.StringType),
+		FromCidr:           fromCidr,
+		UsedCidrs:          usedCidrs,
+		UsedCidrsJSON:      types.StringNull(),
+		ReservedCidrs:      types.ListNull(types.StringType),
+		Keepers:            types.MapNull(types.StringType),
+		Mask:               types.Int64Value(int64(mask)),
+		Masks:              types.ListNull(types.Int64Type),
+		AllocationCount:    types.Int64Value(1),
+		Coalesce:           types.BoolValue(false),
+		Spread:             types.BoolValue(false),
+		Strategy:           types.StringValue(strategyFirstFit),
+		ExcludeFirst:       types.BoolValue(false),
+		ExcludeLast:        types.BoolValue(false),
+		StartOffset:        types.Int64Null(),
+		MinGap:             types.Int64Null(),
+		AfterCidr:          types.StringNull(),
+		ReplaceOnChange:    types.BoolValue(false),
+		ReplaceOnRemoval:   types.BoolValue(false),
+		AllowReallocation:  types.BoolValue(false),
+		AllowGrow:          types.BoolValue(false),
+		SortResults:        types.BoolValue(true),
+		Ipv6Format:         types.StringValue(ipv6FormatCompressed),
+		MaxSearchBlocks:    types.Int64Value(defaultMaxSearchBlocks),
+		DedupeFromCidrs:    types.BoolValue(false),
+		SkipUsedValidation: types.BoolValue(false),
+		StrictUsedCidrs:    types.BoolValue(false),
+		PreferCidr:         types.StringNull(),
+		AllowCidrs:         types.ListNull(types.StringType),
+		AlignTo:            types.Int64Null(),
+		EnumerateBits:      types.Int64Null(),
+		EnumerateLimit:     types.Int64Value(defaultEnumerateLimit),
+		Id:                 types.StringValue(id),
+		Result:             types.StringValue(id),
+		Results:            types.ListValueMust(types.StringType, []attr.Value{types.StringValue(id)}),
+		UsedCidrsNext:      usedCidrsNext,
+		Subnets:            types.ListNull(types.StringType),
+	}
+	state.setResultAttributes(result)
+
+	state.RemainingBlocks = types.Int64Null()
+	state.RemainingAddresses = types.Int64Null()
+	state.NormalizedFromCidrs = types.ListNull(types.StringType)
+	state.AllocationJson = types.StringNull()
+	if fromNetworks != nil {
+		fromNetworks = cidrutil.Normalize(fromNetworks)
+		state.setRemainingCapacity(fromNetworks, cidrutil.Normalize(usedNetworks), &result.Mask, &result.Mask)
+		state.NormalizedFromCidrs = stringListValue(cidrutil.Strings(fromNetworks))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// parseImportCidrs splits a comma separated segment of an import ID into CIDR ranges, keeping them as written so
+// they match the configuration. An empty segment is an empty list.
+func parseImportCidrs(segment string) ([]string, error) {
+	cidrs := []string{}
+	if segment == "" {
+		return cidrs, nil
+	}
+
+	for _, cidr := range strings.Split(segment, ",") {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, err
+		}
+		cidrs = append(cidrs, cidr)
+	}
+
+	return cidrs, nil
+}
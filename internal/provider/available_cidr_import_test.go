@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseImportCidrs(t *testing.T) {
+	tests := []struct {
+		name    string
+		segment string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty", segment: "", want: []string{}},
+		{name: "single", segment: "10.0.0.0/16", want: []string{"10.0.0.0/16"}},
+		{name: "several", segment: "10.0.0.0/16,fd00::/56", want: []string{"10.0.0.0/16", "fd00::/56"}},
+		{name: "kept as written", segment: "10.0.3.5/16", want: []string{"10.0.3.5/16"}},
+		{name: "malformed", segment: "10.0.0.0/16,not-a-cidr", wantErr: true},
+		{name: "trailing comma", segment: "10.0.0.0/16,", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseImportCidrs(tc.segment)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
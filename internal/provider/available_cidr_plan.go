@@ -0,0 +1,376 @@
+package provider
+
+import (
+	"context"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ModifyPlan applies the provider configuration when the resource is created. For an existing resource it plans
+// growing the result when allow_grow is true and mask was made smaller, or a reallocation when its results collide
+// with updated used_cidrs and allow_update_reallocation is true, and replaces it when the result in state is
+// malformed. from_cidrs of an address family the
+// provider doesn't allow are rejected, and an unset strategy is filled in with the provider's
+// default_allocation_strategy if there is one. The strategy isn't a static default so that changing the provider
+// default later doesn't plan a change to existing resources. Once the inputs are known the allocation is also
+// previewed, so the plan shows the result that will be allocated.
+func (r *AvailableCidrResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Existing resources keep their allocation unless allow_grow or allow_update_reallocation say otherwise.
+	if !req.State.Raw.IsNull() {
+		r.planMalformedResult(ctx, req, resp)
+		if !r.planGrow(ctx, req, resp) {
+			r.planReallocation(ctx, req, resp)
+		}
+		r.planIPv6Format(ctx, req, resp)
+		r.planKeptResults(ctx, req, resp)
+		return
+	}
+
+	// The CIDRs covering the from_ranges are searched too, so they are checked along with the from_cidrs.
+	var inputs AvailableCidrResourceModel
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("from_cidrs"), &inputs.FromCidrs)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("from_ranges"), &inputs.FromRanges)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	fromCidrs, diags := effectiveFromCidrs(inputs.FromCidrs, inputs.FromRanges)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, element := range fromCidrs.Elements() {
+		from, ok := element.(types.String)
+		if !ok || from.IsNull() || from.IsUnknown() {
+			continue
+		}
+		_, fromCidr, err := net.ParseCIDR(from.ValueString())
+		if err != nil {
+			continue
+		}
+		fromCidr, err = checkIPv4Mapped(r.ipv4MappedCidrs, from.ValueString(), fromCidr)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				inputs.fromCidrPath(i),
+				"IPv4-mapped IPv6 CIDR in from_cidrs",
+				err.Error(),
+			)
+			continue
+		}
+		if err := checkAddressFamily(r.addressFamily, fromCidr); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				inputs.fromCidrPath(i),
+				"Address family not allowed",
+				err.Error(),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var strategy types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("strategy"), &strategy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if strategy.IsUnknown() {
+		// An unknown strategy in the configuration is left for apply.
+		var configStrategy types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("strategy"), &configStrategy)...)
+		if resp.Diagnostics.HasError() || configStrategy.IsUnknown() {
+			return
+		}
+
+		defaultStrategy := r.defaultStrategy
+		if defaultStrategy == "" {
+			defaultStrategy = strategyFirstFit
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("strategy"), defaultStrategy)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The allocation only depends on the inputs, so when they are all known the result can be shown in the plan
+	// instead of "(known after apply)". Otherwise the computed attributes stay unknown until apply.
+	var data AvailableCidrResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || !data.inputsKnown() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.allocateResults(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+}
+
+// planMalformedResult plans replacing an existing resource whose result in state isn't a valid CIDR, which Read
+// warns about. The results are left unknown so that the replacement allocates new ones.
+func (r *AvailableCidrResource) planMalformedResult(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var stateResult types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("result"), &stateResult)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, _, err := net.ParseCIDR(stateResult.ValueString()); err == nil {
+		return
+	}
+
+	var plan AvailableCidrResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.setResultsUnknown()
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("result"))
+}
+
+// planGrow plans growing the result of an existing resource in place when allow_grow is true and mask was made
+// smaller than the result, and reports whether it did so that a reallocation isn't planned as well. When the inputs
+// are known the grown result is previewed, otherwise it is left unknown for Update. Replacing the resource takes
+// precedence.
+func (r *AvailableCidrResource) planGrow(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) bool {
+	if len(resp.RequiresReplace) > 0 {
+		return false
+	}
+
+	var plan, state AvailableCidrResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return true
+	}
+
+	if !growRequested(&plan, &state) {
+		return false
+	}
+
+	if !plan.inputsKnown() {
+		plan.setResultsUnknown()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return true
+	}
+
+	resp.Diagnostics.Append(r.growResults(ctx, &plan, &state)...)
+	if resp.Diagnostics.HasError() {
+		return true
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	return true
+}
+
+// planIPv6Format re-renders the computed attributes of an existing resource when ipv6_format changes. Only the
+// formatting changes, so the allocation itself is kept.
+func (r *AvailableCidrResource) planIPv6Format(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if resp.Diagnostics.HasError() || len(resp.RequiresReplace) > 0 {
+		return
+	}
+
+	var plan, state AvailableCidrResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Ipv6Format.IsUnknown() || plan.Ipv6Format.Equal(state.Ipv6Format) {
+		return
+	}
+
+	plan.formatIPv6()
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// planKeptResults resolves the attributes describing a kept allocation that are still unknown to their prior values.
+// UseStateForUnknown leaves an attribute unknown when its prior value is null (ex. subnets without enumerate_bits, or
+// the broadcast_address of an IPv6 result), and Update would otherwise write that unknown value to the state.
+func (r *AvailableCidrResource) planKeptResults(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if resp.Diagnostics.HasError() || len(resp.RequiresReplace) > 0 {
+		return
+	}
+
+	var plan, state AvailableCidrResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A reallocation or grow that is left for apply sets every attribute describing the new result.
+	if plan.Result.IsUnknown() {
+		return
+	}
+
+	plan.keepUnknownResults(&state)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// planReallocation plans a new allocation for an existing resource when allow_update_reallocation is true, used_cidrs
+// changed and any of the results in state overlap the new used_cidrs. When the inputs are known the new results are
+// previewed, otherwise they are left unknown for Update to allocate. Replacing the resource takes precedence.
+func (r *AvailableCidrResource) planReallocation(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if len(resp.RequiresReplace) > 0 {
+		return
+	}
+
+	var plan, state AvailableCidrResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.AllowReallocation.ValueBool() || (plan.UsedCidrs.Equal(state.UsedCidrs) && plan.UsedCidrsJSON.Equal(state.UsedCidrsJSON)) {
+		return
+	}
+
+	if !plan.inputsKnown() {
+		plan.setResultsUnknown()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	usedCidrs, diags := effectiveUsedCidrs(plan.UsedCidrs, plan.UsedCidrsJSON)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collides, diags := resultsCollide(ctx, state.Results, usedCidrs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !collides {
+		return
+	}
+
+	tflog.Debug(ctx, "results collide with updated used cidrs, reallocating", map[string]interface{}{
+		"result": state.Result.ValueString(),
+	})
+	// The netmask in the plan is the one computed for the prior result, so it is cleared to be computed again. The
+	// prior id is kept to seed the random strategy when there are no keepers.
+	plan.setResultsUnknown()
+	plan.Id = state.Id
+	resp.Diagnostics.Append(r.allocateResults(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// inputsKnown reports whether every input used to allocate the result is known, including the elements of the
+// lists and keepers. The netmask is only an input when mask isn't set.
+func (m *AvailableCidrResourceModel) inputsKnown() bool {
+	inputs := []attr.Value{
+		m.FromCidrs,
+		m.FromRanges,
+		m.UsedCidrs,
+		m.UsedCidrsJSON,
+		m.ReservedCidrs,
+		m.AllowCidrs,
+		m.Mask,
+		m.Masks,
+		m.AllocationCount,
+		m.Coalesce,
+		m.Spread,
+		m.SortResults,
+		m.Strategy,
+		m.ExcludeFirst,
+		m.ExcludeLast,
+		m.StartOffset,
+		m.MinGap,
+		m.AfterCidr,
+		m.DedupeFromCidrs,
+		m.AlignTo,
+		m.EnumerateBits,
+		m.EnumerateLimit,
+		m.Ipv6Format,
+		m.MaxSearchBlocks,
+		m.StrictUsedCidrs,
+		m.PreferCidr,
+		m.Keepers,
+	}
+	if m.Mask.IsNull() {
+		inputs = append(inputs, m.Netmask)
+	}
+
+	for _, input := range inputs {
+		if input.IsUnknown() {
+			return false
+		}
+	}
+	for _, list := range []types.List{m.FromCidrs, m.FromRanges, m.UsedCidrs, m.ReservedCidrs, m.AllowCidrs, m.Masks} {
+		for _, element := range list.Elements() {
+			if element.IsUnknown() {
+				return false
+			}
+		}
+	}
+	for _, element := range m.Keepers.Elements() {
+		if element.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// resultsCollide reports whether any of the results overlap any of the usedCidrs. Malformed used_cidrs are skipped,
+// since they are reported when the results are allocated.
+func resultsCollide(ctx context.Context, results types.List, usedCidrs types.List) (bool, diag.Diagnostics) {
+	var resultsStrings, usedCidrsStrings []string
+	diags := results.ElementsAs(ctx, &resultsStrings, false)
+	if !usedCidrs.IsNull() {
+		diags.Append(usedCidrs.ElementsAs(ctx, &usedCidrsStrings, false)...)
+	}
+	if diags.HasError() {
+		return false, diags
+	}
+
+	used := make([]*net.IPNet, 0, len(usedCidrsStrings))
+	for _, u := range usedCidrsStrings {
+		if _, network, err := net.ParseCIDR(u); err == nil {
+			used = append(used, network)
+		}
+	}
+
+	for _, r := range resultsStrings {
+		_, result, err := net.ParseCIDR(r)
+		if err != nil {
+			continue
+		}
+		for _, u := range used {
+			if cidrutil.Overlaps(result, u) {
+				return true, diags
+			}
+		}
+	}
+	return false, diags
+}
+
+// growRequested reports whether allow_grow applies to the change from prior to data, which is when mask is known and
+// smaller than the prefix length of the prior result. With masks the result needn't be mask sized, so it never applies.
+func growRequested(data *AvailableCidrResourceModel, prior *AvailableCidrResourceModel) bool {
+	if !data.AllowGrow.ValueBool() || data.Mask.IsNull() || data.Mask.IsUnknown() || !data.Masks.IsNull() || prior.PrefixLength.IsNull() || prior.PrefixLength.IsUnknown() {
+		return false
+	}
+	return data.Mask.ValueInt64() < prior.PrefixLength.ValueInt64()
+}
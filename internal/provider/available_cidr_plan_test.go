@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestResultsCollide(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []string
+		used    []string
+		want    bool
+	}{
+		{name: "disjoint", results: []string{"10.1.1.0/24"}, used: []string{"10.1.0.0/24", "10.1.2.0/24"}, want: false},
+		{name: "same cidr", results: []string{"10.1.1.0/24"}, used: []string{"10.1.1.0/24"}, want: true},
+		{name: "used within result", results: []string{"10.1.0.0/23"}, used: []string{"10.1.1.128/25"}, want: true},
+		{name: "any of several results", results: []string{"10.1.1.0/24", "10.1.2.0/24"}, used: []string{"10.1.2.0/25"}, want: true},
+		{name: "malformed used cidrs are skipped", results: []string{"10.1.1.0/24"}, used: []string{"not-a-cidr"}, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, diags := resultsCollide(context.Background(), stringListValue(tc.results), stringListValue(tc.used))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %+v", diags)
+			}
+			if got != tc.want {
+				t.Errorf("got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -2,119 +2,157 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"regexp"
-	"strconv"
-	"strings"
 
-	"github.com/massdriver-cloud/cola/pkg/cidr"
-
-	"github.com/massdriver-cloud/terraform-provider-utility/internal/planmodifiers"
-
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &AvailableCidrResource{}
+var _ resource.ResourceWithConfigure = &AvailableCidrResource{}
 var _ resource.ResourceWithImportState = &AvailableCidrResource{}
+var _ resource.ResourceWithValidateConfig = &AvailableCidrResource{}
+var _ resource.ResourceWithConfigValidators = &AvailableCidrResource{}
+var _ resource.ResourceWithUpgradeState = &AvailableCidrResource{}
+var _ resource.ResourceWithModifyPlan = &AvailableCidrResource{}
+
+const (
+	ipv4AddressPattern = `(?:[0-9]|[0-9]{2}|1[0-9][0-9]|2[0-4][0-9]|25[0-5])(?:\.(?:[0-9]|[0-9]{2}|1[0-9][0-9]|2[0-4][0-9]|25[0-5])){3}`
+	ipv4CidrPattern    = ipv4AddressPattern + `(?:\/(?:[0-9]|[1-2][0-9]|3[0-2]))`
+	// IPv6 addresses may end in an embedded IPv4 address (ex. the IPv4-mapped ::ffff:10.1.0.0).
+	ipv6AddressPattern = `(?:(?:(?:[0-9a-fA-F]{1,4}:){6}|::(?:[0-9a-fA-F]{1,4}:){0,5}|[0-9a-fA-F]{1,4}::(?:[0-9a-fA-F]{1,4}:){0,4}|(?:[0-9a-fA-F]{1,4}:){2}:(?:[0-9a-fA-F]{1,4}:){0,3}|(?:[0-9a-fA-F]{1,4}:){3}:(?:[0-9a-fA-F]{1,4}:){0,2}|(?:[0-9a-fA-F]{1,4}:){4}:(?:[0-9a-fA-F]{1,4}:)?|(?:[0-9a-fA-F]{1,4}:){5}:)` + ipv4AddressPattern + `|(?:[0-9a-fA-F]{1,4}:){7}[0-9a-fA-F]{1,4}|(?:[0-9a-fA-F]{1,4}:){1,7}:|(?:[0-9a-fA-F]{1,4}:){1,6}:[0-9a-fA-F]{1,4}|(?:[0-9a-fA-F]{1,4}:){1,5}(?::[0-9a-fA-F]{1,4}){1,2}|(?:[0-9a-fA-F]{1,4}:){1,4}(?::[0-9a-fA-F]{1,4}){1,3}|(?:[0-9a-fA-F]{1,4}:){1,3}(?::[0-9a-fA-F]{1,4}){1,4}|(?:[0-9a-fA-F]{1,4}:){1,2}(?::[0-9a-fA-F]{1,4}){1,5}|[0-9a-fA-F]{1,4}:(?::[0-9a-fA-F]{1,4}){1,6}|:(?:(?::[0-9a-fA-F]{1,4}){1,7}|:))`
+	ipv6CidrPattern    = ipv6AddressPattern + `(?:\/(?:[0-9]|[1-9][0-9]|1[0-1][0-9]|12[0-8]))`
+)
+
+// cidrRegex matches an IPv4 or IPv6 CIDR range.
+var cidrRegex = regexp.MustCompile(`^(?:` + ipv4CidrPattern + `|` + ipv6CidrPattern + `)$`)
+
+// defaultEnumerateLimit is the default enumerate_limit, which covers splitting an IPv6 /54 into /64s.
+const defaultEnumerateLimit = 1024
+
+// defaultMaxSearchBlocks is the default max_search_blocks, well above what ordinary used_cidrs lists produce.
+const defaultMaxSearchBlocks = 1000000
+
+// Allocation strategies supported by the `strategy` attribute.
+const (
+	strategyFirstFit = "first_fit"
+	strategyLastFit  = "last_fit"
+	strategyBestFit  = "best_fit"
+	strategyRandom   = "random"
+	strategyCompact  = "compact"
+)
+
+// IPv6 address formats supported by the `ipv6_format` attribute.
+const (
+	ipv6FormatCompressed = "compressed"
+	ipv6FormatExpanded   = "expanded"
+)
 
 func NewAvailableCidrResource() resource.Resource {
 	return &AvailableCidrResource{}
 }
 
 // AvailableCidrResource defines the resource implementation.
-type AvailableCidrResource struct{}
+type AvailableCidrResource struct {
+	// defaultStrategy is the provider's default_allocation_strategy, used when strategy is unset.
+	defaultStrategy string
+	// addressFamily is the provider's address_family, which from_cidrs must all belong to when it is set.
+	addressFamily string
+	// ipv4MappedCidrs is the provider's ipv4_mapped_cidrs, which decides whether IPv4-mapped IPv6 CIDRs are
+	// converted to IPv4 or rejected.
+	ipv4MappedCidrs string
+	// deterministicSeed is the provider's deterministic_seed, which replaces the keepers as the seed of the random
+	// strategy when it is set.
+	deterministicSeed *int64
+}
 
 // AvailableCidrResourceModel describes the resource data model.
 type AvailableCidrResourceModel struct {
-	Id        types.String `tfsdk:"id"`
-	Keepers   types.Map    `tfsdk:"keepers"`
-	FromCidrs types.List   `tfsdk:"from_cidrs"`
-	UsedCidrs types.List   `tfsdk:"used_cidrs"`
-	Mask      types.Int64  `tfsdk:"mask"`
-	Result    types.String `tfsdk:"result"`
+	Id                  types.String `tfsdk:"id"`
+	Keepers             types.Map    `tfsdk:"keepers"`
+	FromCidrs           types.List   `tfsdk:"from_cidrs"`
+	FromRanges          types.List   `tfsdk:"from_ranges"`
+	UsedCidrs           types.List   `tfsdk:"used_cidrs"`
+	UsedCidrsJSON       types.String `tfsdk:"used_cidrs_json"`
+	ReservedCidrs       types.List   `tfsdk:"reserved_cidrs"`
+	AllowCidrs          types.List   `tfsdk:"allow_cidrs"`
+	Mask                types.Int64  `tfsdk:"mask"`
+	Masks               types.List   `tfsdk:"masks"`
+	AllocationCount     types.Int64  `tfsdk:"allocation_count"`
+	Coalesce            types.Bool   `tfsdk:"coalesce"`
+	Spread              types.Bool   `tfsdk:"spread"`
+	SortResults         types.Bool   `tfsdk:"sort_results"`
+	Strategy            types.String `tfsdk:"strategy"`
+	ExcludeFirst        types.Bool   `tfsdk:"exclude_first_subnet"`
+	ExcludeLast         types.Bool   `tfsdk:"exclude_last_subnet"`
+	StartOffset         types.Int64  `tfsdk:"start_offset"`
+	MinGap              types.Int64  `tfsdk:"min_gap"`
+	AfterCidr           types.String `tfsdk:"after_cidr"`
+	ReplaceOnChange     types.Bool   `tfsdk:"replace_on_input_change"`
+	ReplaceOnRemoval    types.Bool   `tfsdk:"replace_on_keeper_removal"`
+	AllowReallocation   types.Bool   `tfsdk:"allow_update_reallocation"`
+	AllowGrow           types.Bool   `tfsdk:"allow_grow"`
+	DedupeFromCidrs     types.Bool   `tfsdk:"dedupe_from_cidrs"`
+	SkipUsedValidation  types.Bool   `tfsdk:"skip_used_validation"`
+	StrictUsedCidrs     types.Bool   `tfsdk:"strict_used_cidrs"`
+	PreferCidr          types.String `tfsdk:"prefer_cidr"`
+	AlignTo             types.Int64  `tfsdk:"align_to"`
+	EnumerateBits       types.Int64  `tfsdk:"enumerate_bits"`
+	EnumerateLimit      types.Int64  `tfsdk:"enumerate_limit"`
+	Ipv6Format          types.String `tfsdk:"ipv6_format"`
+	MaxSearchBlocks     types.Int64  `tfsdk:"max_search_blocks"`
+	NormalizedFromCidrs types.List   `tfsdk:"normalized_from_cidrs"`
+	AllocationJson      types.String `tfsdk:"allocation_json"`
+	FromCidr            types.String `tfsdk:"from_cidr"`
+	Result              types.String `tfsdk:"result"`
+	ResultIp            types.String `tfsdk:"result_ip"`
+	Results             types.List   `tfsdk:"results"`
+	UsedCidrsNext       types.List   `tfsdk:"used_cidrs_next"`
+	Subnets             types.List   `tfsdk:"subnets"`
+	Netmask             types.String `tfsdk:"netmask"`
+	PrefixLength        types.Int64  `tfsdk:"prefix_length"`
+	NetworkAddress      types.String `tfsdk:"network_address"`
+	BroadcastAddress    types.String `tfsdk:"broadcast_address"`
+	FirstHost           types.String `tfsdk:"first_host"`
+	LastHost            types.String `tfsdk:"last_host"`
+	HostCount           types.Int64  `tfsdk:"host_count"`
+	RemainingBlocks     types.Int64  `tfsdk:"remaining_blocks"`
+	RemainingAddresses  types.Int64  `tfsdk:"remaining_addresses"`
 }
 
 func (r *AvailableCidrResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_available_cidr"
 }
 
-func (r *AvailableCidrResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
-		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Given CIDR range(s) to search over (ex. a Network) and a list of already used CIDR ranges (ex. a list of subnets) " +
-			"find an unused, non-conflicting CIDR range of specified size.",
-
-		Attributes: map[string]tfsdk.Attribute{
-			"id": {
-				Computed:            true,
-				MarkdownDescription: "CIDR Identifier. The value will be identical to the `result` field.",
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					resource.UseStateForUnknown(),
-				},
-				Type: types.StringType,
-			},
-			"from_cidrs": {
-				MarkdownDescription: "A list containing the CIDR range(s) from which to search for available CIDR ranges. Changing this value after creation **HAS NO EFFECT**. This allows the `result` CIDR to remain stable when it is used to find a range to create a network/subnet. If you would like to conditionally update this resource, use the `keepers` field.",
-				Type: types.ListType{
-					ElemType: types.StringType,
-				},
-				Validators: []tfsdk.AttributeValidator{
-					listvalidator.SizeAtLeast(1),
-					listvalidator.ValuesAre(stringvalidator.RegexMatches(regexp.MustCompile(`^(?:[0-9]|[0-9]{2}|1[0-9][0-9]|2[0-4][0-9]|25[0-5])(?:\.(?:[0-9]|[0-9]{2}|1[0-9][0-9]|2[0-4][0-9]|25[0-5])){3}(?:\/(?:[1-9]|[1-2][0-9]|3[0-2]))$`), "Must be valid CIDR notation")),
-				},
-				Required: true,
-			},
-			"used_cidrs": {
-				MarkdownDescription: "A list containing the CIDR ranges that are already used within the `from_cidrs` block(s) which should be avoided to prevent overlaps and/or collisions. Changing this value after creation **HAS NO EFFECT**. This allows the `result` CIDR to remain stable when it is used to find a range to create a network/subnet. If you would like to conditionally update this resource, use the `keepers` field.",
-				Type: types.ListType{
-					ElemType: types.StringType,
-				},
-				Validators: []tfsdk.AttributeValidator{
-					listvalidator.ValuesAre(stringvalidator.RegexMatches(regexp.MustCompile(`^(?:[0-9]|[0-9]{2}|1[0-9][0-9]|2[0-4][0-9]|25[0-5])(?:\.(?:[0-9]|[0-9]{2}|1[0-9][0-9]|2[0-4][0-9]|25[0-5])){3}(?:\/(?:[1-9]|[1-2][0-9]|3[0-2]))$`), "Must be valid CIDR notation")),
-				},
-				Required: true,
-			},
-			"mask": {
-				MarkdownDescription: "Desired mask (network/subnet size) to find that is available. Changing this value after creation **HAS NO EFFECT**. This allows the `result` CIDR to remain stable when it is used to find a range to create a network/subnet. If you would like to conditionally update this resource, use the `keepers` field.",
-				Type:                types.Int64Type,
-				Required:            true,
-			},
-			"keepers": {
-				MarkdownDescription: "Arbitrary map of values that, when changed, will trigger re-creation of resource. This field works the same as the `keepers` field in the [`Random` provider](https://registry.terraform.io/providers/hashicorp/random/latest/docs#resource-keepers).",
-				Type: types.MapType{
-					ElemType: types.StringType,
-				},
-				Optional: true,
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					planmodifiers.RequiresReplaceIfValuesNotNull(),
-				},
-			},
-			"result": {
-				MarkdownDescription: "The available CIDR that was found.",
-				Computed:            true,
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					resource.UseStateForUnknown(),
-				},
-				Type: types.StringType,
-			},
-		},
-	}, nil
-}
-
 func (r *AvailableCidrResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
+
+	providerData, ok := req.ProviderData.(*UtilityProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilityProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.defaultStrategy = providerData.DefaultAllocationStrategy
+	r.addressFamily = providerData.AddressFamily
+	r.ipv4MappedCidrs = providerData.IPv4MappedCidrs
+	r.deterministicSeed = providerData.DeterministicSeed
 }
 
 func (r *AvailableCidrResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -127,118 +165,391 @@ func (r *AvailableCidrResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	mask := net.CIDRMask(int(data.Mask.ValueInt64()), 32)
+	// The id is known here when the result was previewed during plan, which ties these logs to the plan's. Otherwise
+	// it is attached once the result is allocated.
+	if !data.Id.IsUnknown() {
+		ctx = tflog.SetField(ctx, "id", data.Id.ValueString())
+	}
+
+	// A result shown in the plan is already the allocation for these inputs, so it is only allocated here when the
+	// plan couldn't preview it.
+	if data.Result.IsUnknown() {
+		resp.Diagnostics.Append(r.allocateResults(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// fromCidrPath returns the path of the element of from_cidrs at index i of the CIDRs that are searched. The CIDRs
+// covering the from_ranges follow the from_cidrs and don't map back to a single element, so they are reported
+// against from_ranges as a whole.
+func (m *AvailableCidrResourceModel) fromCidrPath(i int) path.Path {
+	if i < len(m.FromCidrs.Elements()) {
+		return path.Root("from_cidrs").AtListIndex(i)
+	}
+	return path.Root("from_ranges")
+}
+
+// Read checks that the stored result is still a valid CIDR. A malformed result, ex. from a hand edited state, is
+// reported as a warning and replaced by ModifyPlan. The result was allocated from the from_cidrs, and neither can
+// change without a new allocation, so it isn't checked against them.
+func (r *AvailableCidrResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AvailableCidrResourceModel
 
-	fromCidrsStrings := make([]string, len(data.FromCidrs.Elements()))
-	usedCidrsStrings := make([]string, len(data.UsedCidrs.Elements()))
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
-	resp.Diagnostics.Append(data.FromCidrs.ElementsAs(ctx, &fromCidrsStrings, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.Append(data.UsedCidrs.ElementsAs(ctx, &usedCidrsStrings, false)...)
+	if _, _, err := net.ParseCIDR(data.Result.ValueString()); err != nil {
+		resp.Diagnostics.AddWarning(
+			"Invalid result in state",
+			fmt.Sprintf("The stored result %q is not a valid CIDR, the resource will be replaced with a new allocation: %s", data.Result.ValueString(), err.Error()),
+		)
+	}
+}
+
+// Update ensures the plan value is copied to the state to complete the update. When growing the result was planned
+// without knowing the inputs it is grown now. When a reallocation was, the results are allocated again if they
+// still collide with the used_cidrs, and kept from the prior state if they don't.
+func (r *AvailableCidrResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state AvailableCidrResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	usedCidrs := make([]*net.IPNet, len(usedCidrsStrings))
-	for i, used := range usedCidrsStrings {
-		_, usedCidr, parseErr := net.ParseCIDR(used)
-		if parseErr != nil {
-			resp.Diagnostics.AddError(
-				"Error parsing used_cidrs",
-				fmt.Sprintf("... details ... %s", parseErr.Error()),
-			)
+	ctx = tflog.SetField(ctx, "id", state.Id.ValueString())
+
+	if data.Result.IsUnknown() && growRequested(&data, &state) {
+		resp.Diagnostics.Append(r.growResults(ctx, &data, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if data.Result.IsUnknown() {
+		usedCidrs, diags := effectiveUsedCidrs(data.UsedCidrs, data.UsedCidrsJSON)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
-		usedCidrs[i] = usedCidr
-	}
 
-	var result *net.IPNet
-	var findErr error
-	for _, from := range fromCidrsStrings {
-		_, fromCidr, parseErr := net.ParseCIDR(from)
-		if parseErr != nil {
-			resp.Diagnostics.AddError(
-				"Error parsing from_cidrs",
-				fmt.Sprintf("... details ... %s", parseErr.Error()),
-			)
+		collides, diags := resultsCollide(ctx, state.Results, usedCidrs)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 
-		result, findErr = cidr.FindAvailableCIDR(fromCidr, &mask, usedCidrs)
-		if result != nil {
-			break
+		if collides {
+			// The prior id seeds the random strategy when there are no keepers, the same as during plan.
+			data.Id = state.Id
+			resp.Diagnostics.Append(r.allocateResults(ctx, &data)...)
+		} else {
+			// The kept results are in the prior ipv6_format, which may have changed too.
+			data.keepResults(&state)
+			data.formatIPv6()
+		}
+		if resp.Diagnostics.HasError() {
+			return
 		}
 	}
 
-	if findErr != nil {
-		resp.Diagnostics.AddError(
-			"No available CIDR found",
-			fmt.Sprintf("... details ... %s", findErr.Error()),
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete does not need to explicitly call resp.State.RemoveResource() as this is automatically handled by the
+// [framework](https://github.com/hashicorp/terraform-plugin-framework/pull/301).
+func (r *AvailableCidrResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// effectiveFromCidrs returns the fromCidrs followed by the CIDRs covering each of the fromRanges, which are the
+// ranges that are actually searched. Unknown ranges are kept as unknown elements so that callers skip them in the same
+// way as unknown from_cidrs, and a malformed range is reported as an error against its element of from_ranges.
+func effectiveFromCidrs(fromCidrs types.List, fromRanges types.List) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if fromRanges.IsNull() {
+		return fromCidrs, diags
+	}
+	if fromCidrs.IsUnknown() || fromRanges.IsUnknown() {
+		return types.ListUnknown(types.StringType), diags
+	}
+
+	elements := append([]attr.Value{}, fromCidrs.Elements()...)
+	for i, element := range fromRanges.Elements() {
+		fromRange, ok := element.(types.String)
+		if !ok || fromRange.IsNull() || fromRange.IsUnknown() {
+			elements = append(elements, types.StringUnknown())
+			continue
+		}
+
+		networks, err := cidrutil.ParseRange(fromRange.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("from_ranges").AtListIndex(i),
+				"Invalid from_ranges",
+				err.Error(),
+			)
+			continue
+		}
+		for _, network := range networks {
+			elements = append(elements, types.StringValue(network.String()))
+		}
+	}
+	if diags.HasError() {
+		return types.ListUnknown(types.StringType), diags
+	}
+
+	return types.ListValueMust(types.StringType, elements), diags
+}
+
+// effectiveUsedCidrs returns the usedCidrs followed by the entries of the usedCidrsJSON array, so that the rest of the
+// resource can treat them as one list. The list is unknown while either input is unknown, and a usedCidrsJSON that
+// isn't an array of strings is reported against used_cidrs_json.
+func effectiveUsedCidrs(usedCidrs types.List, usedCidrsJSON types.String) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if usedCidrsJSON.IsNull() {
+		return usedCidrs, diags
+	}
+	if usedCidrs.IsUnknown() || usedCidrsJSON.IsUnknown() {
+		return types.ListUnknown(types.StringType), diags
+	}
+
+	entries, err := parseUsedCidrsJSON(usedCidrsJSON.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("used_cidrs_json"),
+			"Invalid used_cidrs_json",
+			err.Error(),
 		)
-		return
+		return types.ListUnknown(types.StringType), diags
 	}
 
-	data.Id = types.StringValue(result.String())
-	data.Result = types.StringValue(result.String())
+	elements := append([]attr.Value{}, usedCidrs.Elements()...)
+	for _, entry := range entries {
+		elements = append(elements, types.StringValue(entry))
+	}
+	return types.ListValueMust(types.StringType, elements), diags
+}
 
-	tflog.Trace(ctx, "found an available cidr: "+result.String())
+// parseUsedCidrsJSON decodes s as a JSON array of strings. The entries aren't checked for CIDR notation, which is
+// left to usedCidrsJSONDiagnostics and the allocation, but an entry that isn't a string is named in the error.
+func parseUsedCidrsJSON(s string) ([]string, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal([]byte(s), &raw); err != nil {
+		return nil, fmt.Errorf("used_cidrs_json must be a JSON array of CIDR range strings: %w", err)
+	}
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	entries := make([]string, len(raw))
+	for i, element := range raw {
+		if err := json.Unmarshal(element, &entries[i]); err != nil {
+			return nil, fmt.Errorf("entry %d (%s) of used_cidrs_json must be a string", i, element)
+		}
+	}
+	return entries, nil
 }
 
-// Read does not need to perform any operations as the state in ReadResourceResponse is already populated.
-func (r *AvailableCidrResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+// usedCidrPath returns the path to report a problem with the i-th effective used CIDR against, which is the element
+// of used_cidrs when it came from there and used_cidrs_json otherwise.
+func (m *AvailableCidrResourceModel) usedCidrPath(i int) path.Path {
+	if i < len(m.UsedCidrs.Elements()) {
+		return path.Root("used_cidrs").AtListIndex(i)
+	}
+	return path.Root("used_cidrs_json")
 }
 
-// Update ensures the plan value is copied to the state to complete the update.
-func (r *AvailableCidrResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data AvailableCidrResourceModel
+// setResultAttributes populates the computed attributes that are derived from the allocated CIDR.
+func (m *AvailableCidrResourceModel) setResultAttributes(result *net.IPNet) {
+	prefixLength, _ := result.Mask.Size()
 
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	// A configured netmask is kept as-is so the state matches the configuration.
+	if !m.Mask.IsNull() || m.Netmask.IsNull() || m.Netmask.IsUnknown() {
+		m.Netmask = types.StringValue(net.IP(result.Mask).String())
+	}
+	m.PrefixLength = types.Int64Value(int64(prefixLength))
+
+	hosts := cidrutil.Hosts(result)
+	m.ResultIp = types.StringValue(result.IP.String())
+	m.NetworkAddress = types.StringValue(hosts.Network.String())
+	m.BroadcastAddress = types.StringNull()
+	if hosts.Broadcast != nil {
+		m.BroadcastAddress = types.StringValue(hosts.Broadcast.String())
+	}
+	m.FirstHost = types.StringValue(hosts.First.String())
+	m.LastHost = types.StringValue(hosts.Last.String())
+	m.HostCount = types.Int64Value(cidrutil.SaturatedInt64(cidrutil.AddressCount(result)))
+}
 
-	if resp.Diagnostics.HasError() {
-		return
+// setResultsUnknown marks the attributes describing the allocation as unknown, for when a reallocation is planned but
+// the results can't be known until apply. A configured netmask is an input and is left as-is.
+func (m *AvailableCidrResourceModel) setResultsUnknown() {
+	m.Id = types.StringUnknown()
+	m.Result = types.StringUnknown()
+	m.ResultIp = types.StringUnknown()
+	m.Results = types.ListUnknown(types.StringType)
+	m.Subnets = types.ListUnknown(types.StringType)
+	m.UsedCidrsNext = types.ListUnknown(types.StringType)
+	m.NormalizedFromCidrs = types.ListUnknown(types.StringType)
+	m.AllocationJson = types.StringUnknown()
+	m.FromCidr = types.StringUnknown()
+	if !m.Mask.IsNull() {
+		m.Netmask = types.StringUnknown()
 	}
+	m.PrefixLength = types.Int64Unknown()
+	m.NetworkAddress = types.StringUnknown()
+	m.BroadcastAddress = types.StringUnknown()
+	m.FirstHost = types.StringUnknown()
+	m.LastHost = types.StringUnknown()
+	m.HostCount = types.Int64Unknown()
+	m.RemainingBlocks = types.Int64Unknown()
+	m.RemainingAddresses = types.Int64Unknown()
+}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+// keepResults copies the attributes describing the allocation from prior, the reverse of setResultsUnknown.
+func (m *AvailableCidrResourceModel) keepResults(prior *AvailableCidrResourceModel) {
+	m.Id = prior.Id
+	m.Result = prior.Result
+	m.ResultIp = prior.ResultIp
+	m.Results = prior.Results
+	m.Subnets = prior.Subnets
+	m.UsedCidrsNext = prior.UsedCidrsNext
+	m.NormalizedFromCidrs = prior.NormalizedFromCidrs
+	m.AllocationJson = prior.AllocationJson
+	m.FromCidr = prior.FromCidr
+	if !m.Mask.IsNull() {
+		m.Netmask = prior.Netmask
+	}
+	m.PrefixLength = prior.PrefixLength
+	m.NetworkAddress = prior.NetworkAddress
+	m.BroadcastAddress = prior.BroadcastAddress
+	m.FirstHost = prior.FirstHost
+	m.LastHost = prior.LastHost
+	m.HostCount = prior.HostCount
+	m.RemainingBlocks = prior.RemainingBlocks
+	m.RemainingAddresses = prior.RemainingAddresses
 }
 
-// Delete does not need to explicitly call resp.State.RemoveResource() as this is automatically handled by the
-// [framework](https://github.com/hashicorp/terraform-plugin-framework/pull/301).
-func (r *AvailableCidrResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+// keepUnknownResults copies the attributes describing the allocation that are unknown from prior, which may be null.
+func (m *AvailableCidrResourceModel) keepUnknownResults(prior *AvailableCidrResourceModel) {
+	if m.Subnets.IsUnknown() {
+		m.Subnets = prior.Subnets
+	}
+	if m.NormalizedFromCidrs.IsUnknown() {
+		m.NormalizedFromCidrs = prior.NormalizedFromCidrs
+	}
+	if m.AllocationJson.IsUnknown() {
+		m.AllocationJson = prior.AllocationJson
+	}
+	if m.FromCidr.IsUnknown() {
+		m.FromCidr = prior.FromCidr
+	}
+	if m.BroadcastAddress.IsUnknown() {
+		m.BroadcastAddress = prior.BroadcastAddress
+	}
+	if m.RemainingBlocks.IsUnknown() {
+		m.RemainingBlocks = prior.RemainingBlocks
+	}
+	if m.RemainingAddresses.IsUnknown() {
+		m.RemainingAddresses = prior.RemainingAddresses
+	}
 }
 
-func (r *AvailableCidrResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	validation := regexp.MustCompile(`^(?:[0-9]|[0-9]{2}|1[0-9][0-9]|2[0-4][0-9]|25[0-5])(?:\.(?:[0-9]|[0-9]{2}|1[0-9][0-9]|2[0-4][0-9]|25[0-5])){3}(?:\/(?:[1-9]|[1-2][0-9]|3[0-2]))$`)
-	if !validation.Match([]byte(req.ID)) {
-		resp.Diagnostics.AddError(
-			"Malformed resource ID (CIDR)",
-			"The ID that was given must be a valid CIDR range",
-		)
-		return
+// formatIPv6 rewrites the IPv6 addresses in the computed attributes in the ipv6_format, so the state doesn't depend
+// on how the inputs were written. Formatting is idempotent, and IPv4 addresses, unknown values and a configured
+// netmask are left as-is.
+func (m *AvailableCidrResourceModel) formatIPv6() {
+	format := m.Ipv6Format.ValueString()
+	cidr := func(s string) string { return formatIPv6Cidr(s, format) }
+	address := func(s string) string { return formatIPv6Address(s, format) }
+
+	m.Id = formatStringValue(m.Id, cidr)
+	m.Result = formatStringValue(m.Result, cidr)
+	m.Results = formatListValue(m.Results, cidr)
+	m.Subnets = formatListValue(m.Subnets, cidr)
+	m.UsedCidrsNext = formatListValue(m.UsedCidrsNext, cidr)
+	m.NormalizedFromCidrs = formatListValue(m.NormalizedFromCidrs, cidr)
+	m.FromCidr = formatStringValue(m.FromCidr, cidr)
+	m.ResultIp = formatStringValue(m.ResultIp, address)
+	m.NetworkAddress = formatStringValue(m.NetworkAddress, address)
+	m.FirstHost = formatStringValue(m.FirstHost, address)
+	m.LastHost = formatStringValue(m.LastHost, address)
+	if !m.Mask.IsNull() {
+		m.Netmask = formatStringValue(m.Netmask, address)
+	}
+	m.AllocationJson = formatStringValue(m.AllocationJson, func(s string) string {
+		var decision allocationDecision
+		if err := json.Unmarshal([]byte(s), &decision); err != nil {
+			return s
+		}
+		decision.Result = cidr(decision.Result)
+		for i, searched := range decision.SearchedCidrs {
+			decision.SearchedCidrs[i] = cidr(searched)
+		}
+		if decision.Gap != nil {
+			decision.Gap.First = address(decision.Gap.First)
+			decision.Gap.Last = address(decision.Gap.Last)
+		}
+		formatted, err := json.Marshal(decision)
+		if err != nil {
+			return s
+		}
+		return string(formatted)
+	})
+}
+
+// formatIPv6Cidr writes s in the given ipv6_format when it is an IPv6 CIDR, keeping any host bits. Anything else is
+// returned unchanged.
+func formatIPv6Cidr(s string, format string) string {
+	ip, network, err := net.ParseCIDR(s)
+	if err != nil || ip.To4() != nil {
+		return s
 	}
+	ones, _ := network.Mask.Size()
+	return fmt.Sprintf("%s/%d", formatIPv6Address(ip.String(), format), ones)
+}
 
-	mask, err := strconv.Atoi(strings.Split(req.ID, "/")[1])
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error parsing resource ID",
-			fmt.Sprintf("Unable to extract mask from CIDR: %s", err.Error()),
-		)
-		return
+// formatIPv6Address writes s in the given ipv6_format when it is an IPv6 address. Anything else is returned
+// unchanged.
+func formatIPv6Address(s string, format string) string {
+	ip := net.ParseIP(s)
+	if ip == nil || ip.To4() != nil {
+		return s
+	}
+	if format == ipv6FormatExpanded {
+		return cidrutil.ExpandedString(ip)
+	}
+	return ip.String()
+}
+
+// formatStringValue applies format to a known string value.
+func formatStringValue(value types.String, format func(string) string) types.String {
+	if value.IsNull() || value.IsUnknown() {
+		return value
 	}
+	return types.StringValue(format(value.ValueString()))
+}
 
-	state := AvailableCidrResourceModel{
-		FromCidrs: types.ListNull(types.StringType),
-		UsedCidrs: types.ListNull(types.StringType),
-		Keepers:   types.MapNull(types.StringType),
-		Mask:      types.Int64Value(int64(mask)),
-		Id:        types.StringValue(req.ID),
-		Result:    types.StringValue(req.ID),
+// formatListValue applies format to each element of a known list of strings.
+func formatListValue(list types.List, format func(string) string) types.List {
+	if list.IsNull() || list.IsUnknown() {
+		return list
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	elements := make([]attr.Value, len(list.Elements()))
+	for i, element := range list.Elements() {
+		value, ok := element.(types.String)
+		if !ok {
+			return list
+		}
+		elements[i] = formatStringValue(value, format)
+	}
+	return types.ListValueMust(types.StringType, elements)
 }
@@ -2,9 +2,16 @@ package provider
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
 func TestAccExampleResource(t *testing.T) {
@@ -18,6 +25,17 @@ func TestAccExampleResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.1.0/24"),
 					resource.TestCheckResourceAttr("utility_available_cidr.test", "id", "10.1.1.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "netmask", "255.255.255.0"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "prefix_length", "24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result_ip", "10.1.1.0"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "network_address", "10.1.1.0"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "broadcast_address", "10.1.1.255"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "first_host", "10.1.1.1"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "last_host", "10.1.1.254"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "host_count", "256"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "remaining_blocks", "254"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "remaining_addresses", "65024"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "allocation_json", `{"result":"10.1.1.0/24","strategy":"first_fit","preferred":false,"searched_cidrs":["10.1.0.0/16"],"gap":{"first":"10.1.1.0","last":"10.1.255.255"}}`),
 				),
 			},
 			// ImportState testing
@@ -29,7 +47,7 @@ func TestAccExampleResource(t *testing.T) {
 				// example code does not have an actual upstream service.
 				// Once the Read method is able to refresh information from
 				// the upstream service, this can be removed.
-				ImportStateVerifyIgnore: []string{"from_cidrs", "used_cidrs"},
+				ImportStateVerifyIgnore: []string{"from_cidrs", "from_cidr", "used_cidrs", "used_cidrs_next", "normalized_from_cidrs", "remaining_blocks", "remaining_addresses", "allocation_json"},
 			},
 			// Update and Read testing
 			{
@@ -43,12 +61,2144 @@ func TestAccExampleResource(t *testing.T) {
 	})
 }
 
+func TestAccExampleResourceIPv6(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceConfig([]string{"fd00::/56"}, []string{"fd00::/64"}, 64),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "fd00:0:0:1::/64"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "id", "fd00:0:0:1::/64"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result_ip", "fd00:0:0:1::"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "netmask", "ffff:ffff:ffff:ffff::"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "prefix_length", "64"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "host_count", "9223372036854775807"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "remaining_blocks", "254"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "remaining_addresses", "9223372036854775807"),
+				),
+			},
+			{
+				ResourceName:            "utility_available_cidr.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"from_cidrs", "from_cidr", "used_cidrs", "used_cidrs_next", "normalized_from_cidrs", "remaining_blocks", "remaining_addresses", "allocation_json"},
+			},
+			{
+				ResourceName:      "utility_available_cidr.test",
+				ImportState:       true,
+				ImportStateId:     "fd00:0:0:1::/64;fd00::/56;fd00::/64",
+				ImportStateVerify: true,
+				// The allocation decision isn't recorded in the ID.
+				ImportStateVerifyIgnore: []string{"allocation_json"},
+			},
+			{
+				ResourceName:  "utility_available_cidr.test",
+				ImportState:   true,
+				ImportStateId: "fd00:0:0:1::/129",
+				ExpectError:   regexp.MustCompile(`IPv6\s+CIDR\s+range\s+fd00:0:0:1::/129\s+must\s+be\s+between\s+0\s+and\s+128`),
+			},
+			{
+				ResourceName:  "utility_available_cidr.test",
+				ImportState:   true,
+				ImportStateId: "10.1.1.0/64",
+				ExpectError:   regexp.MustCompile("prefix length of the IPv4 CIDR range 10.1.1.0/64 must be between 0 and 32"),
+			},
+			{
+				ResourceName:  "utility_available_cidr.test",
+				ImportState:   true,
+				ImportStateId: "fd00:0:0:1::",
+				ExpectError:   regexp.MustCompile("must be a valid IPv4 or IPv6 CIDR range"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceNoUsedCidrs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "utility_available_cidr" "test" {
+  from_cidrs = ["10.1.0.0/16"]
+  mask       = 24
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.0.0/24"),
+					resource.TestCheckNoResourceAttr("utility_available_cidr.test", "used_cidrs"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceNormalizesFromCidrs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceConfig([]string{"10.5.3.7/16", "10.5.0.0/16"}, []string{}, 24),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.5.0.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "from_cidrs.0", "10.5.3.7/16"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "normalized_from_cidrs.#", "1"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "normalized_from_cidrs.0", "10.5.0.0/16"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourcePlannedResult(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Known inputs show the result in the plan
+			{
+				Config: testAccExampleResourceConfig([]string{"10.1.0.0/16"}, []string{"10.1.0.0/24"}, 24),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("utility_available_cidr.test", tfjsonpath.New("result"), knownvalue.StringExact("10.1.1.0/24")),
+						plancheck.ExpectKnownValue("utility_available_cidr.test", tfjsonpath.New("netmask"), knownvalue.StringExact("255.255.255.0")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.1.0/24"),
+				),
+			},
+			// Inputs that depend on another resource leave the result until apply
+			{
+				Config: `
+resource "utility_available_integer" "seed" {
+  min  = 1
+  max  = 10
+  used = []
+}
+
+resource "utility_available_cidr" "unknown" {
+  from_cidrs = ["10.1.0.0/16"]
+  used_cidrs = ["10.1.${utility_available_integer.seed.result}.0/24"]
+  mask       = 24
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("utility_available_cidr.unknown", tfjsonpath.New("result")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.unknown", "result", "10.1.0.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceMixedFamilies(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceConfig([]string{"10.0.0.0/16", "fd00::/56"}, []string{}, 24),
+				ExpectError: regexp.MustCompile("Mixed address families in from_cidrs"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceFromRanges(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceFromRangesConfig([]string{"10.0.0.0-10.0.3.255", "10.1.0.1-10.1.0.6"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.0.1.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "from_cidr", "10.0.0.0/22"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "normalized_from_cidrs.#", "5"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "normalized_from_cidrs.0", "10.0.0.0/22"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "normalized_from_cidrs.1", "10.1.0.1/32"),
+				),
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceFromRangesConfig([]string{"10.0.1.0-10.0.0.0"}),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("start of \"10.0.1.0-10.0.0.0\" is after its end"),
+			},
+			{
+				Config: `
+resource "utility_available_cidr" "test" {
+  mask = 24
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("(?s)At least one of these attributes must be configured.*from_cidrs"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceMixedFamiliesUsedCidrs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceConfig([]string{"10.0.0.0/16"}, []string{"10.0.0.0/24", "fd00::/64"}, 24),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?s)Mixed address families in from_cidrs and used_cidrs.*from_cidrs entry\s+10\.0\.0\.0/16.*used_cidrs entry\s+fd00::/64`),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceAllocationCount(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceAllocationCountConfig([]string{"10.1.0.0/16"}, []string{"10.1.0.0/24"}, 24, 3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.1.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.#", "3"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.0", "10.1.1.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.1", "10.1.2.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.2", "10.1.3.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceUsedCidrsNext(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceUsedCidrsNextConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.first", "used_cidrs_next.#", "3"),
+					resource.TestCheckResourceAttr("utility_available_cidr.first", "used_cidrs_next.0", "10.1.0.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.first", "used_cidrs_next.1", "10.1.1.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.first", "used_cidrs_next.2", "10.1.2.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.second", "result", "10.1.3.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.second", "used_cidrs_next.#", "4"),
+					resource.TestCheckResourceAttr("utility_available_cidr.second", "used_cidrs_next.3", "10.1.3.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceStartOffset(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceStartOffsetConfig(4),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.5.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "remaining_blocks", "250"),
+				),
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceStartOffsetConfig(256),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid start_offset"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceAfterCidr(t *testing.T) {
+	// Every block before the anchor is free, but the search starts at the anchor, which is itself used.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceAfterCidrConfig("10.1.10.0/24"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.11.0/24"),
+				),
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceAfterCidrConfig("10.2.0.0/24"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("after_cidr outside of from_cidrs"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceUsedCidrsJSON(t *testing.T) {
+	// The used_cidrs_json entries are avoided along with the used_cidrs.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceUsedCidrsJSONConfig(`jsonencode(["10.1.1.0/24", "10.1.2.0/24"])`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.3.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "used_cidrs_next.#", "4"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "used_cidrs_next.3", "10.1.3.0/24"),
+				),
+			},
+		},
+	})
+
+	for name, usedCidrsJSON := range map[string]string{
+		"not an array":     `"{\"cidrs\": []}"`,
+		"not a string":     `jsonencode(["10.1.1.0/24", 42])`,
+		"not a CIDR range": `jsonencode(["10.1.1.0/24", "10.1.2.0"])`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccExampleResourceUsedCidrsJSONConfig(usedCidrsJSON),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile("Invalid used_cidrs_json"),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccExampleResourceMasks(t *testing.T) {
+	// The /16 is full, so the result comes from the /20 with its own mask rather than the global mask.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceMasksConfig(`["10.0.0.0/16"]`, "[null, 26]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.0.0/26"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "prefix_length", "26"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "netmask", "255.255.255.192"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "remaining_blocks", "63"),
+				),
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceMasksConfig("[]", "[20, 26]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.0.0.0/20"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "prefix_length", "20"),
+				),
+			},
+		},
+	})
+
+	for name, tc := range map[string]struct {
+		masks string
+		err   string
+	}{
+		"too few masks":          {masks: "[24]", err: "masks has 1 entries, but there are 2 from_cidrs"},
+		"mask larger than range": {masks: "[24, 16]", err: "masks entry 1 is /16"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccExampleResourceMasksConfig("[]", tc.masks),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "utility_available_cidr" "test" {
+  from_cidrs = ["10.0.0.0/16"]
+  netmask    = "255.255.255.0"
+  masks      = [26]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceSpread(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceSpreadConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.#", "4"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.0", "10.1.0.0/26"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.1", "10.1.0.64/26"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.2", "10.2.0.0/26"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.3", "10.2.0.64/26"),
+				),
+			},
+		},
+	})
+
+	// Without spread the first range is filled before the second is touched.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceSpreadConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.#", "4"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.3", "10.1.0.192/26"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceMinGap(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceMinGapConfig(0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.0.0.64/26"),
+				),
+			},
+		},
+	})
+
+	// 10.0.0.64/26 would fit, but sits right next to the used block, so the next block with a free address on
+	// either side is returned instead.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceMinGapConfig(1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.0.0.128/26"),
+				),
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceMinGapConfig(-1),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Attribute min_gap value must be at least 0"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceEnumerateBits(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceEnumerateBitsConfig(8),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "fd00::/56"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "subnets.#", "256"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "subnets.0", "fd00::/64"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "subnets.1", "fd00:0:0:1::/64"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "subnets.255", "fd00:0:0:ff::/64"),
+				),
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceEnumerateBitsConfig(16),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Too many subnets to enumerate"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceCoalesce(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceCoalesceConfig([]string{"10.1.0.0/16"}, 3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.4.0/22"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "prefix_length", "22"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.#", "3"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.0", "10.1.4.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.1", "10.1.5.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.2", "10.1.6.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "remaining_blocks", "251"),
+				),
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceCoalesceConfig([]string{"10.1.0.0/23"}, 3),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Coalesced block too large for from_cidrs"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceAllocationCountExhausted(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceAllocationCountConfig([]string{"10.1.0.0/22"}, []string{"10.1.0.0/24"}, 24, 4),
+				ExpectError: regexp.MustCompile("only 3 were available"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceLastFit(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceStrategyConfig([]string{"10.1.0.0/16"}, []string{"10.1.255.0/24"}, 24, "last_fit"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.254.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "strategy", "last_fit"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceProviderDefaultStrategy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceProviderDefaultStrategyConfig("last_fit", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.254.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "strategy", "last_fit"),
+				),
+			},
+			// Changing the provider default doesn't affect existing resources
+			{
+				Config: testAccExampleResourceProviderDefaultStrategyConfig("first_fit", ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The resource strategy takes precedence over the provider default
+			{
+				Config: testAccExampleResourceProviderDefaultStrategyConfig("last_fit", "first_fit"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.0.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "strategy", "first_fit"),
+				),
+			},
+		},
+	})
+}
+
+func TestCidrRegex(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want bool
+	}{
+		{cidr: "10.0.0.0/16", want: true},
+		{cidr: "0.0.0.0/0", want: true},
+		{cidr: "10.0.0.0/32", want: true},
+		{cidr: "10.0.0.0/33", want: false},
+		{cidr: "10.0.0.0", want: false},
+		{cidr: "fd00::/56", want: true},
+		{cidr: "::/0", want: true},
+		{cidr: "fd00::/128", want: true},
+		{cidr: "fd00::/129", want: false},
+		{cidr: "::ffff:10.1.0.0/112", want: true},
+		{cidr: "64:ff9b::10.1.0.0/120", want: true},
+		{cidr: "::ffff:10.1.0/112", want: false},
+		{cidr: "1:2:3:4:5:6:7:10.1.0.0/128", want: false},
+		{cidr: "not-a-cidr", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.cidr, func(t *testing.T) {
+			if got := cidrRegex.MatchString(tc.cidr); got != tc.want {
+				t.Errorf("cidrRegex.MatchString(%q) = %t, want %t", tc.cidr, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAccExampleResourceProviderDeterministicSeed(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The seed replaces the keepers, so resources with different keepers pick the same CIDR
+			{
+				Config: testAccExampleResourceProviderDeterministicSeedConfig(42),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("utility_available_cidr.test", "result", "utility_available_cidr.test2", "result"),
+					resource.TestCheckResourceAttrPair("utility_available_integer.test", "result", "utility_available_integer.test2", "result"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceProviderAddressFamily(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceProviderAddressFamilyConfig("ipv6", "10.1.0.0/16", 24),
+				ExpectError: regexp.MustCompile(`only\s+allows\s+IPv6`),
+			},
+			// The CIDRs covering from_ranges are checked too
+			{
+				Config: `
+provider "utility" {
+  address_family = "ipv6"
+}
+
+resource "utility_available_cidr" "test" {
+  from_ranges = ["10.1.0.0-10.1.255.255"]
+  used_cidrs  = []
+  mask        = 24
+}
+`,
+				ExpectError: regexp.MustCompile(`only\s+allows\s+IPv6`),
+			},
+			{
+				Config: testAccExampleResourceProviderAddressFamilyConfig("ipv6", "fd00::/48", 64),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "fd00::/64"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceIPv4Mapped(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceIPv4MappedConfig("reject", "::ffff:10.1.0.0/112", "10.1.0.0/24"),
+				ExpectError: regexp.MustCompile("IPv4-mapped IPv6 CIDR in from_cidrs"),
+			},
+			{
+				Config:      testAccExampleResourceIPv4MappedConfig("reject", "10.1.0.0/16", "::ffff:10.1.0.0/120"),
+				ExpectError: regexp.MustCompile("IPv4-mapped IPv6 CIDR in used_cidrs"),
+			},
+			{
+				Config: testAccExampleResourceIPv4MappedConfig("normalize", "::ffff:10.1.0.0/112", "::ffff:10.1.0.0/120"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.1.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "normalized_from_cidrs.0", "10.1.0.0/16"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "from_cidr", "::ffff:10.1.0.0/112"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceBestFit(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceStrategyConfig([]string{"10.1.0.0/16"}, []string{"10.1.4.0/22", "10.1.9.0/24", "10.1.10.0/23", "10.1.12.0/22"}, 24, "best_fit"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.8.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceLastFitAcrossRanges(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceStrategyConfig([]string{"10.1.0.0/16", "10.2.0.0/16"}, []string{"10.2.255.0/24"}, 24, "last_fit"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.2.254.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "from_cidr", "10.2.0.0/16"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceBestFitAcrossRanges(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceStrategyConfig([]string{"10.1.0.0/16", "10.2.0.0/24"}, []string{"10.2.0.0/25"}, 25, "best_fit"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.2.0.128/25"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceCompact(t *testing.T) {
+	// 10.1.1.0/24 was freed between two used blocks. compact reuses it even though the higher range is listed first,
+	// where first_fit would take 10.2.1.0/24.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceStrategyConfig([]string{"10.2.0.0/16", "10.1.0.0/16"}, []string{"10.1.0.0/24", "10.1.2.0/24", "10.2.0.0/24"}, 24, "compact"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.1.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "from_cidr", "10.1.0.0/16"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceRandom(t *testing.T) {
+	var result string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The same keepers always produce the same result
+			{
+				Config: testAccExampleResourceRandomConfig("one"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("utility_available_cidr.test", "result", "utility_available_cidr.test2", "result"),
+					resource.TestCheckResourceAttrWith("utility_available_cidr.test", "result", func(value string) error {
+						result = value
+						return nil
+					}),
+				),
+			},
+			// Changing a keeper moves the allocation
+			{
+				Config: testAccExampleResourceRandomConfig("two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("utility_available_cidr.test", "result", "utility_available_cidr.test2", "result"),
+					resource.TestCheckResourceAttrWith("utility_available_cidr.test", "result", func(value string) error {
+						if value == result {
+							return fmt.Errorf("expected result to change from %s after changing keepers", result)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceNonStringKeepers(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceNonStringKeepersConfig(1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "keepers.version", "1"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "keepers.enabled", "true"),
+				),
+			},
+			// Bumping a numeric keeper replaces the resource
+			{
+				Config: testAccExampleResourceNonStringKeepersConfig(2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_available_cidr.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "keepers.version", "2"),
+				),
+			},
+			// The same value written as a string is not a change
+			{
+				Config:             strings.Replace(testAccExampleResourceNonStringKeepersConfig(2), "version = 2", `version = "2"`, 1),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceHostRangeEdgeCases(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceConfig([]string{"10.1.0.0/30"}, []string{"10.1.0.0/31"}, 31),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.0.2/31"),
+					resource.TestCheckNoResourceAttr("utility_available_cidr.test", "broadcast_address"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "first_host", "10.1.0.2"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "last_host", "10.1.0.3"),
+				),
+			},
+			{
+				ResourceName:            "utility_available_cidr.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"from_cidrs", "from_cidr", "used_cidrs", "used_cidrs_next", "normalized_from_cidrs", "remaining_blocks", "remaining_addresses", "allocation_json"},
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceConfig([]string{"10.2.0.0/31"}, []string{"10.2.0.0/32"}, 32),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.2.0.1/32"),
+					resource.TestCheckNoResourceAttr("utility_available_cidr.test", "broadcast_address"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "first_host", "10.2.0.1"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "last_host", "10.2.0.1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceReservedCidrs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "utility_available_cidr" "test" {
+  from_cidrs     = ["10.1.0.0/16"]
+  used_cidrs     = ["10.1.0.0/24"]
+  reserved_cidrs = ["10.1.1.0/24", "10.1.2.0/23"]
+  mask           = 24
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.4.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceMaskTooLarge(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceConfig([]string{"10.1.0.0/24", "10.2.0.0/20"}, []string{}, 16),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Mask too large for from_cidrs"),
+			},
+			{
+				Config:      testAccExampleResourceConfig([]string{"10.1.0.0/16"}, []string{}, 40),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("mask must be between 0 and 32 for IPv4 from_cidrs, got 40"),
+			},
+			{
+				Config:      testAccExampleResourceConfig([]string{"fd00::/56"}, []string{}, 129),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Attribute mask value must be between 0 and 128"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceWholeAddressSpace(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceConfig([]string{"0.0.0.0/0"}, []string{"0.0.0.0/8"}, 8),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "1.0.0.0/8"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "remaining_blocks", "254"),
+				),
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceConfig([]string{"::/0"}, []string{"::/1"}, 128),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "8000::/128"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "remaining_blocks", "9223372036854775807"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceSingleAddressFromCidr(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceConfig([]string{"10.0.0.1/32"}, []string{}, 24),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`10\.0\.0\.1/32\s+\(/32\)`),
+			},
+			{
+				Config: testAccExampleResourceConfig([]string{"10.0.0.1/32"}, []string{}, 32),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.0.0.1/32"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "remaining_blocks", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceNetmask(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "utility_available_cidr" "test" {
+  from_cidrs = ["10.1.0.0/16"]
+  used_cidrs = ["10.1.0.0/24"]
+  netmask    = "255.255.255.0"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.1.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "prefix_length", "24"),
+					resource.TestCheckNoResourceAttr("utility_available_cidr.test", "mask"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceMaskAndNetmask(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "utility_available_cidr" "test" {
+  from_cidrs = ["10.1.0.0/16"]
+  used_cidrs = ["10.1.0.0/24"]
+  mask       = 24
+  netmask    = "255.255.255.0"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceExcludeBoundarySubnets(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "utility_available_cidr" "test" {
+  from_cidrs           = ["10.1.0.0/24"]
+  used_cidrs           = []
+  mask                 = 26
+  allocation_count     = 2
+  exclude_first_subnet = true
+  exclude_last_subnet  = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.#", "2"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.0", "10.1.0.64/26"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.1", "10.1.0.128/26"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceFirstRangeFull(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceConfig([]string{"10.1.0.0/24", "10.2.0.0/24"}, []string{"10.1.0.0/24"}, 24),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.2.0.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceLastRangeFull(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceConfig([]string{"10.1.0.0/24", "10.2.0.0/24"}, []string{"10.2.0.0/24"}, 24),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.0.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceAllRangesFull(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceConfig([]string{"10.1.0.0/24", "10.2.0.0/24"}, []string{"10.1.0.0/24", "10.2.0.0/24"}, 24),
+				ExpectError: regexp.MustCompile("No available CIDR found"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceReplaceOnInputChange(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceReplaceOnInputChangeConfig([]string{"10.1.0.0/16"}, []string{"10.1.0.0/24"}, 24, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.1.0/24"),
+				),
+			},
+			// Changing the mask re-allocates
+			{
+				Config: testAccExampleResourceReplaceOnInputChangeConfig([]string{"10.1.0.0/16"}, []string{"10.1.0.0/24"}, 25, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_available_cidr.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.1.0/25"),
+				),
+			},
+			// Changing the used CIDRs re-allocates
+			{
+				Config: testAccExampleResourceReplaceOnInputChangeConfig([]string{"10.1.0.0/16"}, []string{"10.1.0.0/23"}, 25, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_available_cidr.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.2.0/25"),
+				),
+			},
+			// Disabling it keeps the result stable again
+			{
+				Config: testAccExampleResourceReplaceOnInputChangeConfig([]string{"10.1.0.0/16"}, []string{"10.1.0.0/22"}, 24, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_available_cidr.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.2.0/25"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceReplaceOnKeeperRemoval(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceKeepersConfig(`{ a = "1", b = "2" }`, []string{"10.1.0.0/24"}, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.1.0/24"),
+				),
+			},
+			// Other inputs still follow replace_on_input_change, so the result is kept
+			{
+				Config: testAccExampleResourceKeepersConfig(`{ a = "1", b = "2" }`, []string{"10.1.0.0/24", "10.1.1.0/24"}, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_available_cidr.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.1.0/24"),
+				),
+			},
+			// Removing a keeper re-allocates when replace_on_keeper_removal is set
+			{
+				Config: testAccExampleResourceKeepersConfig(`{ a = "1" }`, []string{"10.1.0.0/24", "10.1.1.0/24"}, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_available_cidr.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.2.0/24"),
+				),
+			},
+			// Setting a keeper to null does too
+			{
+				Config: testAccExampleResourceKeepersConfig(`{ a = null }`, []string{"10.1.0.0/24", "10.1.1.0/24"}, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_available_cidr.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+			},
+			// Without it, removing a keeper is ignored
+			{
+				Config: testAccExampleResourceKeepersConfig(`{}`, []string{"10.1.0.0/24", "10.1.1.0/24"}, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_available_cidr.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.2.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceUpdateReallocation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceUpdateReallocationConfig([]string{"10.1.0.0/24"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.1.0/24"),
+				),
+			},
+			// A result that doesn't collide with the new used CIDRs is kept
+			{
+				Config: testAccExampleResourceUpdateReallocationConfig([]string{"10.1.0.0/24", "10.1.2.0/24"}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_available_cidr.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("utility_available_cidr.test", tfjsonpath.New("result"), knownvalue.StringExact("10.1.1.0/24")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.1.0/24"),
+				),
+			},
+			// A collision re-allocates in place
+			{
+				Config: testAccExampleResourceUpdateReallocationConfig([]string{"10.1.0.0/24", "10.1.1.0/24"}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_available_cidr.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("utility_available_cidr.test", tfjsonpath.New("result"), knownvalue.StringExact("10.1.2.0/24")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.2.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "id", "10.1.2.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "network_address", "10.1.2.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceGrow(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceGrowConfig(24, []string{"10.1.0.0/23", "10.1.3.0/24"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.2.0/24"),
+				),
+			},
+			// The additional addresses are used
+			{
+				Config:      testAccExampleResourceGrowConfig(23, []string{"10.1.0.0/23", "10.1.3.0/24"}),
+				ExpectError: regexp.MustCompile(`would\s+overlap\s+these\s+used_cidrs\s+or\s+reserved_cidrs:\s+10\.1\.3\.0/24`),
+			},
+			{
+				Config: testAccExampleResourceGrowConfig(23, []string{"10.1.0.0/23"}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_available_cidr.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("utility_available_cidr.test", tfjsonpath.New("result"), knownvalue.StringExact("10.1.2.0/23")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.2.0/23"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "prefix_length", "23"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "netmask", "255.255.254.0"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "used_cidrs_next.#", "2"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "used_cidrs_next.1", "10.1.2.0/23"),
+				),
+			},
+			// 10.1.2.0 isn't on a /22 boundary
+			{
+				Config:      testAccExampleResourceGrowConfig(22, []string{"10.1.0.0/23"}),
+				ExpectError: regexp.MustCompile(`doesn't\s+start\s+on\s+a\s+/22\s+boundary`),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceIPv6Format(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceIPv6FormatConfig("expanded"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "2001:0db8:0000:0001:0000:0000:0000:0000/64"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "id", "2001:0db8:0000:0001:0000:0000:0000:0000/64"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result_ip", "2001:0db8:0000:0001:0000:0000:0000:0000"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "last_host", "2001:0db8:0000:0001:ffff:ffff:ffff:ffff"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "netmask", "ffff:ffff:ffff:ffff:0000:0000:0000:0000"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "used_cidrs_next.0", "2001:0db8:0000:0000:0000:0000:0000:0000/64"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "normalized_from_cidrs.0", "2001:0db8:0000:0000:0000:0000:0000:0000/56"),
+				),
+			},
+			// Changing the format re-renders the same allocation in place
+			{
+				Config: testAccExampleResourceIPv6FormatConfig("compressed"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_available_cidr.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("utility_available_cidr.test", tfjsonpath.New("result"), knownvalue.StringExact("2001:db8:0:1::/64")),
+						// IPv6 results have no broadcast address, which stays null rather than unknown
+						plancheck.ExpectKnownValue("utility_available_cidr.test", tfjsonpath.New("broadcast_address"), knownvalue.Null()),
+						plancheck.ExpectKnownValue("utility_available_cidr.test", tfjsonpath.New("subnets"), knownvalue.Null()),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "2001:db8:0:1::/64"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result_ip", "2001:db8:0:1::"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "used_cidrs_next.0", "2001:db8::/64"),
+					resource.TestCheckNoResourceAttr("utility_available_cidr.test", "broadcast_address"),
+				),
+			},
+			// Repeated applies don't churn
+			{
+				Config:   testAccExampleResourceIPv6FormatConfig("compressed"),
+				PlanOnly: true,
+			},
+			// Changing it back applies in place too
+			{
+				Config: testAccExampleResourceIPv6FormatConfig("expanded"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_available_cidr.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "2001:0db8:0000:0001:0000:0000:0000:0000/64"),
+					resource.TestCheckNoResourceAttr("utility_available_cidr.test", "broadcast_address"),
+				),
+			},
+		},
+	})
+}
+
+func TestFormatIPv6(t *testing.T) {
+	tests := []struct {
+		value  string
+		format string
+		cidr   bool
+		want   string
+	}{
+		{value: "2001:db8::/48", format: ipv6FormatExpanded, cidr: true, want: "2001:0db8:0000:0000:0000:0000:0000:0000/48"},
+		{value: "2001:0DB8:0000:0000:0000:0000:0000:0000/48", format: ipv6FormatCompressed, cidr: true, want: "2001:db8::/48"},
+		{value: "2001:db8::5/64", format: ipv6FormatExpanded, cidr: true, want: "2001:0db8:0000:0000:0000:0000:0000:0005/64"},
+		{value: "10.0.0.0/24", format: ipv6FormatExpanded, cidr: true, want: "10.0.0.0/24"},
+		{value: "not-a-cidr", format: ipv6FormatExpanded, cidr: true, want: "not-a-cidr"},
+		{value: "fd00::1", format: ipv6FormatExpanded, want: "fd00:0000:0000:0000:0000:0000:0000:0001"},
+		{value: "FD00:0:0:0:0:0:0:1", format: ipv6FormatCompressed, want: "fd00::1"},
+		{value: "10.0.0.1", format: ipv6FormatExpanded, want: "10.0.0.1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.value+" "+tc.format, func(t *testing.T) {
+			got := formatIPv6Address(tc.value, tc.format)
+			if tc.cidr {
+				got = formatIPv6Cidr(tc.value, tc.format)
+			}
+			if got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAccExampleResourceMaxSearchBlocks(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The used_cidrs split the from_cidr into 3 runs of free addresses
+			{
+				Config:      testAccExampleResourceMaxSearchBlocksConfig(2),
+				ExpectError: regexp.MustCompile(`Search space too large`),
+			},
+			{
+				Config: testAccExampleResourceMaxSearchBlocksConfig(3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.0.0.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "max_search_blocks", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceSortResults(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceSortResultsConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.3.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.0", "10.1.1.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.1", "10.1.2.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.2", "10.1.3.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "used_cidrs_next.1", "10.1.1.0/24"),
+				),
+			},
+			// Without sorting the results are in the order last_fit found them
+			{
+				Config: testAccExampleResourceSortResultsConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.3.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.0", "10.1.3.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.1", "10.1.2.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "results.2", "10.1.1.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseUsedCidrsJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    []string
+		wantErr string
+	}{
+		{name: "array of strings", json: `["10.0.0.0/24", "10.0.1.0/24"]`, want: []string{"10.0.0.0/24", "10.0.1.0/24"}},
+		{name: "empty array", json: `[]`, want: []string{}},
+		{name: "object", json: `{"cidrs": []}`, wantErr: "must be a JSON array"},
+		{name: "malformed", json: `["10.0.0.0/24"`, wantErr: "must be a JSON array"},
+		{name: "number entry", json: `["10.0.0.0/24", 42]`, wantErr: "entry 1 (42)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseUsedCidrsJSON(tt.json)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseUsedCidrsJSON(%s) error = %v, want one containing %q", tt.json, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseUsedCidrsJSON(%s) returned error: %v", tt.json, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseUsedCidrsJSON(%s) = %v, want %v", tt.json, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromCidrPath(t *testing.T) {
+	data := AvailableCidrResourceModel{
+		FromCidrs: stringListValue([]string{"10.0.0.0/16", "10.1.0.0/16"}),
+	}
+
+	tests := []struct {
+		index int
+		want  path.Path
+	}{
+		{index: 0, want: path.Root("from_cidrs").AtListIndex(0)},
+		{index: 1, want: path.Root("from_cidrs").AtListIndex(1)},
+		{index: 2, want: path.Root("from_ranges")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.want.String(), func(t *testing.T) {
+			if got := data.fromCidrPath(tc.index); !got.Equal(tc.want) {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAccExampleResourceDedupeFromCidrs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The overlapping ranges are searched in order, so the /24 is allocated first
+			{
+				Config: testAccExampleResourceDedupeFromCidrsConfig([]string{"10.1.0.0/24", "10.0.0.0/8"}, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.0.0/24"),
+				),
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Once collapsed only the /8 is left
+			{
+				Config: testAccExampleResourceDedupeFromCidrsConfig([]string{"10.1.0.0/24", "10.0.0.0/8"}, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.0.0.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceAlignTo(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The invalid config goes first, since the resources are destroyed with the config of the last step
+			{
+				Config:      testAccExampleResourceAlignToConfig([]string{"10.1.0.0/28"}, 26, 28),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid align_to"),
+			},
+			// 10.1.0.16/28 is free, but only 10.1.0.64/28 starts on a /26 boundary
+			{
+				Config: testAccExampleResourceAlignToConfig([]string{"10.1.0.0/28"}, 28, 26),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.0.64/28"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "align_to", "26"),
+					// Only the /26 boundaries at 10.1.0.128 and 10.1.0.192 are left
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "remaining_blocks", "2"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "remaining_addresses", "224"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceSkipUsedValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceSkipUsedValidationConfig(false),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Must be valid CIDR notation"),
+			},
+			// Without validation the malformed element is only caught when it is parsed during apply
+			{
+				Config:      testAccExampleResourceSkipUsedValidationConfig(true),
+				ExpectError: regexp.MustCompile("Error parsing used_cidrs"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceStrictUsedCidrs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "utility_available_cidr" "test" {
+  from_cidrs        = ["10.1.0.0/16"]
+  used_cidrs        = ["10.1.0.0/24", "10.2.0.0/24"]
+  mask              = 24
+  strict_used_cidrs = true
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("aren't within any of the from_cidrs: 10.2.0.0/24"),
+			},
+			// Without strict_used_cidrs the stray entry is only a warning
+			{
+				Config: testAccExampleResourceConfig([]string{"10.1.0.0/16"}, []string{"10.1.0.0/24", "10.2.0.0/24"}, 24),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.1.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "strict_used_cidrs", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourcePreferCidr(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourcePreferCidrConfig("10.1.7.0/24"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.7.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "allocation_json", `{"result":"10.1.7.0/24","strategy":"first_fit","preferred":true,"searched_cidrs":["10.1.0.0/16"],"gap":{"first":"10.1.1.0","last":"10.1.255.255"}}`),
+				),
+			},
+		},
+	})
+
+	// A prefer_cidr that is already used falls back to the strategy
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourcePreferCidrConfig("10.1.0.0/24"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.1.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceAllowCidrs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceAllowCidrsConfig([]string{"10.1.0.0/24"}, []string{"10.1.4.0/22"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.4.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "remaining_blocks", "3"),
+				),
+			},
+		},
+	})
+
+	// The only free space is outside of the allow window
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceAllowCidrsConfig([]string{"10.1.0.0/24", "10.1.1.0/24"}, []string{"10.1.0.0/23"}),
+				ExpectError: regexp.MustCompile("No available CIDR found"),
+			},
+		},
+	})
+
+	// allow_cidrs must be within from_cidrs
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceAllowCidrsConfig([]string{}, []string{"10.1.4.0/22", "10.2.0.0/24"}),
+				ExpectError: regexp.MustCompile("allow_cidrs outside of from_cidrs"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResourceImportWithInputs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceConfig([]string{"10.1.0.0/16", "10.2.0.0/16"}, []string{"10.1.0.0/24", "10.1.1.0/24"}, 24),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "result", "10.1.2.0/24"),
+					resource.TestCheckResourceAttr("utility_available_cidr.test", "from_cidr", "10.1.0.0/16"),
+				),
+			},
+			// The from_cidrs and used_cidrs in the ID reconstruct the full state
+			{
+				ResourceName:      "utility_available_cidr.test",
+				ImportState:       true,
+				ImportStateId:     "10.1.2.0/24;10.1.0.0/16,10.2.0.0/16;10.1.0.0/24,10.1.1.0/24",
+				ImportStateVerify: true,
+				// The allocation decision isn't recorded in the ID.
+				ImportStateVerifyIgnore: []string{"allocation_json"},
+			},
+			{
+				ResourceName:  "utility_available_cidr.test",
+				ImportState:   true,
+				ImportStateId: "10.1.2.0/24;10.1.0.0/16",
+				ExpectError:   regexp.MustCompile("Malformed resource ID"),
+			},
+			{
+				ResourceName:  "utility_available_cidr.test",
+				ImportState:   true,
+				ImportStateId: "10.1.2.0/24;10.1.0.0/16;10.1.0.0",
+				ExpectError:   regexp.MustCompile("The used_cidrs segment must be a comma separated list"),
+			},
+			{
+				ResourceName:  "utility_available_cidr.test",
+				ImportState:   true,
+				ImportStateId: "10.3.0.0/24;10.1.0.0/16,10.2.0.0/16;",
+				ExpectError:   regexp.MustCompile("not within any of the from_cidrs"),
+			},
+		},
+	})
+}
+
 func testAccExampleResourceConfig(from []string, used []string, mask int) string {
 	return fmt.Sprintf(`
 resource "utility_available_cidr" "test" {
-  from_cidrs = %q
-  used_cidrs = %q
+  from_cidrs = %s
+  used_cidrs = %s
   mask = %v
 }
-`, from, used, mask)
+`, testAccStringList(from), testAccStringList(used), mask)
+}
+
+// testAccStringList renders values as an HCL list of strings. Formatting a []string with %q leaves out the commas
+// between elements, which Terraform rejects.
+func testAccStringList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func testAccExampleResourceAllocationCountConfig(from []string, used []string, mask int, count int) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs       = %q
+  used_cidrs       = %q
+  mask             = %v
+  allocation_count = %v
+}
+`, from, used, mask, count)
+}
+
+func testAccExampleResourceFromRangesConfig(from []string) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_ranges = %s
+  used_cidrs  = ["10.0.0.0/24"]
+  mask        = 24
+}
+`, testAccStringList(from))
+}
+
+func testAccExampleResourceUsedCidrsNextConfig() string {
+	return `
+resource "utility_available_cidr" "first" {
+  from_cidrs       = ["10.1.0.0/16"]
+  used_cidrs       = ["10.1.0.0/24"]
+  mask             = 24
+  allocation_count = 2
+}
+
+resource "utility_available_cidr" "second" {
+  from_cidrs = ["10.1.0.0/16"]
+  used_cidrs = utility_available_cidr.first.used_cidrs_next
+  mask       = 24
+}
+`
+}
+
+func testAccExampleResourceStartOffsetConfig(offset int) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs   = ["10.1.0.0/16"]
+  used_cidrs   = ["10.1.4.0/24"]
+  mask         = 24
+  start_offset = %v
+}
+`, offset)
+}
+
+func testAccExampleResourceAfterCidrConfig(afterCidr string) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs = ["10.1.0.0/16"]
+  used_cidrs = ["10.1.10.0/24"]
+  mask       = 24
+  after_cidr = %q
+}
+`, afterCidr)
+}
+
+func testAccExampleResourceUsedCidrsJSONConfig(usedCidrsJSON string) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs      = ["10.1.0.0/16"]
+  used_cidrs      = ["10.1.0.0/24"]
+  used_cidrs_json = %s
+  mask            = 24
+}
+`, usedCidrsJSON)
+}
+
+func testAccExampleResourceMasksConfig(usedCidrs string, masks string) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs = ["10.0.0.0/16", "10.1.0.0/20"]
+  used_cidrs = %s
+  mask       = 24
+  masks      = %s
+}
+`, usedCidrs, masks)
+}
+
+func testAccExampleResourceSpreadConfig(spread bool) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs       = ["10.1.0.0/24", "10.2.0.0/24"]
+  mask             = 26
+  allocation_count = 4
+  spread           = %t
+}
+`, spread)
+}
+
+func testAccExampleResourceMinGapConfig(minGap int) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs = ["10.0.0.0/24"]
+  used_cidrs = ["10.0.0.0/26"]
+  mask       = 26
+  min_gap    = %d
+}
+`, minGap)
+}
+
+func testAccExampleResourceEnumerateBitsConfig(enumerateBits int) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs     = ["fd00::/48"]
+  mask           = 56
+  enumerate_bits = %v
+}
+`, enumerateBits)
+}
+
+func testAccExampleResourceCoalesceConfig(from []string, count int) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs       = %q
+  used_cidrs       = ["10.1.0.0/24"]
+  mask             = 24
+  allocation_count = %v
+  coalesce         = true
+}
+`, from, count)
+}
+
+func testAccExampleResourceSortResultsConfig(sortResults bool) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs              = ["10.1.0.0/22"]
+  used_cidrs              = ["10.1.0.0/24"]
+  mask                    = 24
+  allocation_count        = 3
+  strategy                = "last_fit"
+  sort_results            = %v
+  replace_on_input_change = true
+}
+`, sortResults)
+}
+
+func testAccExampleResourceStrategyConfig(from []string, used []string, mask int, strategy string) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs = %s
+  used_cidrs = %s
+  mask       = %v
+  strategy   = %q
+}
+`, testAccStringList(from), testAccStringList(used), mask, strategy)
+}
+
+func testAccExampleResourceRandomConfig(keeper string) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs = ["10.0.0.0/8"]
+  used_cidrs = []
+  mask       = 24
+  strategy   = "random"
+  keepers = {
+    key = %[1]q
+  }
+}
+
+resource "utility_available_cidr" "test2" {
+  from_cidrs = ["10.0.0.0/8"]
+  used_cidrs = []
+  mask       = 24
+  strategy   = "random"
+  keepers = {
+    key = %[1]q
+  }
+}
+`, keeper)
+}
+
+func testAccExampleResourceNonStringKeepersConfig(version int) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs = ["10.0.0.0/16"]
+  used_cidrs = []
+  mask       = 24
+  keepers = {
+    version = %d
+    enabled = true
+  }
+}
+`, version)
+}
+
+func testAccExampleResourceReplaceOnInputChangeConfig(from []string, used []string, mask int, replace bool) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs              = %q
+  used_cidrs              = %q
+  mask                    = %v
+  replace_on_input_change = %v
+}
+`, from, used, mask, replace)
+}
+
+func testAccExampleResourceKeepersConfig(keepers string, used []string, replace bool) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs                = ["10.1.0.0/16"]
+  used_cidrs                = %s
+  mask                      = 24
+  keepers                   = %s
+  replace_on_keeper_removal = %v
+}
+`, testAccStringList(used), keepers, replace)
+}
+
+func testAccExampleResourceUpdateReallocationConfig(used []string) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs                = ["10.1.0.0/16"]
+  used_cidrs                = %s
+  mask                      = 24
+  allow_update_reallocation = true
+}
+`, testAccStringList(used))
+}
+
+func testAccExampleResourceGrowConfig(mask int, used []string) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs = ["10.1.0.0/16"]
+  used_cidrs = %s
+  mask       = %d
+  allow_grow = true
+}
+`, testAccStringList(used), mask)
+}
+
+func testAccExampleResourceIPv6FormatConfig(format string) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs  = ["2001:db8::/56"]
+  used_cidrs  = ["2001:db8::/64"]
+  mask        = 64
+  ipv6_format = %q
+}
+`, format)
+}
+
+func testAccExampleResourceMaxSearchBlocksConfig(limit int) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs        = ["10.0.0.0/16"]
+  used_cidrs        = ["10.0.1.0/24", "10.0.3.0/24"]
+  mask              = 24
+  max_search_blocks = %d
+}
+`, limit)
+}
+
+func testAccExampleResourceDedupeFromCidrsConfig(from []string, dedupe bool) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs        = %s
+  used_cidrs        = []
+  mask              = 24
+  dedupe_from_cidrs = %v
+}
+`, testAccStringList(from), dedupe)
+}
+
+func testAccExampleResourcePreferCidrConfig(prefer string) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs  = ["10.1.0.0/16"]
+  used_cidrs  = ["10.1.0.0/24"]
+  mask        = 24
+  prefer_cidr = %q
+}
+`, prefer)
+}
+
+func testAccExampleResourceAllowCidrsConfig(used []string, allow []string) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs  = ["10.1.0.0/16"]
+  used_cidrs  = %s
+  allow_cidrs = %s
+  mask        = 24
+}
+`, testAccStringList(used), testAccStringList(allow))
+}
+
+func testAccExampleResourceSkipUsedValidationConfig(skip bool) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs           = ["10.1.0.0/16"]
+  used_cidrs           = ["10.1.0.0/24", "10.1.1.0"]
+  mask                 = 24
+  skip_used_validation = %v
+}
+`, skip)
+}
+
+func testAccExampleResourceAlignToConfig(used []string, mask int, alignTo int) string {
+	return fmt.Sprintf(`
+resource "utility_available_cidr" "test" {
+  from_cidrs = ["10.1.0.0/24"]
+  used_cidrs = %q
+  mask       = %v
+  align_to   = %v
+}
+`, used, mask, alignTo)
+}
+
+func testAccExampleResourceProviderDefaultStrategyConfig(defaultStrategy string, strategy string) string {
+	strategyAttribute := ""
+	if strategy != "" {
+		strategyAttribute = fmt.Sprintf("strategy   = %q", strategy)
+	}
+
+	return fmt.Sprintf(`
+provider "utility" {
+  default_allocation_strategy = %q
+}
+
+resource "utility_available_cidr" "test" {
+  from_cidrs = ["10.1.0.0/16"]
+  used_cidrs = ["10.1.255.0/24"]
+  mask       = 24
+  %s
+}
+`, defaultStrategy, strategyAttribute)
+}
+
+func testAccExampleResourceProviderDeterministicSeedConfig(seed int64) string {
+	return fmt.Sprintf(`
+provider "utility" {
+  deterministic_seed = %d
+}
+
+resource "utility_available_cidr" "test" {
+  from_cidrs = ["10.0.0.0/8"]
+  mask       = 24
+  strategy   = "random"
+  keepers = {
+    key = "one"
+  }
+}
+
+resource "utility_available_cidr" "test2" {
+  from_cidrs = ["10.0.0.0/8"]
+  mask       = 24
+  strategy   = "random"
+  keepers = {
+    key = "two"
+  }
+}
+
+resource "utility_available_integer" "test" {
+  min      = 0
+  max      = 1000000
+  used     = []
+  strategy = "random"
+  keepers = {
+    key = "one"
+  }
+}
+
+resource "utility_available_integer" "test2" {
+  min      = 0
+  max      = 1000000
+  used     = []
+  strategy = "random"
+  keepers = {
+    key = "two"
+  }
+}
+`, seed)
+}
+
+func testAccExampleResourceProviderAddressFamilyConfig(addressFamily string, from string, mask int) string {
+	return fmt.Sprintf(`
+provider "utility" {
+  address_family = %q
+}
+
+resource "utility_available_cidr" "test" {
+  from_cidrs = [%q]
+  used_cidrs = []
+  mask       = %v
+}
+`, addressFamily, from, mask)
+}
+
+func testAccExampleResourceIPv4MappedConfig(mode string, from string, used string) string {
+	return fmt.Sprintf(`
+provider "utility" {
+  ipv4_mapped_cidrs = %q
+}
+
+resource "utility_available_cidr" "test" {
+  from_cidrs = [%q]
+  used_cidrs = [%q]
+  mask       = 24
+}
+`, mode, from, used)
 }
@@ -0,0 +1,928 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/planmodifiers"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func (r *AvailableCidrResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Given CIDR range(s) to search over (ex. a Network) and a list of already used CIDR ranges (ex. a list of subnets) " +
+			"find an unused, non-conflicting CIDR range of specified size.\n\n" +
+			"The `result` is kept stable once it is allocated, since it is typically used to create a network/subnet, so " +
+			"changing the inputs used to find it has no effect unless `replace_on_input_change` is `true`. To conditionally " +
+			"allocate a new CIDR otherwise, change the `keepers`.",
+		Version: availableCidrSchemaVersion,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "CIDR Identifier. The value will be identical to the `result` field.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"from_cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing the CIDR range(s) from which to search for available CIDR ranges. At least one of `from_cidrs` or `from_ranges` must be set. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				Optional: true,
+				PlanModifiers: []planmodifier.List{
+					planmodifiers.ListRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"from_ranges": schema.ListAttribute{
+				MarkdownDescription: "A list containing address ranges in `start-end` notation (ex. `10.0.0.0-10.0.3.255`) from which to search for available CIDR ranges, for IPAM exports that don't use CIDR notation. Each range is converted into the smallest set of CIDRs that covers it, which are searched after the `from_cidrs` and are treated the same way (ex. `from_cidr` is set to the covering CIDR the `result` was allocated from). The start and end of a range must be of the same address family, and the start must not be after the end. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				Optional: true,
+				PlanModifiers: []planmodifier.List{
+					planmodifiers.ListRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"used_cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing the CIDR ranges that are already used within the `from_cidrs` block(s) which should be avoided to prevent overlaps and/or collisions. May be omitted when nothing is used yet (ex. the first allocation out of a new network). Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					cidrListUnlessSkipped(path.Root("skip_used_validation")),
+				},
+				Optional: true,
+				PlanModifiers: []planmodifier.List{
+					planmodifiers.ListRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"used_cidrs_json": schema.StringAttribute{
+				MarkdownDescription: "A JSON array of CIDR range strings that are used in the same way as `used_cidrs`, for lists that are too large to write inline (ex. `file(\"used.json\")`, or the `response_body` of an `http` data source reading an IPAM export). The entries are added to the `used_cidrs`, so the two can be combined. An entry that isn't a string, or isn't a CIDR range, is an error naming the entry. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.StringRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"reserved_cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing CIDR ranges within the `from_cidrs` block(s) that are reserved and should never be allocated, even though nothing uses them yet (ex. management or future expansion ranges). These are avoided in the same way as `used_cidrs`, but are kept separate to make the reservation explicit. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				Optional: true,
+				PlanModifiers: []planmodifier.List{
+					planmodifiers.ListRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"allow_cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing CIDR ranges within the `from_cidrs` block(s) that the `result` must be allocated from. When set, only blocks that are fully contained within at least one of these windows are considered, on top of avoiding the `used_cidrs` and `reserved_cidrs`. Every range must lie within one of the `from_cidrs`, and at least one must be given. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				Optional: true,
+				PlanModifiers: []planmodifier.List{
+					planmodifiers.ListRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"mask": schema.Int64Attribute{
+				MarkdownDescription: "Desired mask (network/subnet size) to find that is available. Must be between `0` and `32` for IPv4 `from_cidrs`, or `0` and `128` for IPv6. Exactly one of `mask` or `netmask` must be set. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 128),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					planmodifiers.Int64RequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"masks": schema.ListAttribute{
+				MarkdownDescription: "A mask for each of the `from_cidrs`, in the same order, for when the ranges are supernets of different sizes and each should be allocated from with its own size. An entry overrides `mask` when searching its range, and a `null` entry uses `mask`. Each mask must fit within its range, and there must be exactly one entry for each of the `from_cidrs`. The `netmask` and `prefix_length` describe the `result`, so they reflect the mask of the range it was allocated from. Can't be combined with `netmask`, `from_ranges`, `coalesce`, `dedupe_from_cidrs` or `start_offset`, which all assume a single size or change the ranges being searched. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				ElementType:         types.Int64Type,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueInt64sAre(int64validator.Between(0, 128)),
+				},
+				Optional: true,
+				PlanModifiers: []planmodifier.List{
+					planmodifiers.ListRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"allocation_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of non-overlapping CIDRs of size `mask` to allocate. Defaults to `1`. Each allocation is treated as used when searching for the next one, so the allocated CIDRs never collide with each other. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					planmodifiers.Int64RequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"coalesce": schema.BoolAttribute{
+				MarkdownDescription: "When `true` and `allocation_count` is greater than `1`, a single block that is large enough to hold `allocation_count` subnets of size `mask` is allocated instead of separate CIDRs (ex. an `allocation_count` of `3` with a `mask` of `24` allocates a `/22`). The block is rounded up to the next power of two, aligned to its own size, returned in `result`, and split into the `mask` sized `results`. Any subnets of the block beyond `allocation_count` are left unallocated but are not counted as remaining. Defaults to `false`. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					planmodifiers.BoolRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"spread": schema.BoolAttribute{
+				MarkdownDescription: "When `true` and `allocation_count` is greater than `1`, the `results` are dealt out across the `from_cidrs` in turn instead of filling the first range with space before moving on, so they are balanced between the ranges (ex. when each of the `from_cidrs` maps to an availability zone or region). The first result is taken from the first of the `from_cidrs`, the second from the second, and so on, wrapping around to the first again. A range without space passes its turn to the next. The `strategy` then only chooses where within the range a result goes (ex. `last_fit` takes the highest available CIDR of the range whose turn it is), rather than which range it comes from. Has no effect with `coalesce`, since a single block is allocated. Defaults to `false`. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					planmodifiers.BoolRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"sort_results": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, the `results` are sorted by ascending network address, so their order doesn't depend on the `strategy` or the order the `from_cidrs` were searched in (ex. `last_fit` finds the highest CIDR first). The `result` is always the first CIDR that was allocated. Defaults to `true`. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					planmodifiers.BoolRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"strategy": schema.StringAttribute{
+				MarkdownDescription: "Allocation strategy used to pick among the available CIDRs. `first_fit` (default) returns the lowest available CIDR, `last_fit` returns the highest, `best_fit` returns the CIDR that fills the smallest gap between the `used_cidrs`, preferring the lowest address when gaps are the same size, `random` returns a random available CIDR and `compact` returns the lowest available CIDR across all of the `from_cidrs`. When there are several `from_cidrs`, `first_fit` and `random` use the first range with space, while `last_fit`, `best_fit` and `compact` compare the available CIDRs across all of the ranges. `compact` differs from `first_fit` only in ignoring the order of the `from_cidrs`, so that in a long-lived pool a block freed at a low address is always reused before a higher one, regardless of which range it is in or how fragmented its gap is. Alignment doesn't set them apart, since every strategy only considers CIDRs starting on an `align_to` boundary. The `random` strategy is seeded from the `keepers`, so the same `keepers` always produce the same `result` and changing them moves the allocation, unless the provider sets `deterministic_seed`. When unset, the provider's `default_allocation_strategy` is used. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(strategyFirstFit, strategyLastFit, strategyBestFit, strategyRandom, strategyCompact),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					planmodifiers.StringRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"exclude_first_subnet": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, the first `mask` sized block of each of the `from_cidrs` is treated as used (ex. when the platform reserves the first subnet of a network). Defaults to `false`. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					planmodifiers.BoolRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"exclude_last_subnet": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, the last `mask` sized block of each of the `from_cidrs` is treated as used (ex. when the platform reserves the last subnet of a network). Defaults to `false`. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					planmodifiers.BoolRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"start_offset": schema.Int64Attribute{
+				MarkdownDescription: "Number of `mask` sized blocks at the start of each of the `from_cidrs` to skip, so that allocations don't always begin at the `.0` block (ex. a `start_offset` of `4` with a `mask` of `24` in `10.0.0.0/16` starts searching at `10.0.4.0/24`). The skipped blocks are treated as used, and combined with the `random` strategy this makes the `result` harder to predict while keeping it stable across plans. Must be less than the number of `mask` sized blocks in the largest of the `from_cidrs`. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					planmodifiers.Int64RequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"min_gap": schema.Int64Attribute{
+				MarkdownDescription: "The least number of free addresses to leave between the `result` and each of the `used_cidrs` and `reserved_cidrs`, as a guard band for blast-radius isolation (ex. a `min_gap` of `256` keeps a `/24` of space on either side of every used block). A CIDR that would fit, but is closer than `min_gap` addresses to a used block on either side, is not allocated. The guard bands are treated as used, so they count towards `remaining_addresses` and `remaining_blocks`. When `allocation_count` is greater than `1`, the `results` are kept apart from the used blocks but not from each other. Must be at least `0`, and `0` (the default when unset) allows allocations right next to a used block. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					planmodifiers.Int64RequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"after_cidr": schema.StringAttribute{
+				MarkdownDescription: "A CIDR to anchor the search at, for predictable layouts (ex. \"the next free `/24` at or after `10.0.10.0/24`\"). Only blocks starting at or after the first address of `after_cidr` are considered, and everything before it is treated as used, even when it is free. Unlike `start_offset`, which counts `mask` sized blocks from the start of each of the `from_cidrs`, this is an absolute address. Must be within one of the `from_cidrs`. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation"),
+				},
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.StringRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"dedupe_from_cidrs": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, overlapping and adjacent `from_cidrs` are collapsed into the smallest set of ranges covering the same addresses before searching, and the collapsed ranges are searched in ascending order. `exclude_first_subnet` and `exclude_last_subnet` apply to the collapsed ranges. When `false`, overlapping `from_cidrs` are reported as a warning. Defaults to `false`. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					planmodifiers.BoolRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"align_to": schema.Int64Attribute{
+				MarkdownDescription: "Prefix length of the boundary that the `result` must start on, for platforms that need subnets aligned more coarsely than their own size (ex. a `mask` of `28` with an `align_to` of `26` only returns `/28`s that start on a `/26` boundary). Must be less than or equal to `mask`. Defaults to `mask`, which aligns each CIDR to its own size. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 128),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					planmodifiers.Int64RequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"enumerate_bits": schema.Int64Attribute{
+				MarkdownDescription: "When set, the `result` is also split into every subnet `enumerate_bits` longer than its prefix, which are returned in `subnets` (ex. an `enumerate_bits` of `8` on a `/56` result lists its 256 `/64`s, for IPv6 prefix delegation). The number of subnets, `2^enumerate_bits`, must not exceed `enumerate_limit`. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 128),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					planmodifiers.Int64RequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"enumerate_limit": schema.Int64Attribute{
+				MarkdownDescription: "The largest number of `subnets` that `enumerate_bits` may produce, to avoid accidentally listing billions of subnets. Defaults to `1024`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(defaultEnumerateLimit),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"ipv6_format": schema.StringAttribute{
+				MarkdownDescription: "How IPv6 addresses are written in `id`, `result`, `results`, `result_ip`, `network_address`, `first_host`, `last_host`, `subnets`, `used_cidrs_next`, `normalized_from_cidrs`, `from_cidr`, `allocation_json` and `netmask` when it is computed from `mask`. `compressed` (default) is the canonical form, with lowercase hex digits and the longest run of zero groups replaced by `::` (ex. `2001:db8::/48`). `expanded` writes all eight groups as four hex digits (ex. `2001:0db8:0000:0000:0000:0000:0000:0000/48`). The addresses are the same network either way, only their formatting differs, so changing this value after creation re-renders the attributes in place without allocating a new CIDR. IPv4 addresses are not affected.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(ipv6FormatCompressed),
+				Validators: []validator.String{
+					stringvalidator.OneOf(ipv6FormatCompressed, ipv6FormatExpanded),
+				},
+			},
+			"max_search_blocks": schema.Int64Attribute{
+				MarkdownDescription: "The largest number of candidate blocks the allocator may examine before giving up with a \"Search space too large\" error, so that bad inputs fail quickly instead of hanging the apply. The allocator walks the runs of free addresses between the `used_cidrs`, `reserved_cidrs` and excluded blocks rather than every `mask` sized block, examining one candidate in each, so the size of the `from_cidrs` doesn't count towards the limit, only how fragmented they are. Defaults to `1000000`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(defaultMaxSearchBlocks),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"skip_used_validation": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, the elements of `used_cidrs` and the entries of `used_cidrs_json` aren't checked for CIDR notation during plan. Checking is slow for lists with tens of thousands of elements, so this speeds up plans for large lists that are known to be well-formed, at the cost of a malformed element only being reported when the CIDR is allocated during apply. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"strict_used_cidrs": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, any `used_cidrs` that aren't contained within one of the `from_cidrs` are an error, since they usually mean the wrong network's ranges were passed in. When `false`, they are reported as a warning and otherwise ignored. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"prefer_cidr": schema.StringAttribute{
+				MarkdownDescription: "A CIDR to allocate in preference to the `strategy`, for adopting an existing network (ex. when importing infrastructure that already uses a known range). It is only used when it is `mask` sized, lies within the `from_cidrs`, starts on an `align_to` boundary and doesn't overlap the `used_cidrs` or `reserved_cidrs`, otherwise the `strategy` is used as normal. When `allocation_count` is greater than `1` it is the `result`. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation"),
+				},
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.StringRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"replace_on_input_change": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, changing any of the inputs used to find the `result` (`from_cidrs`, `from_ranges`, `used_cidrs`, `used_cidrs_json`, `reserved_cidrs`, `allow_cidrs`, `mask`, `masks`, `netmask`, `allocation_count`, `coalesce`, `spread`, `sort_results`, `strategy`, `exclude_first_subnet`, `exclude_last_subnet`, `start_offset`, `min_gap`, `after_cidr`, `dedupe_from_cidrs`, `align_to`, `enumerate_bits` or `prefer_cidr`) after creation will destroy and recreate this resource, allocating a new CIDR. Re-ordering the CIDR lists does not trigger replacement. Defaults to `false`, which keeps the `result` stable regardless of input changes so that a network/subnet created from it isn't replaced. Without it, the `keepers` can be used to conditionally allocate a new CIDR.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"replace_on_keeper_removal": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, removing a key from `keepers`, or setting its value to `null`, after creation will destroy and recreate this resource in the same way as changing a value does. Only the `keepers` are affected, the other inputs still follow `replace_on_input_change`. Defaults to `false`, which ignores removed and `null` keepers so that state written by older versions of the provider isn't replaced.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"allow_update_reallocation": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, changing `used_cidrs` or `used_cidrs_json` after creation re-allocates the `result` in place if any of the `results` now overlap the `used_cidrs`, instead of keeping a CIDR that collides. Results that don't overlap the new `used_cidrs` are kept, so the resource only changes when it has to. The new `result` is shown in the plan when all of the inputs are known, and is `(known after apply)` otherwise. Has no effect when `replace_on_input_change` is `true`, since changing `used_cidrs` replaces the resource instead. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"allow_grow": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, making `mask` smaller after creation grows the `result` in place, keeping its network address, instead of having no effect. The additional addresses must be free of the `used_cidrs` and `reserved_cidrs` (entries within the current `result` are already part of it), the network address must start on a boundary of the new `mask`, and the grown block must still be within the `from_cidrs`, otherwise the plan fails rather than moving the `result` elsewhere. Only a single `result` can be grown, so `allocation_count` must be `1`. Has no effect when `masks` is set, since the `result` isn't necessarily `mask` sized, or when `replace_on_input_change` is `true`, since changing `mask` replaces the resource instead. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"keepers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, will trigger re-creation of resource. This field works the same as the `keepers` field in the [`Random` provider](https://registry.terraform.io/providers/hashicorp/random/latest/docs#resource-keepers). Numbers and bools are converted to strings by Terraform (ex. a `version = 3` counter is stored as `\"3\"`), so they don't need `tostring()`. Removing a keeper, or setting it to `null`, only triggers re-creation when `replace_on_keeper_removal` is `true`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					planmodifiers.RequiresReplaceIfValuesNotNull(),
+					planmodifiers.MapRequiresReplaceIfEnabled(path.Root("replace_on_keeper_removal")),
+				},
+			},
+			"result": schema.StringAttribute{
+				MarkdownDescription: "The available CIDR that was found. When `allocation_count` is greater than `1` this is the first CIDR that was allocated, which is also first in `results` unless `sort_results` moves it. When all of the inputs are known during plan, the allocation is previewed so the plan shows this value instead of `(known after apply)`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"result_ip": schema.StringAttribute{
+				MarkdownDescription: "The `result` CIDR without its prefix length (ex. `10.1.1.0` for `10.1.1.0/24`), for APIs that take the address on its own. This is the same address as `network_address`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"network_address": schema.StringAttribute{
+				MarkdownDescription: "The network address of the `result` CIDR, which is the first address in the range.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"broadcast_address": schema.StringAttribute{
+				MarkdownDescription: "The broadcast address of the `result` CIDR, which is the last address in the range. This is null for IPv4 `/31` and `/32` ranges and for IPv6 ranges, which have no broadcast address.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"first_host": schema.StringAttribute{
+				MarkdownDescription: "The first usable host address in the `result` CIDR. For IPv4 this excludes the network address, except for `/31` ranges where both addresses are usable and `/32` ranges which contain a single host.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_host": schema.StringAttribute{
+				MarkdownDescription: "The last usable host address in the `result` CIDR. For IPv4 this excludes the broadcast address, except for `/31` ranges where both addresses are usable and `/32` ranges which contain a single host.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"host_count": schema.Int64Attribute{
+				MarkdownDescription: "The total number of addresses in the `result` CIDR (`2^(32 - prefix_length)` for IPv4 and `2^(128 - prefix_length)` for IPv6). IPv6 ranges of `/65` or larger hold more addresses than fit in a Number, so the value saturates at `9223372036854775807`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"remaining_blocks": schema.Int64Attribute{
+				MarkdownDescription: "The number of additional `mask` sized CIDRs (or with `masks`, CIDRs of the mask of the range they are in), aligned to `align_to` when it is set, that could still be allocated from the `from_cidrs` once the `results` are taken, as of when this resource was created. This is null when the resource was imported without its `from_cidrs`. Like `host_count`, the value saturates at `9223372036854775807`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"remaining_addresses": schema.Int64Attribute{
+				MarkdownDescription: "The number of addresses in the `from_cidrs` that are not used, reserved or allocated once the `results` are taken, as of when this resource was created. This is null when the resource was imported without its `from_cidrs`. IPv6 ranges can hold more free addresses than fit in a Number, so the value saturates at `9223372036854775807`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"netmask": schema.StringAttribute{
+				MarkdownDescription: "Desired mask in address notation (ex. `255.255.255.0`), as an alternative to `mask`. Exactly one of `mask` or `netmask` must be set. When `mask` is used this is set to the netmask of the `result` CIDR. Changing this value after creation **HAS NO EFFECT** unless `replace_on_input_change` is `true`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					planmodifiers.StringRequiresReplaceIfEnabled(path.Root("replace_on_input_change")),
+				},
+			},
+			"prefix_length": schema.Int64Attribute{
+				MarkdownDescription: "The prefix length of the `result` CIDR (ex. `24`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"from_cidr": schema.StringAttribute{
+				MarkdownDescription: "The entry of `from_cidrs` that the `result` was allocated from, as it was written (ex. for tagging or routing by supernet). When several entries contain the `result`, this is the first of them. This is null when the resource was imported without its `from_cidrs`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"normalized_from_cidrs": schema.ListAttribute{
+				MarkdownDescription: "The `from_cidrs` that were searched, in canonical network form with duplicates removed (ex. `10.5.3.7/16` is searched as `10.5.0.0/16`). When `dedupe_from_cidrs` is `true` these are the collapsed ranges. This is null when the resource was imported without its `from_cidrs`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				ElementType: types.StringType,
+			},
+			"allocation_json": schema.StringAttribute{
+				MarkdownDescription: "A JSON object describing how the `result` was chosen, for auditing and downstream tooling. It holds the `result`, the `strategy` used, whether the `prefer_cidr` was `preferred` over the strategy, the `searched_cidrs` (the same as `normalized_from_cidrs`) and the `gap` of free addresses the `result` was placed in as its `first` and `last` address. This is null when the resource was imported or created by an older version of the provider.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"results": schema.ListAttribute{
+				MarkdownDescription: "All of the available CIDRs that were found, by ascending network address when `sort_results` is `true`, and in the order they were allocated otherwise.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				ElementType: types.StringType,
+			},
+			"subnets": schema.ListAttribute{
+				MarkdownDescription: "Every subnet of the `result` that is `enumerate_bits` longer than its prefix, in ascending order. This is null when `enumerate_bits` isn't set.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				ElementType: types.StringType,
+			},
+			"used_cidrs_next": schema.ListAttribute{
+				MarkdownDescription: "The `used_cidrs`, including the entries of `used_cidrs_json`, followed by the `results`, for chaining allocations out of the same `from_cidrs` (ex. `used_cidrs = utility_available_cidr.previous.used_cidrs_next`). This is null when the resource was imported without its `used_cidrs`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *AvailableCidrResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("mask"),
+			path.MatchRoot("netmask"),
+		),
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("from_cidrs"),
+			path.MatchRoot("from_ranges"),
+		),
+		// masks are matched to the from_cidrs by position, and each of these either fixes a single size or changes
+		// the ranges that are searched.
+		resourcevalidator.Conflicting(path.MatchRoot("masks"), path.MatchRoot("netmask")),
+		resourcevalidator.Conflicting(path.MatchRoot("masks"), path.MatchRoot("from_ranges")),
+		resourcevalidator.Conflicting(path.MatchRoot("masks"), path.MatchRoot("coalesce")),
+		resourcevalidator.Conflicting(path.MatchRoot("masks"), path.MatchRoot("dedupe_from_cidrs")),
+		resourcevalidator.Conflicting(path.MatchRoot("masks"), path.MatchRoot("start_offset")),
+		sameAddressFamily(path.Root("from_cidrs"), path.Root("from_ranges"), path.Root("used_cidrs")),
+	}
+}
+
+// ValidateConfig catches a mask that is too large to fit in any of the from_cidrs during plan, rather than
+// waiting for the allocation to fail during apply.
+func (r *AvailableCidrResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data AvailableCidrResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The used_cidrs_json entries are checked here as well, since a file that doesn't parse should fail the plan.
+	usedCidrs, diags := effectiveUsedCidrs(data.UsedCidrs, data.UsedCidrsJSON)
+	resp.Diagnostics.Append(diags...)
+	if !data.UsedCidrsJSON.IsNull() && !usedCidrs.IsUnknown() && !data.SkipUsedValidation.IsUnknown() && !data.SkipUsedValidation.ValueBool() {
+		resp.Diagnostics.Append(usedCidrsJSONDiagnostics(data.UsedCidrs, usedCidrs)...)
+	}
+
+	// from_ranges are searched as the CIDRs that cover them, so they are checked the same way as from_cidrs.
+	fromCidrs, diags := effectiveFromCidrs(data.FromCidrs, data.FromRanges)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || fromCidrs.IsNull() || fromCidrs.IsUnknown() {
+		return
+	}
+
+	if !data.DedupeFromCidrs.IsUnknown() && !data.DedupeFromCidrs.ValueBool() {
+		resp.Diagnostics.Append(fromCidrsOverlapWarnings(fromCidrs)...)
+	}
+
+	resp.Diagnostics.Append(fromCidrsScaleWarnings(fromCidrs)...)
+	resp.Diagnostics.Append(duplicateCidrsWarnings("from_cidrs", data.FromCidrs)...)
+	resp.Diagnostics.Append(duplicateCidrsWarnings("used_cidrs", data.UsedCidrs)...)
+
+	if !data.StrictUsedCidrs.IsUnknown() {
+		resp.Diagnostics.Append(strayUsedCidrsDiagnostics(fromCidrs, usedCidrs, data.StrictUsedCidrs.ValueBool())...)
+	}
+
+	if stray := cidrsOutsideFromCidrsList(fromCidrs, data.AllowCidrs); len(stray) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allow_cidrs"),
+			"allow_cidrs outside of from_cidrs",
+			fmt.Sprintf("These allow_cidrs aren't within any of the from_cidrs: %s", strings.Join(stray, ", ")),
+		)
+	}
+
+	if stray := cidrsOutsideFromCidrsList(fromCidrs, types.ListValueMust(types.StringType, []attr.Value{data.AfterCidr})); len(stray) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("after_cidr"),
+			"after_cidr outside of from_cidrs",
+			fmt.Sprintf("The after_cidr %s isn't within any of the from_cidrs, so it can't anchor the search.", stray[0]),
+		)
+	}
+
+	if !data.EnumerateBits.IsNull() && !data.EnumerateBits.IsUnknown() && !data.EnumerateLimit.IsUnknown() {
+		// The configuration doesn't have the schema default applied yet.
+		limit := int64(defaultEnumerateLimit)
+		if !data.EnumerateLimit.IsNull() {
+			limit = data.EnumerateLimit.ValueInt64()
+		}
+		if err := checkEnumerateLimit(int(data.EnumerateBits.ValueInt64()), limit); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("enumerate_bits"),
+				"Too many subnets to enumerate",
+				err.Error(),
+			)
+		}
+	}
+
+	var mask int
+	maskPath := path.Root("mask")
+	switch {
+	case !data.Mask.IsNull() && !data.Mask.IsUnknown():
+		mask = int(data.Mask.ValueInt64())
+	case !data.Netmask.IsNull() && !data.Netmask.IsUnknown():
+		maskPath = path.Root("netmask")
+		ones, _, err := cidrutil.ParseNetmask(data.Netmask.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				maskPath,
+				"Invalid netmask",
+				err.Error(),
+			)
+			return
+		}
+		mask = ones
+	default:
+		return
+	}
+
+	// The schema only bounds mask by the larger IPv6 address, so the bound for IPv4 is checked against the address
+	// family of from_cidrs. Mixed families are reported during apply, so the first from_cidr that parses decides.
+	if maskPath.Equal(path.Root("mask")) {
+		for _, element := range fromCidrs.Elements() {
+			from, ok := element.(types.String)
+			if !ok || from.IsNull() || from.IsUnknown() {
+				continue
+			}
+			_, fromCidr, err := net.ParseCIDR(from.ValueString())
+			if err != nil {
+				continue
+			}
+			if bits := cidrutil.AddressBits(cidrutil.UnmapIPv4(fromCidr)); mask > bits {
+				resp.Diagnostics.AddAttributeError(
+					maskPath,
+					"Invalid mask",
+					fmt.Sprintf("mask must be between 0 and %d for %s from_cidrs, got %d", bits, addressFamilyName(bits), mask),
+				)
+				return
+			}
+			break
+		}
+	}
+
+	if data.Masks.IsNull() && !data.AlignTo.IsNull() && !data.AlignTo.IsUnknown() && data.AlignTo.ValueInt64() > int64(mask) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("align_to"),
+			"Invalid align_to",
+			fmt.Sprintf("align_to /%d must not be smaller than the /%d being allocated", data.AlignTo.ValueInt64(), mask),
+		)
+	}
+
+	fromNetworks := make([]*net.IPNet, 0, len(fromCidrs.Elements()))
+	for _, element := range fromCidrs.Elements() {
+		from, ok := element.(types.String)
+		if !ok || from.IsNull() || from.IsUnknown() {
+			return
+		}
+
+		_, fromCidr, err := net.ParseCIDR(from.ValueString())
+		if err != nil {
+			// malformed CIDRs are reported by the attribute validators
+			return
+		}
+		// IPv4-mapped from_cidrs are rejected during plan unless the provider normalizes them, so they are checked as
+		// the IPv4 ranges they would become.
+		fromNetworks = append(fromNetworks, cidrutil.UnmapIPv4(fromCidr))
+	}
+
+	// Each of the from_cidrs is searched with its own mask, so the masks are checked against their ranges instead.
+	if !data.Masks.IsNull() {
+		if data.Masks.IsUnknown() || data.AlignTo.IsUnknown() {
+			return
+		}
+		for _, element := range data.Masks.Elements() {
+			if element.IsUnknown() {
+				return
+			}
+		}
+
+		prefixLengths, err := rangePrefixLengths(mask, data.Masks, fromNetworks)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("masks"),
+				"Invalid masks",
+				err.Error(),
+			)
+			return
+		}
+		if err := checkRangeAlignTo(data.AlignTo, prefixLengths, fromNetworks); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("align_to"),
+				"Invalid align_to",
+				err.Error(),
+			)
+		}
+		return
+	}
+
+	if detail := maskTooLargeDetail(mask, fromNetworks); detail != "" {
+		resp.Diagnostics.AddAttributeError(
+			maskPath,
+			"Mask too large for from_cidrs",
+			detail,
+		)
+		return
+	}
+
+	if !data.StartOffset.IsNull() && !data.StartOffset.IsUnknown() {
+		if err := checkStartOffset(data.StartOffset.ValueInt64(), mask, fromNetworks); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("start_offset"),
+				"Invalid start_offset",
+				err.Error(),
+			)
+		}
+	}
+
+	// A coalesced block is larger than the mask, so it must fit in the from_cidrs as well.
+	if !data.Coalesce.ValueBool() || data.AllocationCount.IsUnknown() || data.AllocationCount.ValueInt64() <= 1 || len(fromNetworks) == 0 {
+		return
+	}
+	count := int(data.AllocationCount.ValueInt64())
+	blockPrefixLength := cidrutil.CoalescedPrefixLength(mask, count)
+	if blockPrefixLength < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allocation_count"),
+			"Invalid coalesce",
+			fmt.Sprintf("%d /%d subnets don't fit in a single %s block", count, mask, addressFamilyName(cidrutil.AddressBits(fromNetworks[0]))),
+		)
+		return
+	}
+	if detail := maskTooLargeDetail(blockPrefixLength, fromNetworks); detail != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allocation_count"),
+			"Coalesced block too large for from_cidrs",
+			fmt.Sprintf("%d /%d subnets are coalesced into a /%d block. %s", count, mask, blockPrefixLength, detail),
+		)
+	}
+}
+
+// checkAddressFamily returns an error if network isn't of the address family the provider restricts resources to.
+// An empty addressFamily allows both families.
+func checkAddressFamily(addressFamily string, network *net.IPNet) error {
+	switch {
+	case addressFamily == addressFamilyIPv4 && cidrutil.AddressBits(network) != 32:
+		return fmt.Errorf("%s is an IPv6 range, but the provider address_family only allows IPv4", network)
+	case addressFamily == addressFamilyIPv6 && cidrutil.AddressBits(network) != 128:
+		return fmt.Errorf("%s is an IPv4 range, but the provider address_family only allows IPv6", network)
+	}
+	return nil
+}
+
+// checkIPv4Mapped returns network unchanged unless it is an IPv4-mapped IPv6 network (ex. ::ffff:10.0.0.0/120), which
+// is converted to IPv4 when mode is ipv4MappedNormalize and rejected otherwise. Allocating from a mapped network
+// would use IPv6 mask lengths for what are IPv4 addresses. cidr is the network as it was written, since a mapped
+// network prints as if it were IPv4.
+func checkIPv4Mapped(mode string, cidr string, network *net.IPNet) (*net.IPNet, error) {
+	if !cidrutil.IsIPv4Mapped(network) {
+		return network, nil
+	}
+	if mode == ipv4MappedNormalize {
+		return cidrutil.UnmapIPv4(network), nil
+	}
+	return nil, fmt.Errorf("%s is an IPv4-mapped IPv6 range, which would be searched with IPv6 mask lengths. Use the IPv4 range %s instead, "+
+		"or set the provider ipv4_mapped_cidrs to %q to convert it automatically", cidr, cidrutil.UnmapIPv4(network), ipv4MappedNormalize)
+}
+
+// addressFamilyName returns the name of the address family with the given number of address bits.
+func addressFamilyName(bits int) string {
+	if bits == 32 {
+		return "IPv4"
+	}
+	return "IPv6"
+}
+
+// fromCidrsOverlapWarnings returns a warning for each pair of from_cidrs that overlap. Overlapping ranges are
+// searched separately, so the same addresses are offered from both, which is usually a mistake. Elements that are
+// unknown or aren't valid CIDRs are skipped.
+func fromCidrsOverlapWarnings(fromCidrs types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	networks := []*net.IPNet{}
+	values := []string{}
+	indexes := []int{}
+	for i, element := range fromCidrs.Elements() {
+		from, ok := element.(types.String)
+		if !ok || from.IsNull() || from.IsUnknown() {
+			continue
+		}
+		_, network, err := net.ParseCIDR(from.ValueString())
+		if err != nil {
+			continue
+		}
+		networks = append(networks, network)
+		values = append(values, from.ValueString())
+		indexes = append(indexes, i)
+	}
+
+	for _, pair := range cidrutil.OverlappingPairs(networks) {
+		// Exact duplicates are reported by duplicateCidrsWarnings instead.
+		if networks[pair[0]].String() == networks[pair[1]].String() {
+			continue
+		}
+		diags.AddAttributeWarning(
+			path.Root("from_cidrs").AtListIndex(indexes[pair[1]]),
+			"Overlapping from_cidrs",
+			fmt.Sprintf("%s overlaps %s, so the same CIDR could be allocated from either range. Remove the overlap or set dedupe_from_cidrs to collapse the ranges before searching.", values[pair[1]], values[pair[0]]),
+		)
+	}
+
+	return diags
+}
+
+// duplicateCidrsWarnings warns about each element of the list attribute that is the same network as an earlier
+// element once any host bits are dropped (ex. 10.0.0.0/24 and 10.0.0.5/24). Duplicates don't change the result,
+// but they usually mean a range was pasted twice or another one was meant.
+func duplicateCidrsWarnings(attribute string, cidrs types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	seen := map[string]string{}
+	for i, element := range cidrs.Elements() {
+		cidr, ok := element.(types.String)
+		if !ok || cidr.IsNull() || cidr.IsUnknown() {
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr.ValueString())
+		if err != nil {
+			continue
+		}
+
+		first, ok := seen[network.String()]
+		if !ok {
+			seen[network.String()] = cidr.ValueString()
+			continue
+		}
+		diags.AddAttributeWarning(
+			path.Root(attribute).AtListIndex(i),
+			"Duplicate "+attribute,
+			fmt.Sprintf("%s is listed more than once in %s (as %s and %s), remove the repeated entry.", network, attribute, first, cidr.ValueString()),
+		)
+	}
+
+	return diags
+}
+
+// fromCidrsScaleWarnings warns about from_cidrs that span an entire address space (ex. 0.0.0.0/0). The search only
+// walks the gaps between the used ranges, so it stays fast, but such a range is almost always a mistake.
+func fromCidrsScaleWarnings(fromCidrs types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for i, element := range fromCidrs.Elements() {
+		from, ok := element.(types.String)
+		if !ok || from.IsNull() || from.IsUnknown() {
+			continue
+		}
+		_, network, err := net.ParseCIDR(from.ValueString())
+		if err != nil {
+			continue
+		}
+		if ones, bits := network.Mask.Size(); ones == 0 {
+			diags.AddAttributeWarning(
+				path.Root("from_cidrs").AtListIndex(i),
+				"from_cidrs covers the entire address space",
+				fmt.Sprintf("%s covers every %s address, including public and reserved ranges, so the result may be a range that isn't yours to use "+
+					"and remaining_blocks and remaining_addresses will usually saturate. Use the ranges you actually allocate from instead.", from.ValueString(), addressFamilyName(bits)),
+			)
+		}
+	}
+
+	return diags
+}
+
+// checkRangeAlignTo returns an error if alignTo is finer than any of the prefixLengths, since a block can't start on a
+// boundary smaller than itself. It is the per range form of the align_to check against mask.
+func checkRangeAlignTo(alignTo types.Int64, prefixLengths []int, fromCidrs []*net.IPNet) error {
+	if alignTo.IsNull() || alignTo.IsUnknown() {
+		return nil
+	}
+	for i, prefixLength := range prefixLengths {
+		if alignTo.ValueInt64() > int64(prefixLength) {
+			return fmt.Errorf("align_to /%d must not be smaller than the /%d being allocated from %s", alignTo.ValueInt64(), prefixLength, fromCidrs[i])
+		}
+	}
+	return nil
+}
+
+// strayUsedCidrsDiagnostics reports the known usedCidrs that aren't contained within any of the fromCidrs, as an
+// error when strict is set and as a warning otherwise. Nothing is reported while any of the fromCidrs are unknown,
+// since a stray entry may be contained by one of them.
+func strayUsedCidrsDiagnostics(fromCidrs types.List, usedCidrs types.List, strict bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	stray := cidrsOutsideFromCidrsList(fromCidrs, usedCidrs)
+	if len(stray) == 0 {
+		return diags
+	}
+
+	summary := "used_cidrs outside of from_cidrs"
+	detail := fmt.Sprintf("These used_cidrs aren't within any of the from_cidrs: %s", strings.Join(stray, ", "))
+	if strict {
+		diags.AddAttributeError(path.Root("used_cidrs"), summary, detail)
+	} else {
+		diags.AddAttributeWarning(path.Root("used_cidrs"), summary, detail+". They are ignored, set strict_used_cidrs to make this an error.")
+	}
+	return diags
+}
+
+// usedCidrsJSONDiagnostics reports the first entry of used_cidrs_json that isn't in CIDR notation, given the
+// usedCidrs as configured and the effective list that follows them with the used_cidrs_json entries.
+func usedCidrsJSONDiagnostics(usedCidrs types.List, effective types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	offset := len(usedCidrs.Elements())
+	for i, element := range effective.Elements()[offset:] {
+		entry, ok := element.(types.String)
+		if !ok {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(entry.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("used_cidrs_json"),
+				"Invalid used_cidrs_json",
+				fmt.Sprintf("Entry %d (%q) of used_cidrs_json is not a CIDR range: %s", i, entry.ValueString(), err.Error()),
+			)
+			return diags
+		}
+	}
+	return diags
+}
+
+// cidrsOutsideFromCidrsList returns the known, well-formed elements of cidrs that aren't contained within any of the
+// fromCidrs. Nothing is returned while any of the fromCidrs are unknown. IPv4-mapped CIDRs are compared as IPv4, since
+// they are either normalized or rejected during plan.
+func cidrsOutsideFromCidrsList(fromCidrs types.List, cidrs types.List) []string {
+	fromNetworks := []*net.IPNet{}
+	for _, element := range fromCidrs.Elements() {
+		from, ok := element.(types.String)
+		if !ok || from.IsUnknown() {
+			return nil
+		}
+		if _, network, err := net.ParseCIDR(from.ValueString()); err == nil {
+			fromNetworks = append(fromNetworks, cidrutil.UnmapIPv4(network))
+		}
+	}
+
+	stray := []string{}
+	for _, element := range cidrs.Elements() {
+		cidr, ok := element.(types.String)
+		if !ok || cidr.IsNull() || cidr.IsUnknown() {
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr.ValueString())
+		if err != nil {
+			// malformed CIDRs are reported by the attribute validators
+			continue
+		}
+		if len(cidrutil.Outside(fromNetworks, []*net.IPNet{cidrutil.UnmapIPv4(network)})) > 0 {
+			stray = append(stray, cidr.ValueString())
+		}
+	}
+	return stray
+}
@@ -0,0 +1,289 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAvailableCidrResourceSchema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	NewAvailableCidrResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	if diagnostics := schemaResponse.Schema.ValidateImplementation(ctx); diagnostics.HasError() {
+		t.Fatalf("schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestCheckAddressFamily(t *testing.T) {
+	tests := []struct {
+		addressFamily string
+		cidr          string
+		wantErr       bool
+	}{
+		{addressFamily: "", cidr: "10.0.0.0/16"},
+		{addressFamily: "", cidr: "fd00::/48"},
+		{addressFamily: "ipv4", cidr: "10.0.0.0/16"},
+		{addressFamily: "ipv4", cidr: "fd00::/48", wantErr: true},
+		{addressFamily: "ipv6", cidr: "fd00::/48"},
+		{addressFamily: "ipv6", cidr: "10.0.0.0/16", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		_, network, err := net.ParseCIDR(tc.cidr)
+		if err != nil {
+			t.Fatalf("unable to parse %s: %s", tc.cidr, err)
+		}
+		if err := checkAddressFamily(tc.addressFamily, network); (err != nil) != tc.wantErr {
+			t.Errorf("%q with %s: expected error %v, got %v", tc.addressFamily, tc.cidr, tc.wantErr, err)
+		}
+	}
+}
+
+func TestCheckIPv4Mapped(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		cidr    string
+		want    string
+		wantErr bool
+	}{
+		{name: "ipv4 is unchanged", mode: ipv4MappedReject, cidr: "10.0.0.0/24", want: "10.0.0.0/24"},
+		{name: "ipv6 is unchanged", mode: ipv4MappedReject, cidr: "fd00::/64", want: "fd00::/64"},
+		{name: "mapped is rejected", mode: ipv4MappedReject, cidr: "::ffff:10.0.0.0/120", wantErr: true},
+		{name: "mapped is rejected by default", mode: "", cidr: "::ffff:10.0.0.0/120", wantErr: true},
+		{name: "mapped is normalized", mode: ipv4MappedNormalize, cidr: "::ffff:10.0.0.0/120", want: "10.0.0.0/24"},
+		{name: "hex mapped is normalized", mode: ipv4MappedNormalize, cidr: "::ffff:a00:0/104", want: "10.0.0.0/8"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, network, err := net.ParseCIDR(tc.cidr)
+			if err != nil {
+				t.Fatalf("unable to parse %s: %s", tc.cidr, err)
+			}
+
+			got, err := checkIPv4Mapped(tc.mode, tc.cidr, network)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %s", got)
+				}
+				if !strings.Contains(err.Error(), tc.cidr) {
+					t.Errorf("expected the error to name %s, got %q", tc.cidr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromCidrsOverlapWarnings(t *testing.T) {
+	tests := []struct {
+		name      string
+		fromCidrs []attr.Value
+		want      []string
+	}{
+		{
+			name:      "disjoint",
+			fromCidrs: []attr.Value{types.StringValue("10.0.0.0/16"), types.StringValue("10.1.0.0/16")},
+		},
+		{
+			name:      "supernet",
+			fromCidrs: []attr.Value{types.StringValue("10.0.0.0/8"), types.StringValue("10.2.0.0/16"), types.StringValue("10.1.0.0/16")},
+			want:      []string{"10.2.0.0/16 overlaps 10.0.0.0/8", "10.1.0.0/16 overlaps 10.0.0.0/8"},
+		},
+		{
+			name:      "different families",
+			fromCidrs: []attr.Value{types.StringValue("10.0.0.0/8"), types.StringValue("::/0")},
+		},
+		{
+			name:      "duplicates are left to duplicateCidrsWarnings",
+			fromCidrs: []attr.Value{types.StringValue("10.0.0.0/16"), types.StringValue("10.0.0.0/16")},
+		},
+		{
+			name:      "unknown and malformed elements are skipped",
+			fromCidrs: []attr.Value{types.StringValue("10.0.0.0/8"), types.StringUnknown(), types.StringValue("10.0.0.0")},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := fromCidrsOverlapWarnings(types.ListValueMust(types.StringType, tc.fromCidrs))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %+v", diags)
+			}
+			if len(diags) != len(tc.want) {
+				t.Fatalf("expected %d warnings, got %+v", len(tc.want), diags)
+			}
+			for i, want := range tc.want {
+				if !strings.Contains(diags[i].Detail(), want) {
+					t.Errorf("expected warning %d to contain %q, got %q", i, want, diags[i].Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestDuplicateCidrsWarnings(t *testing.T) {
+	tests := []struct {
+		name  string
+		cidrs []attr.Value
+		want  []string
+	}{
+		{
+			name:  "distinct",
+			cidrs: []attr.Value{types.StringValue("10.0.0.0/16"), types.StringValue("10.0.0.0/24")},
+		},
+		{
+			name:  "exact duplicate",
+			cidrs: []attr.Value{types.StringValue("10.0.0.0/16"), types.StringValue("10.1.0.0/16"), types.StringValue("10.0.0.0/16")},
+			want:  []string{"10.0.0.0/16 is listed more than once"},
+		},
+		{
+			name:  "duplicate after normalization",
+			cidrs: []attr.Value{types.StringValue("10.0.3.0/24"), types.StringValue("10.0.3.5/24"), types.StringValue("10.0.3.9/24")},
+			want:  []string{"as 10.0.3.0/24 and 10.0.3.5/24", "as 10.0.3.0/24 and 10.0.3.9/24"},
+		},
+		{
+			name:  "unknown and malformed elements are skipped",
+			cidrs: []attr.Value{types.StringUnknown(), types.StringUnknown(), types.StringValue("10.0.0.0"), types.StringValue("10.0.0.0")},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := duplicateCidrsWarnings("used_cidrs", types.ListValueMust(types.StringType, tc.cidrs))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %+v", diags)
+			}
+			if len(diags) != len(tc.want) {
+				t.Fatalf("expected %d warnings, got %+v", len(tc.want), diags)
+			}
+			for i, want := range tc.want {
+				if !strings.Contains(diags[i].Detail(), want) {
+					t.Errorf("expected warning %d to contain %q, got %q", i, want, diags[i].Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestFromCidrsScaleWarnings(t *testing.T) {
+	tests := []struct {
+		name      string
+		fromCidrs []attr.Value
+		want      []string
+	}{
+		{
+			name:      "ordinary ranges",
+			fromCidrs: []attr.Value{types.StringValue("10.0.0.0/8"), types.StringValue("fd00::/8")},
+		},
+		{
+			name:      "whole address spaces",
+			fromCidrs: []attr.Value{types.StringValue("0.0.0.0/0"), types.StringValue("10.0.0.0/8"), types.StringValue("::/0")},
+			want:      []string{"0.0.0.0/0 covers every IPv4 address", "::/0 covers every IPv6 address"},
+		},
+		{
+			name:      "unknown and malformed elements are skipped",
+			fromCidrs: []attr.Value{types.StringUnknown(), types.StringValue("0.0.0.0")},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := fromCidrsScaleWarnings(types.ListValueMust(types.StringType, tc.fromCidrs))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %+v", diags)
+			}
+			if len(diags) != len(tc.want) {
+				t.Fatalf("expected %d warnings, got %+v", len(tc.want), diags)
+			}
+			for i, want := range tc.want {
+				if !strings.Contains(diags[i].Detail(), want) {
+					t.Errorf("expected warning %d to contain %q, got %q", i, want, diags[i].Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestStrayUsedCidrsDiagnostics(t *testing.T) {
+	stringList := func(values ...string) types.List {
+		elements := make([]attr.Value, len(values))
+		for i, v := range values {
+			elements[i] = types.StringValue(v)
+		}
+		return types.ListValueMust(types.StringType, elements)
+	}
+
+	tests := []struct {
+		name      string
+		fromCidrs types.List
+		usedCidrs types.List
+		strict    bool
+		want      string
+		wantError bool
+	}{
+		{
+			name:      "all contained",
+			fromCidrs: stringList("10.0.0.0/16", "10.1.0.0/16"),
+			usedCidrs: stringList("10.0.0.0/24", "10.1.0.0/16"),
+		},
+		{
+			name:      "stray entries are a warning",
+			fromCidrs: stringList("10.0.0.0/16"),
+			usedCidrs: stringList("10.0.0.0/24", "10.2.0.0/24", "10.0.0.0/8"),
+			want:      "10.2.0.0/24, 10.0.0.0/8",
+		},
+		{
+			name:      "stray entries are an error when strict",
+			fromCidrs: stringList("10.0.0.0/16"),
+			usedCidrs: stringList("10.2.0.0/24"),
+			strict:    true,
+			want:      "10.2.0.0/24",
+			wantError: true,
+		},
+		{
+			name:      "unknown from_cidrs skip the check",
+			fromCidrs: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.0/16"), types.StringUnknown()}),
+			usedCidrs: stringList("10.2.0.0/24"),
+			strict:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := strayUsedCidrsDiagnostics(tc.fromCidrs, tc.usedCidrs, tc.strict)
+			if diags.HasError() != tc.wantError {
+				t.Fatalf("expected error %v, got %+v", tc.wantError, diags)
+			}
+			if tc.want == "" {
+				if len(diags) != 0 {
+					t.Fatalf("expected no diagnostics, got %+v", diags)
+				}
+				return
+			}
+			if len(diags) != 1 || !strings.Contains(diags[0].Detail(), tc.want) {
+				t.Errorf("expected a diagnostic listing %q, got %+v", tc.want, diags)
+			}
+		})
+	}
+}
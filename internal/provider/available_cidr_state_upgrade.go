@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// availableCidrSchemaVersion is the current version of the utility_available_cidr schema. Bump it, and add an
+// upgrader below, whenever existing state needs to be rewritten to match the schema.
+const availableCidrSchemaVersion = 1
+
+// availableCidrResourceModelV0 is the state written before the schema was versioned. Only id, keepers, from_cidrs,
+// used_cidrs, mask and result are guaranteed to be present, the other attributes were added over the course of
+// version 0 and may be missing.
+type availableCidrResourceModelV0 struct {
+	Id              *string            `json:"id"`
+	Keepers         map[string]*string `json:"keepers"`
+	FromCidrs       []string           `json:"from_cidrs"`
+	UsedCidrs       []string           `json:"used_cidrs"`
+	ReservedCidrs   []string           `json:"reserved_cidrs"`
+	Mask            *int64             `json:"mask"`
+	AllocationCount *int64             `json:"allocation_count"`
+	Strategy        *string            `json:"strategy"`
+	ExcludeFirst    *bool              `json:"exclude_first_subnet"`
+	ExcludeLast     *bool              `json:"exclude_last_subnet"`
+	ReplaceOnChange *bool              `json:"replace_on_input_change"`
+	Result          *string            `json:"result"`
+	Results         []string           `json:"results"`
+	Netmask         *string            `json:"netmask"`
+}
+
+// UpgradeState backfills the attributes that were added to version 0 of the schema. Without this, state written by
+// an older provider has null computed attributes, which UseStateForUnknown can't keep stable, so every plan shows
+// them as known after apply.
+func (r *AvailableCidrResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// The prior schema is left unset since version 0 state may hold any subset of the attributes, so it is
+		// decoded from the raw JSON instead.
+		0: {
+			StateUpgrader: upgradeAvailableCidrStateV0,
+		},
+	}
+}
+
+func upgradeAvailableCidrStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil {
+		resp.Diagnostics.AddError(
+			"Unable to upgrade state",
+			"The prior state is missing",
+		)
+		return
+	}
+
+	var prior availableCidrResourceModelV0
+	if err := json.Unmarshal(req.RawState.JSON, &prior); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to upgrade state",
+			fmt.Sprintf("Unable to read the version 0 state: %s", err.Error()),
+		)
+		return
+	}
+
+	data := AvailableCidrResourceModel{
+		Id:              types.StringPointerValue(prior.Id),
+		Mask:            types.Int64PointerValue(prior.Mask),
+		AllocationCount: types.Int64Value(1),
+		Coalesce:        types.BoolValue(false),
+		Strategy:        types.StringValue(strategyFirstFit),
+		ExcludeFirst:    types.BoolValue(false),
+		ExcludeLast:     types.BoolValue(false),
+		ReplaceOnChange: types.BoolValue(false),
+		// dedupe_from_cidrs and replace_on_keeper_removal were added with version 1, so there is nothing to carry over.
+		DedupeFromCidrs:    types.BoolValue(false),
+		ReplaceOnRemoval:   types.BoolValue(false),
+		SkipUsedValidation: types.BoolValue(false),
+		StrictUsedCidrs:    types.BoolValue(false),
+		UsedCidrsJSON:      types.StringNull(),
+		Masks:              types.ListNull(types.Int64Type),
+		Spread:             types.BoolValue(false),
+		PreferCidr:         types.StringNull(),
+		AllowCidrs:         types.ListNull(types.StringType),
+		AlignTo:            types.Int64Null(),
+		AllowReallocation:  types.BoolValue(false),
+		AllowGrow:          types.BoolValue(false),
+		SortResults:        types.BoolValue(true),
+		Ipv6Format:         types.StringValue(ipv6FormatCompressed),
+		MaxSearchBlocks:    types.Int64Value(defaultMaxSearchBlocks),
+		EnumerateBits:      types.Int64Null(),
+		StartOffset:        types.Int64Null(),
+		MinGap:             types.Int64Null(),
+		AfterCidr:          types.StringNull(),
+		EnumerateLimit:     types.Int64Value(defaultEnumerateLimit),
+		Subnets:            types.ListNull(types.StringType),
+		// The capacity left when the resource was created can't be recovered.
+		RemainingBlocks:    types.Int64Null(),
+		RemainingAddresses: types.Int64Null(),
+		Result:             types.StringPointerValue(prior.Result),
+		Netmask:            types.StringPointerValue(prior.Netmask),
+	}
+	if prior.AllocationCount != nil {
+		data.AllocationCount = types.Int64Value(*prior.AllocationCount)
+	}
+	if prior.Strategy != nil {
+		data.Strategy = types.StringValue(*prior.Strategy)
+	}
+	if prior.ExcludeFirst != nil {
+		data.ExcludeFirst = types.BoolValue(*prior.ExcludeFirst)
+	}
+	if prior.ExcludeLast != nil {
+		data.ExcludeLast = types.BoolValue(*prior.ExcludeLast)
+	}
+	if prior.ReplaceOnChange != nil {
+		data.ReplaceOnChange = types.BoolValue(*prior.ReplaceOnChange)
+	}
+
+	var diags diag.Diagnostics
+	data.Keepers, diags = types.MapValueFrom(ctx, types.StringType, prior.Keepers)
+	resp.Diagnostics.Append(diags...)
+	data.FromCidrs = stringListValue(prior.FromCidrs)
+	data.FromRanges = types.ListNull(types.StringType)
+	// Version 0 had no dedupe_from_cidrs, so the searched ranges are just the from_cidrs in network form.
+	data.NormalizedFromCidrs = types.ListNull(types.StringType)
+	data.AllocationJson = types.StringNull()
+	data.FromCidr = types.StringNull()
+	if prior.FromCidrs != nil {
+		fromNetworks := make([]*net.IPNet, 0, len(prior.FromCidrs))
+		for _, from := range prior.FromCidrs {
+			if _, network, err := net.ParseCIDR(from); err == nil {
+				fromNetworks = append(fromNetworks, network)
+			}
+		}
+		data.NormalizedFromCidrs = stringListValue(cidrutil.Strings(cidrutil.Normalize(fromNetworks)))
+	}
+	data.UsedCidrs = stringListValue(prior.UsedCidrs)
+	data.ReservedCidrs = stringListValue(prior.ReservedCidrs)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Before allocation_count was added the result was the only allocation.
+	results := prior.Results
+	if results == nil && prior.Result != nil {
+		results = []string{*prior.Result}
+	}
+	data.Results = stringListValue(results)
+	data.UsedCidrsNext = types.ListNull(types.StringType)
+	if results != nil {
+		data.UsedCidrsNext = stringListValue(append(append([]string{}, prior.UsedCidrs...), results...))
+	}
+
+	// A result that doesn't parse is left for Read to report, which removes the resource so it is allocated again.
+	if prior.Result != nil {
+		if _, result, err := net.ParseCIDR(*prior.Result); err == nil {
+			data.setResultAttributes(result)
+			if from, ok := cidrutil.Containing(result, prior.FromCidrs); ok {
+				data.FromCidr = types.StringValue(from)
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// stringListValue converts a decoded JSON list of strings to a list value, keeping a JSON null as a null list.
+func stringListValue(values []string) types.List {
+	if values == nil {
+		return types.ListNull(types.StringType)
+	}
+
+	elements := make([]attr.Value, len(values))
+	for i, value := range values {
+		elements[i] = types.StringValue(value)
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAvailableCidrResourceUpgradeStateV0(t *testing.T) {
+	type expected struct {
+		strategy       string
+		netmask        string
+		prefixLength   int64
+		networkAddress string
+		broadcast      string
+		hostCount      int64
+		keepers        map[string]string
+		results        []string
+		usedCidrsNext  []string
+	}
+
+	cases := []struct {
+		name     string
+		rawState string
+		want     expected
+	}{
+		{
+			name:     "original schema",
+			rawState: `{"id":"10.1.1.0/24","keepers":null,"from_cidrs":["10.1.0.0/16"],"used_cidrs":["10.1.0.0/24"],"mask":24,"result":"10.1.1.0/24"}`,
+			want: expected{
+				strategy:       strategyFirstFit,
+				netmask:        "255.255.255.0",
+				prefixLength:   24,
+				networkAddress: "10.1.1.0",
+				broadcast:      "10.1.1.255",
+				hostCount:      256,
+				results:        []string{"10.1.1.0/24"},
+				usedCidrsNext:  []string{"10.1.0.0/24", "10.1.1.0/24"},
+			},
+		},
+		{
+			name:     "later attributes are kept",
+			rawState: `{"id":"10.1.255.0/24","keepers":{"version":"1"},"from_cidrs":["10.1.0.0/16"],"used_cidrs":[],"mask":24,"allocation_count":2,"strategy":"last_fit","result":"10.1.255.0/24","results":["10.1.255.0/24","10.1.254.0/24"],"netmask":"255.255.255.0"}`,
+			want: expected{
+				strategy:       strategyLastFit,
+				netmask:        "255.255.255.0",
+				prefixLength:   24,
+				networkAddress: "10.1.255.0",
+				broadcast:      "10.1.255.255",
+				hostCount:      256,
+				keepers:        map[string]string{"version": "1"},
+				results:        []string{"10.1.255.0/24", "10.1.254.0/24"},
+				usedCidrsNext:  []string{"10.1.255.0/24", "10.1.254.0/24"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &AvailableCidrResource{}
+
+			schemaResponse := &fwresource.SchemaResponse{}
+			r.Schema(ctx, fwresource.SchemaRequest{}, schemaResponse)
+			if schemaResponse.Schema.Version != 1 {
+				t.Fatalf("expected schema version 1, got %d", schemaResponse.Schema.Version)
+			}
+
+			upgrader, ok := r.UpgradeState(ctx)[0]
+			if !ok {
+				t.Fatal("missing upgrader for version 0")
+			}
+
+			req := fwresource.UpgradeStateRequest{
+				RawState: &tfprotov6.RawState{JSON: []byte(tc.rawState)},
+			}
+			resp := &fwresource.UpgradeStateResponse{
+				State: tfsdk.State{
+					Schema: schemaResponse.Schema,
+					Raw:    tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil),
+				},
+			}
+			upgrader.StateUpgrader(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("upgrade diagnostics: %+v", resp.Diagnostics)
+			}
+
+			var data AvailableCidrResourceModel
+			if diags := resp.State.Get(ctx, &data); diags.HasError() {
+				t.Fatalf("state diagnostics: %+v", diags)
+			}
+
+			if got := data.Strategy.ValueString(); got != tc.want.strategy {
+				t.Errorf("strategy: expected %s, got %s", tc.want.strategy, got)
+			}
+			if got := data.Netmask.ValueString(); got != tc.want.netmask {
+				t.Errorf("netmask: expected %s, got %s", tc.want.netmask, got)
+			}
+			if got := data.PrefixLength.ValueInt64(); got != tc.want.prefixLength {
+				t.Errorf("prefix_length: expected %d, got %d", tc.want.prefixLength, got)
+			}
+			if got := data.NetworkAddress.ValueString(); got != tc.want.networkAddress {
+				t.Errorf("network_address: expected %s, got %s", tc.want.networkAddress, got)
+			}
+			if got := data.BroadcastAddress.ValueString(); got != tc.want.broadcast {
+				t.Errorf("broadcast_address: expected %s, got %s", tc.want.broadcast, got)
+			}
+			if got := data.HostCount.ValueInt64(); got != tc.want.hostCount {
+				t.Errorf("host_count: expected %d, got %d", tc.want.hostCount, got)
+			}
+			if data.AllocationCount.IsNull() || data.ExcludeFirst.IsNull() || data.ExcludeLast.IsNull() || data.ReplaceOnChange.IsNull() || data.DedupeFromCidrs.IsNull() {
+				t.Errorf("expected defaulted attributes to be set, got %+v", data)
+			}
+
+			var keepers map[string]string
+			if !data.Keepers.IsNull() {
+				data.Keepers.ElementsAs(ctx, &keepers, false)
+			}
+			if len(keepers) != len(tc.want.keepers) {
+				t.Errorf("keepers: expected %v, got %v", tc.want.keepers, keepers)
+			}
+			for k, v := range tc.want.keepers {
+				if keepers[k] != v {
+					t.Errorf("keepers: expected %v, got %v", tc.want.keepers, keepers)
+				}
+			}
+
+			var results []string
+			data.Results.ElementsAs(ctx, &results, false)
+			if len(results) != len(tc.want.results) {
+				t.Fatalf("results: expected %v, got %v", tc.want.results, results)
+			}
+			for i := range results {
+				if results[i] != tc.want.results[i] {
+					t.Errorf("results: expected %v, got %v", tc.want.results, results)
+				}
+			}
+
+			var usedCidrsNext []string
+			data.UsedCidrsNext.ElementsAs(ctx, &usedCidrsNext, false)
+			if len(usedCidrsNext) != len(tc.want.usedCidrsNext) {
+				t.Fatalf("used_cidrs_next: expected %v, got %v", tc.want.usedCidrsNext, usedCidrsNext)
+			}
+			for i := range usedCidrsNext {
+				if usedCidrsNext[i] != tc.want.usedCidrsNext[i] {
+					t.Errorf("used_cidrs_next: expected %v, got %v", tc.want.usedCidrsNext, usedCidrsNext)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/intrange"
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/planmodifiers"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &AvailableIntegerResource{}
+var _ resource.ResourceWithConfigure = &AvailableIntegerResource{}
+var _ resource.ResourceWithImportState = &AvailableIntegerResource{}
+var _ resource.ResourceWithValidateConfig = &AvailableIntegerResource{}
+
+func NewAvailableIntegerResource() resource.Resource {
+	return &AvailableIntegerResource{}
+}
+
+// AvailableIntegerResource defines the resource implementation.
+type AvailableIntegerResource struct {
+	// deterministicSeed is the provider's deterministic_seed, which replaces the keepers as the seed of the random
+	// strategy when it is set.
+	deterministicSeed *int64
+}
+
+// AvailableIntegerResourceModel describes the resource data model.
+type AvailableIntegerResourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	Keepers  types.Map    `tfsdk:"keepers"`
+	Min      types.Int64  `tfsdk:"min"`
+	Max      types.Int64  `tfsdk:"max"`
+	Step     types.Int64  `tfsdk:"step"`
+	Used     types.List   `tfsdk:"used"`
+	Strategy types.String `tfsdk:"strategy"`
+	Result   types.Int64  `tfsdk:"result"`
+}
+
+func (r *AvailableIntegerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_available_integer"
+}
+
+func (r *AvailableIntegerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Given a range of integers to search over and a list of already used integers, find an unused integer.\n\n" +
+			"The `result` is kept stable once it is allocated, so changing the inputs used to find it has no effect. To " +
+			"conditionally allocate a new integer, change the `keepers`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Integer Identifier. The value will be identical to the `result` field.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"min": schema.Int64Attribute{
+				MarkdownDescription: "The lowest integer that may be allocated. Changing this value after creation **HAS NO EFFECT**.",
+				Required:            true,
+			},
+			"max": schema.Int64Attribute{
+				MarkdownDescription: "The highest integer that may be allocated. Changing this value after creation **HAS NO EFFECT**.",
+				Required:            true,
+			},
+			"step": schema.Int64Attribute{
+				MarkdownDescription: "The distance between the integers that may be allocated, starting from `min` (ex. a `min` of `0` and a `step` of `10` allocates `0`, `10`, `20`, ...). Defaults to `1`. Changing this value after creation **HAS NO EFFECT**.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"used": schema.ListAttribute{
+				MarkdownDescription: "A list containing the integers that are already used which should be avoided to prevent collisions. Values that aren't between `min` and `max` or aren't a whole number of `step`s from `min` can never be allocated, so they are ignored. Changing this value after creation **HAS NO EFFECT**.",
+				ElementType:         types.Int64Type,
+				Required:            true,
+			},
+			"strategy": schema.StringAttribute{
+				MarkdownDescription: "Allocation strategy used to pick among the available integers. `first_fit` (default) returns the lowest available integer, `last_fit` returns the highest and `random` returns a random available integer. The `random` strategy is seeded from the `keepers`, so the same `keepers` always produce the same `result` and changing them moves the allocation, unless the provider sets `deterministic_seed`. Changing this value after creation **HAS NO EFFECT**.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(strategyFirstFit),
+				Validators: []validator.String{
+					stringvalidator.OneOf(strategyFirstFit, strategyLastFit, strategyRandom),
+				},
+			},
+			"keepers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, will trigger re-creation of resource. This field works the same as the `keepers` field in the [`Random` provider](https://registry.terraform.io/providers/hashicorp/random/latest/docs#resource-keepers). Numbers and bools are converted to strings by Terraform (ex. a `version = 3` counter is stored as `\"3\"`), so they don't need `tostring()`. Adding, removing or changing any key, including setting its value to `null`, triggers re-creation.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					planmodifiers.RequiresReplaceIfMapChanged(),
+				},
+			},
+			"result": schema.Int64Attribute{
+				MarkdownDescription: "The available integer that was found.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig catches a min that is greater than max during plan, rather than waiting for the allocation to
+// fail during apply.
+func (r *AvailableIntegerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var min, max, step types.Int64
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("min"), &min)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("max"), &max)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("step"), &step)...)
+	if resp.Diagnostics.HasError() || min.IsNull() || min.IsUnknown() || max.IsNull() || max.IsUnknown() || step.IsUnknown() {
+		return
+	}
+
+	// An unset step uses the default, and steps less than 1 are already reported by the attribute validator.
+	stepValue := int64(1)
+	if !step.IsNull() {
+		stepValue = step.ValueInt64()
+	}
+	if stepValue < 1 {
+		return
+	}
+
+	if _, err := intrange.NewGrid(min.ValueInt64(), max.ValueInt64(), stepValue); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max"),
+			"Invalid range",
+			err.Error(),
+		)
+	}
+}
+
+func (r *AvailableIntegerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*UtilityProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *UtilityProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.deterministicSeed = providerData.DeterministicSeed
+}
+
+func (r *AvailableIntegerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AvailableIntegerResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grid, err := intrange.NewGrid(data.Min.ValueInt64(), data.Max.ValueInt64(), data.Step.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid range",
+			err.Error(),
+		)
+		return
+	}
+
+	used := make([]int64, len(data.Used.Elements()))
+	resp.Diagnostics.Append(data.Used.ElementsAs(ctx, &used, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result int64
+	var ok bool
+	switch data.Strategy.ValueString() {
+	case strategyLastFit:
+		result, ok = grid.FindLast(used)
+	case strategyRandom:
+		result, ok = grid.FindRandom(used, rand.New(rand.NewSource(randomSeed(r.deterministicSeed, data.Keepers, data.Id))))
+	default:
+		result, ok = grid.FindFirst(used)
+	}
+	if !ok {
+		resp.Diagnostics.AddError(
+			"No available integer found",
+			fmt.Sprintf("All %d integers from %d to %d with a step of %d are used", grid.Count(), grid.Range.First, grid.Range.Last, grid.Step),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(strconv.FormatInt(result, 10))
+	data.Result = types.Int64Value(result)
+
+	tflog.Trace(ctx, "found available integer: "+data.Id.ValueString())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AvailableIntegerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AvailableIntegerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update ensures the plan value is copied to the state to complete the update.
+func (r *AvailableIntegerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AvailableIntegerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete does not need to explicitly call resp.State.RemoveResource() as this is automatically handled by the
+// [framework](https://github.com/hashicorp/terraform-plugin-framework/pull/301).
+func (r *AvailableIntegerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *AvailableIntegerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	value, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Malformed resource ID (integer)",
+			fmt.Sprintf("The ID that was given must be an integer: %s", err.Error()),
+		)
+		return
+	}
+
+	state := AvailableIntegerResourceModel{
+		Min:      types.Int64Null(),
+		Max:      types.Int64Null(),
+		Step:     types.Int64Value(1),
+		Used:     types.ListNull(types.Int64Type),
+		Strategy: types.StringValue(strategyFirstFit),
+		Keepers:  types.MapNull(types.StringType),
+		Id:       types.StringValue(strconv.FormatInt(value, 10)),
+		Result:   types.Int64Value(value),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
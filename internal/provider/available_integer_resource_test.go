@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAvailableIntegerResourceSchema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	NewAvailableIntegerResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	if diagnostics := schemaResponse.Schema.ValidateImplementation(ctx); diagnostics.HasError() {
+		t.Fatalf("schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestAccAvailableIntegerResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAvailableIntegerResourceConfig(100, 4000, 10, "[100, 110, 115, 130]", "first_fit"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_integer.test", "result", "120"),
+					resource.TestCheckResourceAttr("utility_available_integer.test", "id", "120"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "utility_available_integer.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"min", "max", "step", "used"},
+			},
+			// Changing the inputs keeps the result stable
+			{
+				Config: testAccAvailableIntegerResourceConfig(0, 10, 1, "[]", "first_fit"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_integer.test", "result", "120"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAvailableIntegerResourceLastFit(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAvailableIntegerResourceConfig(0, 25, 10, "[20]", "last_fit"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_integer.test", "result", "10"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAvailableIntegerResourceInvalidRange(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAvailableIntegerResourceConfig(10, 1, 1, "[]", "first_fit"),
+				ExpectError: regexp.MustCompile("Invalid range"),
+			},
+		},
+	})
+}
+
+func TestAccAvailableIntegerResourceExhausted(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAvailableIntegerResourceConfig(0, 20, 10, "[0, 10, 20]", "random"),
+				ExpectError: regexp.MustCompile("No available integer found"),
+			},
+		},
+	})
+}
+
+func testAccAvailableIntegerResourceConfig(min int, max int, step int, used string, strategy string) string {
+	return fmt.Sprintf(`
+resource "utility_available_integer" "test" {
+  min      = %d
+  max      = %d
+  step     = %d
+  used     = %s
+  strategy = %q
+}
+`, min, max, step, used, strategy)
+}
@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/intrange"
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/planmodifiers"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &AvailablePortResource{}
+var _ resource.ResourceWithConfigure = &AvailablePortResource{}
+var _ resource.ResourceWithImportState = &AvailablePortResource{}
+var _ resource.ResourceWithValidateConfig = &AvailablePortResource{}
+
+// The range of valid port numbers. Port 0 is excluded since it asks the operating system to pick a port.
+const (
+	minPort = 1
+	maxPort = 65535
+)
+
+func NewAvailablePortResource() resource.Resource {
+	return &AvailablePortResource{}
+}
+
+// AvailablePortResource defines the resource implementation.
+type AvailablePortResource struct{}
+
+// AvailablePortResourceModel describes the resource data model.
+type AvailablePortResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Keepers   types.Map    `tfsdk:"keepers"`
+	FromRange types.String `tfsdk:"from_range"`
+	UsedPorts types.List   `tfsdk:"used_ports"`
+	Result    types.Int64  `tfsdk:"result"`
+}
+
+func (r *AvailablePortResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_available_port"
+}
+
+func (r *AvailablePortResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Given a range of ports to search over and a list of already used ports, find an unused port.\n\n" +
+			"The `result` is kept stable once it is allocated, since it is typically used to configure a service, so changing " +
+			"the inputs used to find it has no effect. To conditionally allocate a new port, change the `keepers`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Port Identifier. The value will be identical to the `result` field.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"from_range": schema.StringAttribute{
+				MarkdownDescription: "The inclusive range of ports from which to search for an available port, in the form `<first>-<last>` (ex. `30000-32767`). Changing this value after creation **HAS NO EFFECT**.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(rangeRegex, "Must be a port range in the form <first>-<last>"),
+				},
+			},
+			"used_ports": schema.ListAttribute{
+				MarkdownDescription: "A list containing the ports that are already used within the `from_range` which should be avoided to prevent collisions. Changing this value after creation **HAS NO EFFECT**.",
+				ElementType:         types.Int64Type,
+				Validators: []validator.List{
+					listvalidator.ValueInt64sAre(int64validator.Between(minPort, maxPort)),
+				},
+				Required: true,
+			},
+			"keepers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, will trigger re-creation of resource. This field works the same as the `keepers` field in the [`Random` provider](https://registry.terraform.io/providers/hashicorp/random/latest/docs#resource-keepers). Numbers and bools are converted to strings by Terraform (ex. a `version = 3` counter is stored as `\"3\"`), so they don't need `tostring()`. Adding, removing or changing any key, including setting its value to `null`, triggers re-creation.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					planmodifiers.RequiresReplaceIfMapChanged(),
+				},
+			},
+			"result": schema.Int64Attribute{
+				MarkdownDescription: "The available port that was found.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig catches a from_range that is out of order or outside of the valid port numbers during plan,
+// rather than waiting for the allocation to fail during apply.
+func (r *AvailablePortResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var fromRange types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("from_range"), &fromRange)...)
+	if resp.Diagnostics.HasError() || fromRange.IsNull() || fromRange.IsUnknown() {
+		return
+	}
+
+	// Malformed ranges are already reported by the attribute validator.
+	if !rangeRegex.MatchString(fromRange.ValueString()) {
+		return
+	}
+
+	if _, err := intrange.Parse(fromRange.ValueString(), minPort, maxPort); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("from_range"),
+			"Invalid from_range",
+			err.Error(),
+		)
+	}
+}
+
+func (r *AvailablePortResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (r *AvailablePortResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AvailablePortResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fromRange, err := intrange.Parse(data.FromRange.ValueString(), minPort, maxPort)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing from_range",
+			err.Error(),
+		)
+		return
+	}
+
+	usedPorts := make([]int64, len(data.UsedPorts.Elements()))
+	resp.Diagnostics.Append(data.UsedPorts.ElementsAs(ctx, &usedPorts, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, ok := intrange.FindFirst([]intrange.Range{fromRange}, usedPorts)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"No available port found",
+			fmt.Sprintf("All %d ports in %s are used", fromRange.Size(), fromRange),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(strconv.FormatInt(result, 10))
+	data.Result = types.Int64Value(result)
+
+	tflog.Trace(ctx, "found available port: "+data.Id.ValueString())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AvailablePortResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AvailablePortResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update ensures the plan value is copied to the state to complete the update.
+func (r *AvailablePortResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AvailablePortResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete does not need to explicitly call resp.State.RemoveResource() as this is automatically handled by the
+// [framework](https://github.com/hashicorp/terraform-plugin-framework/pull/301).
+func (r *AvailablePortResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *AvailablePortResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	port, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil || port < minPort || port > maxPort {
+		resp.Diagnostics.AddError(
+			"Malformed resource ID (port)",
+			fmt.Sprintf("The ID that was given must be a port number between %d and %d", minPort, maxPort),
+		)
+		return
+	}
+
+	state := AvailablePortResourceModel{
+		FromRange: types.StringNull(),
+		UsedPorts: types.ListNull(types.Int64Type),
+		Keepers:   types.MapNull(types.StringType),
+		Id:        types.StringValue(strconv.FormatInt(port, 10)),
+		Result:    types.Int64Value(port),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
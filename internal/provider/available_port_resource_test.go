@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAvailablePortResourceSchema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	NewAvailablePortResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	if diagnostics := schemaResponse.Schema.ValidateImplementation(ctx); diagnostics.HasError() {
+		t.Fatalf("schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestAccAvailablePortResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAvailablePortResourceConfig("30000-32767", "[30000, 30001, 30003]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_port.test", "result", "30002"),
+					resource.TestCheckResourceAttr("utility_available_port.test", "id", "30002"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "utility_available_port.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"from_range", "used_ports"},
+			},
+			// Changing the inputs keeps the result stable
+			{
+				Config: testAccAvailablePortResourceConfig("31000-32767", "[30002]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_port.test", "result", "30002"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAvailablePortResourceExhausted(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAvailablePortResourceConfig("8080-8081", "[8080, 8081]"),
+				ExpectError: regexp.MustCompile("No available port found"),
+			},
+		},
+	})
+}
+
+func TestAccAvailablePortResourceInvalidRange(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAvailablePortResourceConfig("8081-8080", "[]"),
+				ExpectError: regexp.MustCompile("Invalid from_range"),
+			},
+			{
+				Config:      testAccAvailablePortResourceConfig("30000-70000", "[]"),
+				ExpectError: regexp.MustCompile("Invalid from_range"),
+			},
+			{
+				Config:      testAccAvailablePortResourceConfig("30000", "[]"),
+				ExpectError: regexp.MustCompile("Must be a port range"),
+			},
+		},
+	})
+}
+
+func testAccAvailablePortResourceConfig(fromRange string, usedPorts string) string {
+	return fmt.Sprintf(`
+resource "utility_available_port" "test" {
+  from_range = %q
+  used_ports = %s
+}
+`, fromRange, usedPorts)
+}
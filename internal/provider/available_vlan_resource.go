@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/intrange"
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/planmodifiers"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &AvailableVlanResource{}
+var _ resource.ResourceWithConfigure = &AvailableVlanResource{}
+var _ resource.ResourceWithImportState = &AvailableVlanResource{}
+var _ resource.ResourceWithValidateConfig = &AvailableVlanResource{}
+
+// 802.1Q reserves VLAN IDs 0 and 4095, and VLAN 1 is the default VLAN on most switches, so only 2-4094 are
+// allocated unless allow_reserved is set.
+const (
+	minVlan         = 2
+	maxVlan         = 4094
+	minReservedVlan = 0
+	maxReservedVlan = 4095
+)
+
+func NewAvailableVlanResource() resource.Resource {
+	return &AvailableVlanResource{}
+}
+
+// AvailableVlanResource defines the resource implementation.
+type AvailableVlanResource struct{}
+
+// AvailableVlanResourceModel describes the resource data model.
+type AvailableVlanResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	Keepers       types.Map    `tfsdk:"keepers"`
+	FromRange     types.String `tfsdk:"from_range"`
+	UsedVlans     types.List   `tfsdk:"used_vlans"`
+	AllowReserved types.Bool   `tfsdk:"allow_reserved"`
+	Result        types.Int64  `tfsdk:"result"`
+}
+
+func (r *AvailableVlanResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_available_vlan"
+}
+
+func (r *AvailableVlanResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Given a range of VLAN IDs to search over and a list of already used VLAN IDs, find an unused VLAN ID.\n\n" +
+			"The `result` is kept stable once it is allocated, since it is typically used to configure a network, so changing " +
+			"the inputs used to find it has no effect. To conditionally allocate a new VLAN ID, change the `keepers`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "VLAN Identifier. The value will be identical to the `result` field.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"from_range": schema.StringAttribute{
+				MarkdownDescription: "The inclusive range of VLAN IDs from which to search for an available VLAN ID, in the form `<first>-<last>`. Defaults to `2-4094`. Changing this value after creation **HAS NO EFFECT**.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(fmt.Sprintf("%d-%d", minVlan, maxVlan)),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(rangeRegex, "Must be a VLAN range in the form <first>-<last>"),
+				},
+			},
+			"used_vlans": schema.ListAttribute{
+				MarkdownDescription: "A list containing the VLAN IDs that are already used within the `from_range` which should be avoided to prevent collisions. Changing this value after creation **HAS NO EFFECT**.",
+				ElementType:         types.Int64Type,
+				Validators: []validator.List{
+					listvalidator.ValueInt64sAre(int64validator.Between(minReservedVlan, maxReservedVlan)),
+				},
+				Required: true,
+			},
+			"allow_reserved": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, the `from_range` may include the VLAN IDs that are reserved by 802.1Q (`0` and `4095`) and the default VLAN (`1`). Defaults to `false`. Changing this value after creation **HAS NO EFFECT**.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"keepers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, will trigger re-creation of resource. This field works the same as the `keepers` field in the [`Random` provider](https://registry.terraform.io/providers/hashicorp/random/latest/docs#resource-keepers). Numbers and bools are converted to strings by Terraform (ex. a `version = 3` counter is stored as `\"3\"`), so they don't need `tostring()`. Adding, removing or changing any key, including setting its value to `null`, triggers re-creation.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					planmodifiers.RequiresReplaceIfMapChanged(),
+				},
+			},
+			"result": schema.Int64Attribute{
+				MarkdownDescription: "The available VLAN ID that was found.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig catches a from_range that is out of order or includes reserved VLAN IDs during plan, rather than
+// waiting for the allocation to fail during apply.
+func (r *AvailableVlanResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var fromRange types.String
+	var allowReserved types.Bool
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("from_range"), &fromRange)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("allow_reserved"), &allowReserved)...)
+	if resp.Diagnostics.HasError() || fromRange.IsNull() || fromRange.IsUnknown() || allowReserved.IsUnknown() {
+		return
+	}
+
+	// Malformed ranges are already reported by the attribute validator.
+	if !rangeRegex.MatchString(fromRange.ValueString()) {
+		return
+	}
+
+	min, max := vlanBounds(allowReserved.ValueBool())
+	if _, err := intrange.Parse(fromRange.ValueString(), min, max); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("from_range"),
+			"Invalid from_range",
+			err.Error(),
+		)
+	}
+}
+
+func (r *AvailableVlanResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (r *AvailableVlanResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AvailableVlanResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	min, max := vlanBounds(data.AllowReserved.ValueBool())
+	fromRange, err := intrange.Parse(data.FromRange.ValueString(), min, max)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing from_range",
+			err.Error(),
+		)
+		return
+	}
+
+	usedVlans := make([]int64, len(data.UsedVlans.Elements()))
+	resp.Diagnostics.Append(data.UsedVlans.ElementsAs(ctx, &usedVlans, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, ok := intrange.FindFirst([]intrange.Range{fromRange}, usedVlans)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"No available VLAN found",
+			fmt.Sprintf("All %d VLAN IDs in %s are used", fromRange.Size(), fromRange),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(strconv.FormatInt(result, 10))
+	data.Result = types.Int64Value(result)
+
+	tflog.Trace(ctx, "found available vlan: "+data.Id.ValueString())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AvailableVlanResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AvailableVlanResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update ensures the plan value is copied to the state to complete the update.
+func (r *AvailableVlanResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AvailableVlanResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete does not need to explicitly call resp.State.RemoveResource() as this is automatically handled by the
+// [framework](https://github.com/hashicorp/terraform-plugin-framework/pull/301).
+func (r *AvailableVlanResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// ImportState accepts any VLAN ID, including reserved ones, since the VLAN may have been allocated with
+// allow_reserved set.
+func (r *AvailableVlanResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	vlan, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil || vlan < minReservedVlan || vlan > maxReservedVlan {
+		resp.Diagnostics.AddError(
+			"Malformed resource ID (VLAN)",
+			fmt.Sprintf("The ID that was given must be a VLAN ID between %d and %d", minReservedVlan, maxReservedVlan),
+		)
+		return
+	}
+
+	min, max := vlanBounds(false)
+	state := AvailableVlanResourceModel{
+		FromRange:     types.StringValue(fmt.Sprintf("%d-%d", min, max)),
+		UsedVlans:     types.ListNull(types.Int64Type),
+		AllowReserved: types.BoolValue(false),
+		Keepers:       types.MapNull(types.StringType),
+		Id:            types.StringValue(strconv.FormatInt(vlan, 10)),
+		Result:        types.Int64Value(vlan),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// vlanBounds returns the lowest and highest VLAN IDs that may be allocated.
+func vlanBounds(allowReserved bool) (int64, int64) {
+	if allowReserved {
+		return minReservedVlan, maxReservedVlan
+	}
+	return minVlan, maxVlan
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAvailableVlanResourceSchema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	NewAvailableVlanResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	if diagnostics := schemaResponse.Schema.ValidateImplementation(ctx); diagnostics.HasError() {
+		t.Fatalf("schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestAccAvailableVlanResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "utility_available_vlan" "test" {
+  used_vlans = [2, 3, 5]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_vlan.test", "result", "4"),
+					resource.TestCheckResourceAttr("utility_available_vlan.test", "id", "4"),
+					resource.TestCheckResourceAttr("utility_available_vlan.test", "from_range", "2-4094"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "utility_available_vlan.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"used_vlans"},
+			},
+			// Changing the inputs keeps the result stable
+			{
+				Config: testAccAvailableVlanResourceConfig("100-199", "[4]", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_vlan.test", "result", "4"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAvailableVlanResourceReserved(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAvailableVlanResourceConfig("1-10", "[]", false),
+				ExpectError: regexp.MustCompile("Invalid from_range"),
+			},
+			{
+				Config:      testAccAvailableVlanResourceConfig("4000-4095", "[]", false),
+				ExpectError: regexp.MustCompile("Invalid from_range"),
+			},
+			{
+				Config: testAccAvailableVlanResourceConfig("0-10", "[]", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_available_vlan.test", "result", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAvailableVlanResourceExhausted(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAvailableVlanResourceConfig("100-101", "[100, 101]", false),
+				ExpectError: regexp.MustCompile("No available VLAN found"),
+			},
+		},
+	})
+}
+
+func testAccAvailableVlanResourceConfig(fromRange string, usedVlans string, allowReserved bool) string {
+	return fmt.Sprintf(`
+resource "utility_available_vlan" "test" {
+  from_range     = %q
+  used_vlans     = %s
+  allow_reserved = %v
+}
+`, fromRange, usedVlans, allowReserved)
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &CidrAggregateDataSource{}
+
+func NewCidrAggregateDataSource() datasource.DataSource {
+	return &CidrAggregateDataSource{}
+}
+
+// CidrAggregateDataSource defines the data source implementation.
+type CidrAggregateDataSource struct{}
+
+// CidrAggregateDataSourceModel describes the data source data model.
+type CidrAggregateDataSourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	Cidrs      types.List   `tfsdk:"cidrs"`
+	Aggregated types.List   `tfsdk:"aggregated"`
+}
+
+func (d *CidrAggregateDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cidr_aggregate"
+}
+
+func (d *CidrAggregateDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Summarizes a list of CIDR ranges into the smallest list of CIDR ranges that covers exactly the same addresses.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier. The value will be the `aggregated` values separated by commas.",
+			},
+			"cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing the CIDR ranges to aggregate. IPv4 and IPv6 ranges may be mixed, and are aggregated separately.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				Required: true,
+			},
+			"aggregated": schema.ListAttribute{
+				MarkdownDescription: "The aggregated CIDR ranges. Overlapping ranges are combined and adjacent ranges are merged into their parent range wherever possible. IPv4 ranges are listed before IPv6 ranges, each in ascending order.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *CidrAggregateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CidrAggregateDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cidrs, diags := parseCidrs(ctx, data.Cidrs, "cidrs")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	aggregated := cidrutil.Strings(cidrutil.Aggregate(cidrs))
+
+	aggregatedList, diags := types.ListValueFrom(ctx, types.StringType, aggregated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(strings.Join(aggregated, ","))
+	data.Aggregated = aggregatedList
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCidrAggregateDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCidrAggregateDataSourceConfig(`"10.0.1.0/24", "10.0.0.0/24", "10.0.2.0/23", "10.0.8.0/24"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_cidr_aggregate.test", "aggregated.#", "2"),
+					resource.TestCheckResourceAttr("data.utility_cidr_aggregate.test", "aggregated.0", "10.0.0.0/22"),
+					resource.TestCheckResourceAttr("data.utility_cidr_aggregate.test", "aggregated.1", "10.0.8.0/24"),
+				),
+			},
+			{
+				Config: testAccCidrAggregateDataSourceConfig(`"fd00::/64", "10.0.0.0/25", "fd00:0:0:1::/64", "10.0.0.128/25"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_cidr_aggregate.test", "aggregated.#", "2"),
+					resource.TestCheckResourceAttr("data.utility_cidr_aggregate.test", "aggregated.0", "10.0.0.0/24"),
+					resource.TestCheckResourceAttr("data.utility_cidr_aggregate.test", "aggregated.1", "fd00::/63"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCidrAggregateDataSourceConfig(cidrs string) string {
+	return `
+data "utility_cidr_aggregate" "test" {
+  cidrs = [` + cidrs + `]
+}
+`
+}
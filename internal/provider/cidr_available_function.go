@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &CidrAvailableFunction{}
+
+func NewCidrAvailableFunction() function.Function {
+	return &CidrAvailableFunction{}
+}
+
+// CidrAvailableFunction defines the function implementation.
+type CidrAvailableFunction struct{}
+
+func (f *CidrAvailableFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_available"
+}
+
+func (f *CidrAvailableFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Find an unused CIDR range of a given size",
+		MarkdownDescription: "Given CIDR range(s) to search over (ex. a Network) and a list of already used CIDR ranges (ex. a list of subnets) " +
+			"find the lowest unused, non-conflicting CIDR range of specified size.\n\n" +
+			"Like the `utility_available_cidr` data source, the result is recomputed on every plan and **WILL CHANGE** whenever the arguments change. " +
+			"Use the `utility_available_cidr` resource instead if the result is used to create a network/subnet and must remain stable.",
+
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "from_cidrs",
+				MarkdownDescription: "A list containing the CIDR range(s) from which to search for available CIDR ranges.",
+				ElementType:         types.StringType,
+			},
+			function.ListParameter{
+				Name:                "used_cidrs",
+				MarkdownDescription: "A list containing the CIDR ranges that are already used within the `from_cidrs` block(s) which should be avoided to prevent overlaps and/or collisions.",
+				ElementType:         types.StringType,
+			},
+			function.Int64Parameter{
+				Name:                "mask",
+				MarkdownDescription: "Desired mask (network/subnet size) to find that is available.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CidrAvailableFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var fromCidrsStrings []string
+	var usedCidrsStrings []string
+	var prefixLength int64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &fromCidrsStrings, &usedCidrsStrings, &prefixLength))
+	if resp.Error != nil {
+		return
+	}
+
+	if len(fromCidrsStrings) == 0 {
+		resp.Error = function.NewArgumentFuncError(0, "from_cidrs must contain at least one CIDR")
+		return
+	}
+
+	fromCidrs, funcErr := parseCidrArguments(fromCidrsStrings, 0)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	usedCidrs, funcErr := parseCidrArguments(usedCidrsStrings, 1)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	if err := checkSameFamily(fromCidrs, "from_cidrs"); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	addrBits := cidrutil.AddressBits(fromCidrs[0])
+
+	if prefixLength < 0 || prefixLength > int64(addrBits) {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("mask must be between 0 and %d, got %d", addrBits, prefixLength))
+		return
+	}
+
+	mask := net.CIDRMask(int(prefixLength), addrBits)
+
+	result, err := allocate(strategyFirstFit, nil, fromCidrs, &mask, &mask, cidrutil.Normalize(usedCidrs))
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("%s: %s", allocationErrorSummary(err), err.Error()))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result.String()))
+}
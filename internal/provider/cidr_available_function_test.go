@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCidrAvailableFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::utility::cidr_available(["10.1.0.0/16"], ["10.1.0.0/24"], 24)
+}
+`,
+				Check: resource.TestCheckOutput("test", "10.1.1.0/24"),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::utility::cidr_available(["fd00::/56"], ["fd00::/64"], 64)
+}
+`,
+				Check: resource.TestCheckOutput("test", "fd00:0:0:1::/64"),
+			},
+		},
+	})
+}
+
+func TestAccCidrAvailableFunctionNoneAvailable(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::utility::cidr_available(["10.1.0.0/24"], ["10.1.0.0/24"], 26)
+}
+`,
+				ExpectError: regexp.MustCompile(`No\s+available\s+CIDR\s+found`),
+			},
+		},
+	})
+}
+
+func TestAccCidrAvailableFunctionInvalidMask(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::utility::cidr_available(["10.1.0.0/24"], [], 16)
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid\s+mask\s+for\s+from_cidrs`),
+			},
+		},
+	})
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &CidrCommonSupernetFunction{}
+
+func NewCidrCommonSupernetFunction() function.Function {
+	return &CidrCommonSupernetFunction{}
+}
+
+// CidrCommonSupernetFunction defines the function implementation.
+type CidrCommonSupernetFunction struct{}
+
+func (f *CidrCommonSupernetFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_common_supernet"
+}
+
+func (f *CidrCommonSupernetFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Find the smallest CIDR range containing two CIDR ranges",
+		MarkdownDescription: "Returns the smallest CIDR range that contains both `a` and `b`, for computing an aggregate route. The prefix is the longest common prefix of the two network addresses, so the supernet may contain addresses outside of `a` and `b` (ex. `10.0.0.0/24` and `10.0.2.0/24` give `10.0.0.0/22`). Both ranges must be of the same address family.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "a",
+				MarkdownDescription: "The first CIDR range (ex. `10.0.0.0/24`).",
+			},
+			function.StringParameter{
+				Name:                "b",
+				MarkdownDescription: "The second CIDR range (ex. `10.0.1.0/24`).",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CidrCommonSupernetFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var aString string
+	var bString string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &aString, &bString))
+	if resp.Error != nil {
+		return
+	}
+
+	_, a, err := net.ParseCIDR(aString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	_, b, err := net.ParseCIDR(bString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	supernet, err := cidrutil.CommonSupernet(a, b)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, supernet.String()))
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &CidrContainsDataSource{}
+
+func NewCidrContainsDataSource() datasource.DataSource {
+	return &CidrContainsDataSource{}
+}
+
+// CidrContainsDataSource defines the data source implementation.
+type CidrContainsDataSource struct{}
+
+// CidrContainsDataSourceModel describes the data source data model.
+type CidrContainsDataSourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Outer     types.String `tfsdk:"outer"`
+	Inner     types.String `tfsdk:"inner"`
+	Contained types.Bool   `tfsdk:"contained"`
+}
+
+func (d *CidrContainsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cidr_contains"
+}
+
+func (d *CidrContainsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Checks whether a CIDR range or IP address lies entirely within another CIDR range.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier. The value will be the `outer` and `inner` values separated by a comma.",
+			},
+			"outer": schema.StringAttribute{
+				MarkdownDescription: "The CIDR range to check against (ex. `10.0.0.0/8`).",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation"),
+				},
+				Required: true,
+			},
+			"inner": schema.StringAttribute{
+				MarkdownDescription: "The CIDR range (ex. `10.1.2.0/24`) or bare IP address (ex. `10.1.2.3`) to look for within `outer`.",
+				Required:            true,
+			},
+			"contained": schema.BoolAttribute{
+				MarkdownDescription: "Whether every address in `inner` is within `outer`. This is always `false` when `outer` and `inner` are of different address families.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CidrContainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CidrContainsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outer, err := cidrutil.ParseCIDROrIP(data.Outer.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("outer"),
+			"Error parsing outer",
+			err.Error(),
+		)
+		return
+	}
+
+	inner, err := cidrutil.ParseCIDROrIP(data.Inner.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("inner"),
+			"Error parsing inner",
+			err.Error(),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(data.Outer.ValueString() + "," + data.Inner.ValueString())
+	data.Contained = types.BoolValue(cidrutil.Contains(outer, inner))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
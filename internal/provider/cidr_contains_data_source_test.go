@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCidrContainsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCidrContainsDataSourceConfig("10.0.0.0/8", "10.1.2.0/24"),
+				Check:  resource.TestCheckResourceAttr("data.utility_cidr_contains.test", "contained", "true"),
+			},
+			{
+				Config: testAccCidrContainsDataSourceConfig("10.0.0.0/16", "10.0.0.0/8"),
+				Check:  resource.TestCheckResourceAttr("data.utility_cidr_contains.test", "contained", "false"),
+			},
+			{
+				Config: testAccCidrContainsDataSourceConfig("10.0.0.0/16", "10.0.255.255"),
+				Check:  resource.TestCheckResourceAttr("data.utility_cidr_contains.test", "contained", "true"),
+			},
+			{
+				Config: testAccCidrContainsDataSourceConfig("10.0.0.0/16", "10.1.0.0"),
+				Check:  resource.TestCheckResourceAttr("data.utility_cidr_contains.test", "contained", "false"),
+			},
+			{
+				Config: testAccCidrContainsDataSourceConfig("fd00::/56", "fd00:0:0:1::1"),
+				Check:  resource.TestCheckResourceAttr("data.utility_cidr_contains.test", "contained", "true"),
+			},
+		},
+	})
+}
+
+func TestAccCidrContainsDataSourceInvalidInner(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCidrContainsDataSourceConfig("10.0.0.0/8", "10.1.2"),
+				ExpectError: regexp.MustCompile("Error parsing inner"),
+			},
+		},
+	})
+}
+
+func testAccCidrContainsDataSourceConfig(outer string, inner string) string {
+	return `
+data "utility_cidr_contains" "test" {
+  outer = "` + outer + `"
+  inner = "` + inner + `"
+}
+`
+}
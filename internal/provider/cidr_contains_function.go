@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &CidrContainsFunction{}
+
+func NewCidrContainsFunction() function.Function {
+	return &CidrContainsFunction{}
+}
+
+// CidrContainsFunction defines the function implementation.
+type CidrContainsFunction struct{}
+
+func (f *CidrContainsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_contains"
+}
+
+func (f *CidrContainsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Check whether a CIDR range or IP address lies within another CIDR range",
+		MarkdownDescription: "Returns `true` when every address in `inner` is within `outer`. This is always `false` when `outer` and `inner` are of different address families.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "outer",
+				MarkdownDescription: "The CIDR range to check against (ex. `10.0.0.0/8`).",
+			},
+			function.StringParameter{
+				Name:                "inner",
+				MarkdownDescription: "The CIDR range (ex. `10.1.2.0/24`) or bare IP address (ex. `10.1.2.3`) to look for within `outer`.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *CidrContainsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var outerString string
+	var innerString string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &outerString, &innerString))
+	if resp.Error != nil {
+		return
+	}
+
+	outer, err := cidrutil.ParseCIDROrIP(outerString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	inner, err := cidrutil.ParseCIDROrIP(innerString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, cidrutil.Contains(outer, inner)))
+}
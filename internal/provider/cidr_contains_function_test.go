@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCidrContainsFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "cidr" {
+  value = provider::utility::cidr_contains("10.0.0.0/8", "10.1.2.0/24")
+}
+
+output "larger" {
+  value = provider::utility::cidr_contains("10.0.0.0/16", "10.0.0.0/8")
+}
+
+output "ip" {
+  value = provider::utility::cidr_contains("10.0.0.0/16", "10.0.255.255")
+}
+
+output "families" {
+  value = provider::utility::cidr_contains("::/0", "10.0.0.0/8")
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("cidr", "true"),
+					resource.TestCheckOutput("larger", "false"),
+					resource.TestCheckOutput("ip", "true"),
+					resource.TestCheckOutput("families", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCidrContainsFunctionInvalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::utility::cidr_contains("10.0.0.0/8", "10.1.2")
+}
+`,
+				ExpectError: regexp.MustCompile(`not\s+a\s+valid\s+IP\s+address\s+or\s+CIDR`),
+			},
+		},
+	})
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &CidrDiffDataSource{}
+
+func NewCidrDiffDataSource() datasource.DataSource {
+	return &CidrDiffDataSource{}
+}
+
+// CidrDiffDataSource defines the data source implementation.
+type CidrDiffDataSource struct{}
+
+// CidrDiffDataSourceModel describes the data source data model.
+type CidrDiffDataSourceModel struct {
+	Id   types.String `tfsdk:"id"`
+	From types.String `tfsdk:"from"`
+	Used types.List   `tfsdk:"used"`
+	Free types.List   `tfsdk:"free"`
+}
+
+func (d *CidrDiffDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cidr_diff"
+}
+
+func (d *CidrDiffDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Subtracts a list of used CIDR ranges from a CIDR range, returning the remaining free space as the smallest list of CIDR ranges.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "CIDR Identifier. The value will be the network address and prefix length of `from`.",
+			},
+			"from": schema.StringAttribute{
+				MarkdownDescription: "The CIDR range to subtract the `used` CIDR ranges from (ex. a Network).",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation"),
+				},
+				Required: true,
+			},
+			"used": schema.ListAttribute{
+				MarkdownDescription: "A list containing the CIDR ranges that are already used (ex. a list of subnets). Ranges outside of `from` or of a different address family are ignored.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				Required: true,
+			},
+			"free": schema.ListAttribute{
+				MarkdownDescription: "The CIDR ranges within `from` that don't overlap any of the `used` CIDR ranges, in ascending order. Each free run of addresses is covered by the fewest, largest CIDR ranges possible.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *CidrDiffDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CidrDiffDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, from, err := net.ParseCIDR(data.From.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("from"),
+			"Error parsing from",
+			err.Error(),
+		)
+		return
+	}
+
+	used, diags := parseCidrs(ctx, data.Used, "used")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	freeList, diags := types.ListValueFrom(ctx, types.StringType, cidrutil.Strings(cidrutil.Free(from, used)))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(from.String())
+	data.Free = freeList
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
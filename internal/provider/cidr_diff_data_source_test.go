@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCidrDiffDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "utility_cidr_diff" "test" {
+  from = "10.0.0.0/22"
+  used = ["10.0.1.0/24"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_cidr_diff.test", "free.#", "2"),
+					resource.TestCheckResourceAttr("data.utility_cidr_diff.test", "free.0", "10.0.0.0/24"),
+					resource.TestCheckResourceAttr("data.utility_cidr_diff.test", "free.1", "10.0.2.0/23"),
+				),
+			},
+			{
+				Config: `
+data "utility_cidr_diff" "test" {
+  from = "10.0.0.0/24"
+  used = ["10.0.0.0/24"]
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.utility_cidr_diff.test", "free.#", "0"),
+			},
+		},
+	})
+}
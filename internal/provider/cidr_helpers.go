@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// parseCidrs parses each element of a list of CIDR strings, reporting the first element that fails to parse
+// against the named attribute.
+func parseCidrs(ctx context.Context, list types.List, attribute string) ([]*net.IPNet, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	cidrsStrings := make([]string, len(list.Elements()))
+	diags.Append(list.ElementsAs(ctx, &cidrsStrings, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	cidrs := make([]*net.IPNet, len(cidrsStrings))
+	for i, c := range cidrsStrings {
+		_, parsed, err := net.ParseCIDR(c)
+		if err != nil {
+			diags.AddError(
+				fmt.Sprintf("Error parsing %s", attribute),
+				fmt.Sprintf("Unable to parse %q: %s", c, err.Error()),
+			)
+			return nil, diags
+		}
+		cidrs[i] = parsed
+	}
+
+	return cidrs, diags
+}
+
+// parseCidrArguments parses each CIDR string passed to a function argument, reporting the first one that fails to
+// parse against the argument at the given position.
+func parseCidrArguments(cidrsStrings []string, position int64) ([]*net.IPNet, *function.FuncError) {
+	cidrs := make([]*net.IPNet, len(cidrsStrings))
+	for i, c := range cidrsStrings {
+		_, parsed, err := net.ParseCIDR(c)
+		if err != nil {
+			return nil, function.NewArgumentFuncError(position, fmt.Sprintf("Unable to parse %q: %s", c, err.Error()))
+		}
+		cidrs[i] = parsed
+	}
+	return cidrs, nil
+}
+
+// checkSameFamily returns an error naming the first of cidrs that isn't the same address family as the first one.
+func checkSameFamily(cidrs []*net.IPNet, name string) error {
+	if len(cidrs) == 0 {
+		return nil
+	}
+
+	addrBits := cidrutil.AddressBits(cidrs[0])
+	for _, cidr := range cidrs {
+		if cidrutil.AddressBits(cidr) != addrBits {
+			return fmt.Errorf("all %s must be either IPv4 or IPv6, but %s and %s are of different families", name, cidrs[0], cidr)
+		}
+	}
+	return nil
+}
+
+// containedByAny reports whether network is within any of the given CIDR ranges. Ranges that don't parse are
+// skipped.
+func containedByAny(network *net.IPNet, cidrs []string) bool {
+	_, ok := cidrutil.Containing(network, cidrs)
+	return ok
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &CidrHostFunction{}
+
+func NewCidrHostFunction() function.Function {
+	return &CidrHostFunction{}
+}
+
+// CidrHostFunction defines the function implementation.
+type CidrHostFunction struct{}
+
+func (f *CidrHostFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_host"
+}
+
+func (f *CidrHostFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Return the IP address at an offset within a CIDR range",
+		MarkdownDescription: "Returns the IP address at `index` within `cidr`. Unlike the built-in `cidrhost` function, negative indexes count back from the end of the range, so `-1` is the last address. " +
+			"An error is returned when `index` is outside of the range in either direction. Both IPv4 and IPv6 ranges are supported.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "cidr",
+				MarkdownDescription: "The CIDR range to find the address in (ex. `10.0.0.0/24`).",
+			},
+			function.Int64Parameter{
+				Name:                "index",
+				MarkdownDescription: "The offset of the address from the start of `cidr`, or from the end of `cidr` when negative.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CidrHostFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cidrString string
+	var index int64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidrString, &index))
+	if resp.Error != nil {
+		return
+	}
+
+	_, network, err := net.ParseCIDR(cidrString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	host, err := cidrutil.Host(network, index)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, host.String()))
+}
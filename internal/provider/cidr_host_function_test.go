@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCidrHostFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "first" {
+  value = provider::utility::cidr_host("10.0.0.0/24", 1)
+}
+
+output "last" {
+  value = provider::utility::cidr_host("10.0.0.0/24", -1)
+}
+
+output "ipv6" {
+  value = provider::utility::cidr_host("fd00::/64", -2)
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("first", "10.0.0.1"),
+					resource.TestCheckOutput("last", "10.0.0.255"),
+					resource.TestCheckOutput("ipv6", "fd00::ffff:ffff:ffff:fffe"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCidrHostFunctionOutOfRange(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::utility::cidr_host("10.0.0.0/24", -257)
+}
+`,
+				ExpectError: regexp.MustCompile("outside of 10.0.0.0/24"),
+			},
+		},
+	})
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &CidrInfoDataSource{}
+
+func NewCidrInfoDataSource() datasource.DataSource {
+	return &CidrInfoDataSource{}
+}
+
+// CidrInfoDataSource defines the data source implementation.
+type CidrInfoDataSource struct{}
+
+// CidrInfoDataSourceModel describes the data source data model.
+type CidrInfoDataSourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	Cidr             types.String `tfsdk:"cidr"`
+	NetworkAddress   types.String `tfsdk:"network_address"`
+	BroadcastAddress types.String `tfsdk:"broadcast_address"`
+	Netmask          types.String `tfsdk:"netmask"`
+	PrefixLength     types.Int64  `tfsdk:"prefix_length"`
+	HostCount        types.Int64  `tfsdk:"host_count"`
+	FirstHost        types.String `tfsdk:"first_host"`
+	LastHost         types.String `tfsdk:"last_host"`
+	Version          types.Int64  `tfsdk:"version"`
+}
+
+func (d *CidrInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cidr_info"
+}
+
+func (d *CidrInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Parses a CIDR range and returns details about the addresses it contains.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "CIDR Identifier. The value will be the network address and prefix length of `cidr`.",
+			},
+			"cidr": schema.StringAttribute{
+				MarkdownDescription: "The CIDR range to inspect (ex. `10.0.0.0/16`).",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation"),
+				},
+				Required: true,
+			},
+			"network_address": schema.StringAttribute{
+				MarkdownDescription: "The network address of the CIDR, which is the first address in the range.",
+				Computed:            true,
+			},
+			"broadcast_address": schema.StringAttribute{
+				MarkdownDescription: "The broadcast address of the CIDR, which is the last address in the range. This is null for IPv4 `/31` and `/32` ranges and for IPv6 ranges, which have no broadcast address.",
+				Computed:            true,
+			},
+			"netmask": schema.StringAttribute{
+				MarkdownDescription: "The netmask of the CIDR in address notation (ex. `255.255.0.0`).",
+				Computed:            true,
+			},
+			"prefix_length": schema.Int64Attribute{
+				MarkdownDescription: "The prefix length of the CIDR (ex. `16`).",
+				Computed:            true,
+			},
+			"host_count": schema.Int64Attribute{
+				MarkdownDescription: "The total number of addresses in the CIDR (`2^(32 - prefix_length)` for IPv4 and `2^(128 - prefix_length)` for IPv6). IPv6 ranges of `/65` or larger hold more addresses than fit in a Number, so the value saturates at `9223372036854775807`.",
+				Computed:            true,
+			},
+			"first_host": schema.StringAttribute{
+				MarkdownDescription: "The first usable host address in the CIDR. For IPv4 this excludes the network address, except for `/31` ranges where both addresses are usable and `/32` ranges which contain a single host.",
+				Computed:            true,
+			},
+			"last_host": schema.StringAttribute{
+				MarkdownDescription: "The last usable host address in the CIDR. For IPv4 this excludes the broadcast address, except for `/31` ranges where both addresses are usable and `/32` ranges which contain a single host.",
+				Computed:            true,
+			},
+			"version": schema.Int64Attribute{
+				MarkdownDescription: "The IP version of the CIDR, either `4` or `6`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CidrInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CidrInfoDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, network, err := net.ParseCIDR(data.Cidr.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cidr"),
+			"Error parsing cidr",
+			err.Error(),
+		)
+		return
+	}
+
+	prefixLength, bits := network.Mask.Size()
+	hosts := cidrutil.Hosts(network)
+
+	data.Id = types.StringValue(network.String())
+	data.NetworkAddress = types.StringValue(hosts.Network.String())
+	data.BroadcastAddress = types.StringNull()
+	if hosts.Broadcast != nil {
+		data.BroadcastAddress = types.StringValue(hosts.Broadcast.String())
+	}
+	data.Netmask = types.StringValue(net.IP(network.Mask).String())
+	data.PrefixLength = types.Int64Value(int64(prefixLength))
+	data.HostCount = types.Int64Value(cidrutil.SaturatedInt64(cidrutil.AddressCount(network)))
+	data.FirstHost = types.StringValue(hosts.First.String())
+	data.LastHost = types.StringValue(hosts.Last.String())
+	data.Version = types.Int64Value(4)
+	if bits == 128 {
+		data.Version = types.Int64Value(6)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
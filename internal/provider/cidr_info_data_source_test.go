@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCidrInfoDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCidrInfoDataSourceConfig("10.1.2.3/24"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_cidr_info.test", "id", "10.1.2.0/24"),
+					resource.TestCheckResourceAttr("data.utility_cidr_info.test", "network_address", "10.1.2.0"),
+					resource.TestCheckResourceAttr("data.utility_cidr_info.test", "broadcast_address", "10.1.2.255"),
+					resource.TestCheckResourceAttr("data.utility_cidr_info.test", "netmask", "255.255.255.0"),
+					resource.TestCheckResourceAttr("data.utility_cidr_info.test", "prefix_length", "24"),
+					resource.TestCheckResourceAttr("data.utility_cidr_info.test", "host_count", "256"),
+					resource.TestCheckResourceAttr("data.utility_cidr_info.test", "first_host", "10.1.2.1"),
+					resource.TestCheckResourceAttr("data.utility_cidr_info.test", "last_host", "10.1.2.254"),
+					resource.TestCheckResourceAttr("data.utility_cidr_info.test", "version", "4"),
+				),
+			},
+			{
+				Config: testAccCidrInfoDataSourceConfig("fd00::/64"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_cidr_info.test", "network_address", "fd00::"),
+					resource.TestCheckNoResourceAttr("data.utility_cidr_info.test", "broadcast_address"),
+					resource.TestCheckResourceAttr("data.utility_cidr_info.test", "prefix_length", "64"),
+					resource.TestCheckResourceAttr("data.utility_cidr_info.test", "last_host", "fd00::ffff:ffff:ffff:ffff"),
+					resource.TestCheckResourceAttr("data.utility_cidr_info.test", "version", "6"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCidrInfoDataSourceInvalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCidrInfoDataSourceConfig("10.1.2.0"),
+				ExpectError: regexp.MustCompile("Must be valid CIDR notation"),
+			},
+		},
+	})
+}
+
+func testAccCidrInfoDataSourceConfig(cidr string) string {
+	return `
+data "utility_cidr_info" "test" {
+  cidr = "` + cidr + `"
+}
+`
+}
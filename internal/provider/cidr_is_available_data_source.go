@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &CidrIsAvailableDataSource{}
+
+func NewCidrIsAvailableDataSource() datasource.DataSource {
+	return &CidrIsAvailableDataSource{}
+}
+
+// CidrIsAvailableDataSource defines the data source implementation.
+type CidrIsAvailableDataSource struct{}
+
+// CidrIsAvailableDataSourceModel describes the data source data model.
+type CidrIsAvailableDataSourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	Candidate       types.String `tfsdk:"candidate"`
+	FromCidrs       types.List   `tfsdk:"from_cidrs"`
+	UsedCidrs       types.List   `tfsdk:"used_cidrs"`
+	Available       types.Bool   `tfsdk:"available"`
+	ConflictingCidr types.String `tfsdk:"conflicting_cidr"`
+}
+
+func (d *CidrIsAvailableDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cidr_is_available"
+}
+
+func (d *CidrIsAvailableDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Checks whether a single CIDR range is free to allocate, without searching for one like `utility_available_cidr` does.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier. The value will be the `candidate`.",
+			},
+			"candidate": schema.StringAttribute{
+				MarkdownDescription: "The CIDR range to check (ex. `10.0.5.0/24`). Any host bits are dropped (ex. `10.0.5.7/24` is checked as `10.0.5.0/24`).",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation"),
+				},
+				Required: true,
+			},
+			"from_cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing the CIDR ranges the `candidate` must lie entirely within one of.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				Required: true,
+			},
+			"used_cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing the CIDR ranges that are already used, which the `candidate` must not overlap.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				Required: true,
+			},
+			"available": schema.BoolAttribute{
+				MarkdownDescription: "Whether the `candidate` lies entirely within one of the `from_cidrs` and doesn't overlap any of the `used_cidrs`.",
+				Computed:            true,
+			},
+			"conflicting_cidr": schema.StringAttribute{
+				MarkdownDescription: "The first of the `used_cidrs` that the `candidate` overlaps. This is null when the `candidate` is available, or when it is unavailable only because it isn't within any of the `from_cidrs`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CidrIsAvailableDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CidrIsAvailableDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	candidate, err := cidrutil.ParseCIDROrIP(data.Candidate.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("candidate"),
+			"Error parsing candidate",
+			err.Error(),
+		)
+		return
+	}
+
+	from, diags := parseCidrs(ctx, data.FromCidrs, "from_cidrs")
+	resp.Diagnostics.Append(diags...)
+	used, diags := parseCidrs(ctx, data.UsedCidrs, "used_cidrs")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contained := false
+	for _, f := range from {
+		if cidrutil.Contains(f, candidate) {
+			contained = true
+			break
+		}
+	}
+
+	data.Id = types.StringValue(data.Candidate.ValueString())
+	data.ConflictingCidr = types.StringNull()
+	for _, u := range used {
+		if cidrutil.Overlaps(candidate, u) {
+			data.ConflictingCidr = types.StringValue(u.String())
+			break
+		}
+	}
+	data.Available = types.BoolValue(contained && data.ConflictingCidr.IsNull())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
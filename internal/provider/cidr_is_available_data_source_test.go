@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCidrIsAvailableDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCidrIsAvailableDataSourceConfig("10.0.5.0/24", []string{"10.0.0.0/16"}, []string{"10.0.4.0/24", "10.0.6.0/23"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_cidr_is_available.test", "id", "10.0.5.0/24"),
+					resource.TestCheckResourceAttr("data.utility_cidr_is_available.test", "available", "true"),
+					resource.TestCheckNoResourceAttr("data.utility_cidr_is_available.test", "conflicting_cidr"),
+				),
+			},
+			// Contained, but conflicting with a used CIDR
+			{
+				Config: testAccCidrIsAvailableDataSourceConfig("10.0.5.0/24", []string{"10.0.0.0/16"}, []string{"10.0.4.0/24", "10.0.5.128/25"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_cidr_is_available.test", "available", "false"),
+					resource.TestCheckResourceAttr("data.utility_cidr_is_available.test", "conflicting_cidr", "10.0.5.128/25"),
+				),
+			},
+			// Free, but not contained by any of the from_cidrs
+			{
+				Config: testAccCidrIsAvailableDataSourceConfig("10.1.5.0/24", []string{"10.0.0.0/16"}, []string{}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_cidr_is_available.test", "available", "false"),
+					resource.TestCheckNoResourceAttr("data.utility_cidr_is_available.test", "conflicting_cidr"),
+				),
+			},
+			// Only partly within the from_cidrs
+			{
+				Config: testAccCidrIsAvailableDataSourceConfig("10.0.0.0/15", []string{"10.0.0.0/16"}, []string{}),
+				Check:  resource.TestCheckResourceAttr("data.utility_cidr_is_available.test", "available", "false"),
+			},
+		},
+	})
+}
+
+func testAccCidrIsAvailableDataSourceConfig(candidate string, from []string, used []string) string {
+	return fmt.Sprintf(`
+data "utility_cidr_is_available" "test" {
+  candidate  = %q
+  from_cidrs = %s
+  used_cidrs = %s
+}
+`, candidate, testAccStringList(from), testAccStringList(used))
+}
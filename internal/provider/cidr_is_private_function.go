@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &CidrIsPrivateFunction{}
+
+func NewCidrIsPrivateFunction() function.Function {
+	return &CidrIsPrivateFunction{}
+}
+
+// CidrIsPrivateFunction defines the function implementation.
+type CidrIsPrivateFunction struct{}
+
+func (f *CidrIsPrivateFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_is_private"
+}
+
+func (f *CidrIsPrivateFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Check whether a CIDR range is private address space",
+		MarkdownDescription: "Returns `true` when `cidr` lies entirely within the RFC 1918 ranges (`10.0.0.0/8`, `172.16.0.0/12` and `192.168.0.0/16`) for IPv4, or the unique local range `fc00::/7` for IPv6. A range that only partly overlaps them (ex. `172.0.0.0/8`) is not private.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "cidr",
+				MarkdownDescription: "The CIDR range (ex. `172.16.0.0/16`) or bare IP address to check.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *CidrIsPrivateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cidrString string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidrString))
+	if resp.Error != nil {
+		return
+	}
+
+	network, err := cidrutil.ParseCIDROrIP(cidrString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, cidrutil.IsPrivate(network)))
+}
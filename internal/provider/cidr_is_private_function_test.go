@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCidrIsPrivateFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "below_rfc1918" {
+  value = provider::utility::cidr_is_private("172.15.0.0/16")
+}
+
+output "rfc1918" {
+  value = provider::utility::cidr_is_private("172.16.0.0/16")
+}
+
+output "partly_private" {
+  value = provider::utility::cidr_is_private("172.0.0.0/8")
+}
+
+output "address" {
+  value = provider::utility::cidr_is_private("192.168.1.10")
+}
+
+output "ula" {
+  value = provider::utility::cidr_is_private("fd00:1::/64")
+}
+
+output "public_ipv6" {
+  value = provider::utility::cidr_is_private("2001:db8::/32")
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("below_rfc1918", "false"),
+					resource.TestCheckOutput("rfc1918", "true"),
+					resource.TestCheckOutput("partly_private", "false"),
+					resource.TestCheckOutput("address", "true"),
+					resource.TestCheckOutput("ula", "true"),
+					resource.TestCheckOutput("public_ipv6", "false"),
+				),
+			},
+			{
+				Config: `
+output "invalid" {
+  value = provider::utility::cidr_is_private("10.0.0.0/33")
+}
+`,
+				ExpectError: regexp.MustCompile(`not\s+a\s+valid\s+IP\s+address\s+or\s+CIDR`),
+			},
+		},
+	})
+}
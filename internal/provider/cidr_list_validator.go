@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// cidrListUnlessSkipped returns a validator which ensures that every element of a list is in CIDR notation, unless
+// the boolean attribute at skip is configured as true. Matching each element against cidrRegex is slow for lists
+// with tens of thousands of elements, so the skip attribute lets well-formed lists bypass it and leaves malformed
+// elements to be reported when they are parsed during apply.
+func cidrListUnlessSkipped(skip path.Path) validator.List {
+	return cidrListUnlessSkippedValidator{skip: skip}
+}
+
+type cidrListUnlessSkippedValidator struct {
+	skip path.Path
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v cidrListUnlessSkippedValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("each element must be valid CIDR notation unless %s is true", v.skip)
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v cidrListUnlessSkippedValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateList performs the validation. An unknown skip attribute is treated as false so nothing slips through.
+func (v cidrListUnlessSkippedValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	var skip types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.skip, &skip)...)
+	if resp.Diagnostics.HasError() || skip.ValueBool() {
+		return
+	}
+
+	listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")).ValidateList(ctx, req, resp)
+}
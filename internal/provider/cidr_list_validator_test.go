@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// cidrListValidatorRequest builds a request for the used_cidrs attribute of a configuration that only holds
+// used_cidrs and skip_used_validation.
+func cidrListValidatorRequest(cidrs []string, skip bool) validator.ListRequest {
+	elements := make([]attr.Value, len(cidrs))
+	rawElements := make([]tftypes.Value, len(cidrs))
+	for i, c := range cidrs {
+		elements[i] = types.StringValue(c)
+		rawElements[i] = tftypes.NewValue(tftypes.String, c)
+	}
+
+	configSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"used_cidrs":           schema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"skip_used_validation": schema.BoolAttribute{Optional: true},
+		},
+	}
+	raw := tftypes.NewValue(
+		tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"used_cidrs":           tftypes.List{ElementType: tftypes.String},
+			"skip_used_validation": tftypes.Bool,
+		}},
+		map[string]tftypes.Value{
+			"used_cidrs":           tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, rawElements),
+			"skip_used_validation": tftypes.NewValue(tftypes.Bool, skip),
+		},
+	)
+
+	return validator.ListRequest{
+		Path:        path.Root("used_cidrs"),
+		ConfigValue: types.ListValueMust(types.StringType, elements),
+		Config:      tfsdk.Config{Schema: configSchema, Raw: raw},
+	}
+}
+
+func TestCidrListUnlessSkipped(t *testing.T) {
+	cases := []struct {
+		name    string
+		cidrs   []string
+		skip    bool
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			cidrs: []string{"10.0.0.0/24", "fd00::/64"},
+		},
+		{
+			name:    "malformed",
+			cidrs:   []string{"10.0.0.0/24", "10.0.0.0"},
+			wantErr: true,
+		},
+		{
+			name:  "malformed but skipped",
+			cidrs: []string{"10.0.0.0/24", "10.0.0.0"},
+			skip:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &validator.ListResponse{}
+			cidrListUnlessSkipped(path.Root("skip_used_validation")).ValidateList(context.Background(), cidrListValidatorRequest(tc.cidrs, tc.skip), resp)
+			if got := resp.Diagnostics.HasError(); got != tc.wantErr {
+				t.Errorf("expected error %v, got diagnostics: %+v", tc.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func BenchmarkCidrListUnlessSkipped(b *testing.B) {
+	cidrs := make([]string, 50000)
+	for i := range cidrs {
+		cidrs[i] = fmt.Sprintf("10.%d.%d.0/24", i/256, i%256)
+	}
+
+	for _, skip := range []bool{false, true} {
+		b.Run(fmt.Sprintf("skip=%v", skip), func(b *testing.B) {
+			req := cidrListValidatorRequest(cidrs, skip)
+			v := cidrListUnlessSkipped(path.Root("skip_used_validation"))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				resp := &validator.ListResponse{}
+				v.ValidateList(context.Background(), req, resp)
+				if resp.Diagnostics.HasError() {
+					b.Fatalf("unexpected diagnostics: %+v", resp.Diagnostics)
+				}
+			}
+		})
+	}
+}
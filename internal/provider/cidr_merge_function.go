@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &CidrMergeFunction{}
+
+func NewCidrMergeFunction() function.Function {
+	return &CidrMergeFunction{}
+}
+
+// CidrMergeFunction defines the function implementation.
+type CidrMergeFunction struct{}
+
+func (f *CidrMergeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_merge"
+}
+
+func (f *CidrMergeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Merge CIDR ranges into the smallest covering list",
+		MarkdownDescription: "Returns the smallest list of CIDR ranges that covers exactly the same addresses as `cidrs` (route summarization), in ascending order. Overlapping and adjacent ranges are merged (ex. `10.0.0.0/25` and `10.0.0.128/25` become `10.0.0.0/24`). This behaves the same as the `utility_cidr_aggregate` data source, except that every range must be of the same address family.",
+
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "cidrs",
+				MarkdownDescription: "A list containing the CIDR ranges to merge. All of the ranges must be either IPv4 or IPv6.",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *CidrMergeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cidrsStrings []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidrsStrings))
+	if resp.Error != nil {
+		return
+	}
+
+	cidrs, funcErr := parseCidrArguments(cidrsStrings, 0)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	if err := checkSameFamily(cidrs, "cidrs"); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, cidrutil.Strings(cidrutil.Aggregate(cidrs))))
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCidrMergeFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = join(",", provider::utility::cidr_merge(["10.0.1.0/24", "10.0.0.128/25", "10.0.0.0/25", "10.0.4.0/24", "10.0.4.0/26"]))
+}
+`,
+				Check: resource.TestCheckOutput("test", "10.0.0.0/23,10.0.4.0/24"),
+			},
+		},
+	})
+}
+
+func TestAccCidrMergeFunctionMixedFamilies(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::utility::cidr_merge(["10.0.0.0/24", "fd00::/64"])
+}
+`,
+				ExpectError: regexp.MustCompile("are of different families"),
+			},
+		},
+	})
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &CidrNetmaskFunction{}
+
+func NewCidrNetmaskFunction() function.Function {
+	return &CidrNetmaskFunction{}
+}
+
+// CidrNetmaskFunction defines the function implementation.
+type CidrNetmaskFunction struct{}
+
+func (f *CidrNetmaskFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_netmask"
+}
+
+func (f *CidrNetmaskFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Return the netmask of a CIDR range",
+		MarkdownDescription: "Returns the netmask of `cidr` in address notation (ex. `10.0.0.0/24` is `255.255.255.0`). IPv6 ranges return the full IPv6 mask (ex. `fd00::/64` is `ffff:ffff:ffff:ffff::`).",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "cidr",
+				MarkdownDescription: "The CIDR range to return the netmask of (ex. `10.0.0.0/24`).",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CidrNetmaskFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cidrString string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidrString))
+	if resp.Error != nil {
+		return
+	}
+
+	_, network, err := net.ParseCIDR(cidrString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, net.IP(network.Mask).String()))
+}
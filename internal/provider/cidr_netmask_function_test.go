@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCidrNetmaskFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "ipv4" {
+  value = provider::utility::cidr_netmask("10.0.0.0/24")
+}
+
+output "ipv4_host_bits" {
+  value = provider::utility::cidr_netmask("10.0.5.7/20")
+}
+
+output "ipv6" {
+  value = provider::utility::cidr_netmask("fd00::/64")
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("ipv4", "255.255.255.0"),
+					resource.TestCheckOutput("ipv4_host_bits", "255.255.240.0"),
+					resource.TestCheckOutput("ipv6", "ffff:ffff:ffff:ffff::"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCidrNetmaskFunctionInvalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::utility::cidr_netmask("10.0.0.0")
+}
+`,
+				ExpectError: regexp.MustCompile("invalid CIDR address"),
+			},
+		},
+	})
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &CidrNthSubnetFunction{}
+
+func NewCidrNthSubnetFunction() function.Function {
+	return &CidrNthSubnetFunction{}
+}
+
+// CidrNthSubnetFunction defines the function implementation.
+type CidrNthSubnetFunction struct{}
+
+func (f *CidrNthSubnetFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_nth_subnet"
+}
+
+func (f *CidrNthSubnetFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Return a single subnet of a CIDR range by index",
+		MarkdownDescription: "Returns the `index`-th subnet of `cidr` when it is split into subnets `new_bits` longer than its prefix. This is similar to the built-in `cidrsubnet` function, but an `index` outside of `cidr` is an error rather than wrapping around, and it returns the same subnets as `cidr_subnets`.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "cidr",
+				MarkdownDescription: "The CIDR range to take the subnet from.",
+			},
+			function.Int64Parameter{
+				Name:                "new_bits",
+				MarkdownDescription: "The number of bits to add to the prefix length of `cidr` for the subnet. For example, `new_bits` of `8` in a `/16` returns a `/24`.",
+			},
+			function.Int64Parameter{
+				Name:                "index",
+				MarkdownDescription: "The zero-based index of the subnet to return. Must be less than `2^new_bits`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CidrNthSubnetFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cidrString string
+	var newBits int64
+	var index int64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidrString, &newBits, &index))
+	if resp.Error != nil {
+		return
+	}
+
+	_, network, err := net.ParseCIDR(cidrString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	subnet, err := cidrutil.Subnet(network, int(newBits), index)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, subnet.String()))
+}
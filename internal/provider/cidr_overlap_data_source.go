@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &CidrOverlapDataSource{}
+
+func NewCidrOverlapDataSource() datasource.DataSource {
+	return &CidrOverlapDataSource{}
+}
+
+// CidrOverlapDataSource defines the data source implementation.
+type CidrOverlapDataSource struct{}
+
+// CidrOverlapDataSourceModel describes the data source data model.
+type CidrOverlapDataSourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	Cidrs      types.List   `tfsdk:"cidrs"`
+	Overlaps   types.List   `tfsdk:"overlaps"`
+	HasOverlap types.Bool   `tfsdk:"has_overlap"`
+}
+
+func (d *CidrOverlapDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cidr_overlap"
+}
+
+func (d *CidrOverlapDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Finds every pair of CIDR ranges in a list that share any addresses.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier. The value will be the `cidrs` values separated by commas.",
+			},
+			"cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing the CIDR ranges to check for overlaps. IPv4 and IPv6 ranges may be mixed, but ranges of different address families never overlap.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				Required: true,
+			},
+			"overlaps": schema.ListAttribute{
+				MarkdownDescription: "A list of `[a, b]` pairs of overlapping CIDR ranges, as they were given in `cidrs`. Within each pair `a` comes before `b` in `cidrs`, and the pairs are ordered by the position of `a` and then `b`.",
+				Computed:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+			},
+			"has_overlap": schema.BoolAttribute{
+				MarkdownDescription: "Whether any of the CIDR ranges overlap.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CidrOverlapDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CidrOverlapDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cidrsStrings := make([]string, len(data.Cidrs.Elements()))
+	resp.Diagnostics.Append(data.Cidrs.ElementsAs(ctx, &cidrsStrings, false)...)
+	cidrs, diags := parseCidrs(ctx, data.Cidrs, "cidrs")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pairs := cidrutil.OverlappingPairs(cidrs)
+	overlaps := make([][]string, len(pairs))
+	for i, pair := range pairs {
+		overlaps[i] = []string{cidrsStrings[pair[0]], cidrsStrings[pair[1]]}
+	}
+
+	overlapsList, diags := types.ListValueFrom(ctx, types.ListType{ElemType: types.StringType}, overlaps)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(strings.Join(cidrsStrings, ","))
+	data.Overlaps = overlapsList
+	data.HasOverlap = types.BoolValue(len(pairs) > 0)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
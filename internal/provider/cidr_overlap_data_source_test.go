@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCidrOverlapDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCidrOverlapDataSourceConfig(`"10.0.0.0/25", "10.0.0.128/25", "10.0.1.0/24"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_cidr_overlap.test", "has_overlap", "false"),
+					resource.TestCheckResourceAttr("data.utility_cidr_overlap.test", "overlaps.#", "0"),
+				),
+			},
+			{
+				Config: testAccCidrOverlapDataSourceConfig(`"10.0.0.0/24", "10.0.1.0/24", "10.0.0.0/23", "10.0.2.0/24"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_cidr_overlap.test", "has_overlap", "true"),
+					resource.TestCheckResourceAttr("data.utility_cidr_overlap.test", "overlaps.#", "2"),
+					resource.TestCheckResourceAttr("data.utility_cidr_overlap.test", "overlaps.0.0", "10.0.0.0/24"),
+					resource.TestCheckResourceAttr("data.utility_cidr_overlap.test", "overlaps.0.1", "10.0.0.0/23"),
+					resource.TestCheckResourceAttr("data.utility_cidr_overlap.test", "overlaps.1.0", "10.0.1.0/24"),
+					resource.TestCheckResourceAttr("data.utility_cidr_overlap.test", "overlaps.1.1", "10.0.0.0/23"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCidrOverlapDataSourceConfig(cidrs string) string {
+	return `
+data "utility_cidr_overlap" "test" {
+  cidrs = [` + cidrs + `]
+}
+`
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &CidrOverlapsFunction{}
+
+func NewCidrOverlapsFunction() function.Function {
+	return &CidrOverlapsFunction{}
+}
+
+// CidrOverlapsFunction defines the function implementation.
+type CidrOverlapsFunction struct{}
+
+func (f *CidrOverlapsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_overlaps"
+}
+
+func (f *CidrOverlapsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Check whether two CIDR ranges share any addresses",
+		MarkdownDescription: "Returns `true` when `a` and `b` share at least one address. Adjacent ranges (ex. `10.0.0.0/25` and `10.0.0.128/25`) don't overlap, and ranges of different address families never overlap.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "a",
+				MarkdownDescription: "The first CIDR range (ex. `10.0.0.0/24`) or bare IP address.",
+			},
+			function.StringParameter{
+				Name:                "b",
+				MarkdownDescription: "The second CIDR range (ex. `10.0.0.128/25`) or bare IP address.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *CidrOverlapsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var aString string
+	var bString string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &aString, &bString))
+	if resp.Error != nil {
+		return
+	}
+
+	a, err := cidrutil.ParseCIDROrIP(aString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	b, err := cidrutil.ParseCIDROrIP(bString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, cidrutil.Overlaps(a, b)))
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCidrOverlapsFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "adjacent" {
+  value = provider::utility::cidr_overlaps("10.0.0.0/25", "10.0.0.128/25")
+}
+
+output "contained" {
+  value = provider::utility::cidr_overlaps("10.0.0.0/24", "10.0.0.128/25")
+}
+
+output "last_address" {
+  value = provider::utility::cidr_overlaps("10.0.0.0/24", "10.0.0.255")
+}
+
+output "next_address" {
+  value = provider::utility::cidr_overlaps("10.0.0.0/24", "10.0.1.0")
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("adjacent", "false"),
+					resource.TestCheckOutput("contained", "true"),
+					resource.TestCheckOutput("last_address", "true"),
+					resource.TestCheckOutput("next_address", "false"),
+				),
+			},
+		},
+	})
+}
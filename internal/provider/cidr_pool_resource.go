@@ -0,0 +1,375 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &CidrPoolResource{}
+var _ resource.ResourceWithConfigure = &CidrPoolResource{}
+var _ resource.ResourceWithModifyPlan = &CidrPoolResource{}
+
+func NewCidrPoolResource() resource.Resource {
+	return &CidrPoolResource{}
+}
+
+// CidrPoolResource defines the resource implementation.
+//
+// A provider can only write to the state of the resource it is operating on, so a separate allocation resource has
+// no way to record the block it took in the pool. Instead the pool is the single owner of its allocations: each one
+// is requested by adding a key to `requests`, and the pool records the allocated block in its own state. Since only
+// the pool ever writes allocations, two allocations can never be handed the same block, which is the race that
+// sibling utility_available_cidr resources scanning the same range are exposed to.
+type CidrPoolResource struct{}
+
+// CidrPoolResourceModel describes the resource data model.
+type CidrPoolResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	FromCidrs   types.List   `tfsdk:"from_cidrs"`
+	UsedCidrs   types.List   `tfsdk:"used_cidrs"`
+	Requests    types.Map    `tfsdk:"requests"`
+	Allocations types.Map    `tfsdk:"allocations"`
+}
+
+func (r *CidrPoolResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cidr_pool"
+}
+
+func (r *CidrPoolResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Owns CIDR range(s) (ex. a Network) and allocates non-conflicting CIDR ranges from them for each of the `requests`, " +
+			"recording the allocations in its own state.\n\n" +
+			"Unlike several `utility_available_cidr` resources searching the same range, every allocation is made by the pool, so no two " +
+			"allocations can collide. Allocations are stable: adding a request allocates a new range without moving the existing ones, and " +
+			"removing a request frees its range for later requests.\n\n" +
+			"There is no separate allocation resource. A resource can only write its own state, so an allocation resource couldn't record " +
+			"its range in the pool. Instead, a range is requested by adding a key to `requests`, and read back from the matching key of " +
+			"`allocations`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Pool Identifier. The value will be the `from_cidrs`, separated by commas.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"from_cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing the CIDR range(s) from which to allocate CIDR ranges. Changing this value keeps the existing allocations that are still within the `from_cidrs`, and moves the ones that aren't, which are re-allocated along with the new requests.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				Required: true,
+			},
+			"used_cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing the CIDR ranges within the `from_cidrs` block(s) that are used outside of the pool and should be avoided. Changing this value only affects new allocations.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				Optional: true,
+			},
+			"requests": schema.MapAttribute{
+				MarkdownDescription: "A map of allocation names to the desired mask (network/subnet size) of each allocation. New requests are allocated in order of their names, taking the lowest available CIDR. Changing the mask of a request moves that allocation.",
+				ElementType:         types.Int64Type,
+				Validators: []validator.Map{
+					mapvalidator.ValueInt64sAre(int64validator.Between(1, 128)),
+				},
+				Required: true,
+			},
+			"allocations": schema.MapAttribute{
+				MarkdownDescription: "A map of allocation names from `requests` to the CIDR range allocated to them.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *CidrPoolResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+// ModifyPlan keeps the planned allocations when the requests haven't changed and every allocation is still within the
+// from_cidrs. Otherwise the framework marks them as unknown whenever any other attribute changes, even though changing
+// used_cidrs, or growing from_cidrs, never moves an existing allocation. The id follows the from_cidrs.
+func (r *CidrPoolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// There are no allocations to keep when creating or destroying the pool.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan CidrPoolResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), poolId(plan.FromCidrs))...)
+
+	if plan.Requests.Equal(state.Requests) && allocationsWithin(state.Allocations, plan.FromCidrs) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("allocations"), state.Allocations)...)
+	}
+}
+
+func (r *CidrPoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CidrPoolResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.allocateRequests(ctx, &data, map[string]string{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = poolId(data.FromCidrs)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CidrPoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CidrPoolResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update keeps the allocation of every request whose mask hasn't changed, and allocates the new or changed requests
+// around them. Allocations of removed requests are dropped, which frees their ranges, and allocations that are no
+// longer within the from_cidrs are re-allocated.
+func (r *CidrPoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state CidrPoolResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planRequests := map[string]int64{}
+	resp.Diagnostics.Append(plan.Requests.ElementsAs(ctx, &planRequests, false)...)
+	stateRequests := map[string]int64{}
+	resp.Diagnostics.Append(state.Requests.ElementsAs(ctx, &stateRequests, false)...)
+	stateAllocations := map[string]string{}
+	resp.Diagnostics.Append(state.Allocations.ElementsAs(ctx, &stateAllocations, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kept := map[string]string{}
+	for name, allocation := range stateAllocations {
+		if mask, ok := planRequests[name]; ok && mask == stateRequests[name] {
+			kept[name] = allocation
+		}
+	}
+
+	resp.Diagnostics.Append(r.allocateRequests(ctx, &plan, kept)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = poolId(plan.FromCidrs)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete does not need to explicitly call resp.State.RemoveResource() as this is automatically handled by the
+// [framework](https://github.com/hashicorp/terraform-plugin-framework/pull/301).
+func (r *CidrPoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// allocateRequests sets data.Allocations to the existing allocations plus a new allocation for each of the requests
+// that doesn't have one yet.
+func (r *CidrPoolResource) allocateRequests(ctx context.Context, data *CidrPoolResourceModel, existing map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	fromCidrs, parseDiags := parseCidrs(ctx, data.FromCidrs, "from_cidrs")
+	diags.Append(parseDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	addrBits := cidrutil.AddressBits(fromCidrs[0])
+	for _, fromCidr := range fromCidrs {
+		if cidrutil.AddressBits(fromCidr) != addrBits {
+			diags.AddError(
+				"Mixed address families in from_cidrs",
+				fmt.Sprintf("All from_cidrs must be either IPv4 or IPv6, but %s and %s are of different families", fromCidrs[0], fromCidr),
+			)
+			return diags
+		}
+	}
+
+	usedCidrs := []*net.IPNet{}
+	if !data.UsedCidrs.IsNull() {
+		usedCidrs, parseDiags = parseCidrs(ctx, data.UsedCidrs, "used_cidrs")
+		diags.Append(parseDiags...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	requests := map[string]int64{}
+	diags.Append(data.Requests.ElementsAs(ctx, &requests, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	allocations, err := allocatePoolRequests(fromCidrs, usedCidrs, requests, existing)
+	if err != nil {
+		diags.AddError(
+			allocationErrorSummary(err),
+			err.Error(),
+		)
+		return diags
+	}
+
+	allocationsMap, mapDiags := types.MapValueFrom(ctx, types.StringType, allocations)
+	diags.Append(mapDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	data.Allocations = allocationsMap
+
+	tflog.Trace(ctx, "allocated cidrs from pool", map[string]interface{}{
+		"allocations": allocations,
+	})
+
+	return diags
+}
+
+// poolId joins the from_cidrs with commas, or is unknown until they are all known.
+func poolId(fromCidrs types.List) types.String {
+	cidrs, ok := knownStrings(fromCidrs)
+	if !ok {
+		return types.StringUnknown()
+	}
+	return types.StringValue(strings.Join(cidrs, ","))
+}
+
+// allocationsWithin reports whether every one of the allocations lies within one of the from_cidrs. It is false while
+// either of them is unknown, since the allocations may have to move.
+func allocationsWithin(allocations types.Map, fromCidrs types.List) bool {
+	cidrs, ok := knownStrings(fromCidrs)
+	if !ok || allocations.IsUnknown() {
+		return false
+	}
+
+	for _, element := range allocations.Elements() {
+		allocation, ok := element.(types.String)
+		if !ok {
+			return false
+		}
+		_, network, err := net.ParseCIDR(allocation.ValueString())
+		if err != nil || !containedByAny(network, cidrs) {
+			return false
+		}
+	}
+	return true
+}
+
+// knownStrings returns the elements of a list of strings, or false if the list or any of its elements is unknown.
+func knownStrings(list types.List) ([]string, bool) {
+	if list.IsUnknown() {
+		return nil, false
+	}
+
+	values := make([]string, 0, len(list.Elements()))
+	for _, element := range list.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() {
+			return nil, false
+		}
+		values = append(values, value.ValueString())
+	}
+	return values, true
+}
+
+// allocatePoolRequests returns the existing allocations along with the lowest available CIDR for each request that
+// doesn't have one, allocated in order of the request names. Both the used CIDRs and every existing or newly made
+// allocation are avoided. An existing allocation that isn't within the from CIDRs, because they have changed since it
+// was made, is re-allocated like a new request.
+func allocatePoolRequests(fromCidrs []*net.IPNet, usedCidrs []*net.IPNet, requests map[string]int64, existing map[string]string) (map[string]string, error) {
+	addrBits := cidrutil.AddressBits(fromCidrs[0])
+
+	allocations := make(map[string]string, len(requests))
+	used := append([]*net.IPNet{}, usedCidrs...)
+	fromCidrStrings := cidrutil.Strings(fromCidrs)
+	for name, allocation := range existing {
+		_, network, err := net.ParseCIDR(allocation)
+		if err != nil {
+			return nil, fmt.Errorf("the existing allocation %q for %q is not a valid CIDR: %w", allocation, name, err)
+		}
+		if !containedByAny(network, fromCidrStrings) {
+			continue
+		}
+		allocations[name] = allocation
+		used = append(used, network)
+	}
+
+	names := make([]string, 0, len(requests))
+	for name := range requests {
+		if _, ok := allocations[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		prefixLength := int(requests[name])
+		if prefixLength > addrBits {
+			return nil, fmt.Errorf("the mask /%d requested for %q is larger than the from_cidrs address family allows: %w", prefixLength, name, cidrutil.ErrInvalidMask)
+		}
+
+		mask := net.CIDRMask(prefixLength, addrBits)
+		result, err := allocate(strategyFirstFit, nil, fromCidrs, &mask, &mask, cidrutil.Normalize(used))
+		if err != nil {
+			return nil, fmt.Errorf("unable to allocate %q: %w", name, err)
+		}
+
+		allocations[name] = result.String()
+		used = append(used, result)
+	}
+
+	return allocations, nil
+}
@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestCidrPoolResourceSchema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	NewCidrPoolResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	if diagnostics := schemaResponse.Schema.ValidateImplementation(ctx); diagnostics.HasError() {
+		t.Fatalf("schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestAllocatePoolRequests(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     []string
+		used     []string
+		requests map[string]int64
+		existing map[string]string
+		want     map[string]string
+		wantErr  bool
+	}{
+		{
+			name:     "requests are allocated in name order",
+			from:     []string{"10.0.0.0/16"},
+			used:     []string{"10.0.0.0/24"},
+			requests: map[string]int64{"c": 20, "b": 24, "a": 24},
+			existing: map[string]string{},
+			want:     map[string]string{"a": "10.0.1.0/24", "b": "10.0.2.0/24", "c": "10.0.16.0/20"},
+		},
+		{
+			name:     "existing allocations are kept and avoided",
+			from:     []string{"10.0.0.0/16"},
+			requests: map[string]int64{"a": 24, "b": 24},
+			existing: map[string]string{"b": "10.0.0.0/24"},
+			want:     map[string]string{"a": "10.0.1.0/24", "b": "10.0.0.0/24"},
+		},
+		{
+			name:     "later ranges are used once the first is full",
+			from:     []string{"10.0.0.0/24", "10.1.0.0/24"},
+			requests: map[string]int64{"a": 24, "b": 24},
+			existing: map[string]string{},
+			want:     map[string]string{"a": "10.0.0.0/24", "b": "10.1.0.0/24"},
+		},
+		{
+			name:     "existing allocations outside the from ranges are re-allocated",
+			from:     []string{"10.1.0.0/23"},
+			requests: map[string]int64{"a": 24, "b": 24},
+			existing: map[string]string{"a": "10.0.0.0/24", "b": "10.1.0.0/24"},
+			want:     map[string]string{"a": "10.1.1.0/24", "b": "10.1.0.0/24"},
+		},
+		{
+			name:     "pool exhausted",
+			from:     []string{"10.0.0.0/24"},
+			requests: map[string]int64{"a": 24, "b": 24},
+			existing: map[string]string{},
+			wantErr:  true,
+		},
+		{
+			name:     "mask too large for the address family",
+			from:     []string{"10.0.0.0/16"},
+			requests: map[string]int64{"a": 33},
+			existing: map[string]string{},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parse := func(cidrs []string) []*net.IPNet {
+				networks := make([]*net.IPNet, len(cidrs))
+				for i, c := range cidrs {
+					_, network, err := net.ParseCIDR(c)
+					if err != nil {
+						t.Fatalf("unable to parse %s: %s", c, err)
+					}
+					networks[i] = network
+				}
+				return networks
+			}
+
+			got, err := allocatePoolRequests(parse(tc.from), parse(tc.used), tc.requests, tc.existing)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+			for name, want := range tc.want {
+				if got[name] != want {
+					t.Errorf("%s: expected %s, got %s", name, want, got[name])
+				}
+			}
+		})
+	}
+}
+
+func TestAccCidrPoolResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCidrPoolResourceConfig([]string{"10.0.0.0/16"}, []string{"10.0.0.0/24"}, map[string]int{"a": 24, "b": 24, "c": 20}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "id", "10.0.0.0/16"),
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "allocations.%", "3"),
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "allocations.a", "10.0.1.0/24"),
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "allocations.b", "10.0.2.0/24"),
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "allocations.c", "10.0.16.0/20"),
+				),
+			},
+			// Removing a request frees its range and adding one doesn't move the others
+			{
+				Config: testAccCidrPoolResourceConfig([]string{"10.0.0.0/16"}, []string{"10.0.0.0/24"}, map[string]int{"a": 24, "c": 20, "d": 23}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_cidr_pool.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "allocations.%", "3"),
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "allocations.a", "10.0.1.0/24"),
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "allocations.c", "10.0.16.0/20"),
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "allocations.d", "10.0.2.0/23"),
+				),
+			},
+			// Changing used_cidrs doesn't move any allocation
+			{
+				Config: testAccCidrPoolResourceConfig([]string{"10.0.0.0/16"}, []string{"10.0.0.0/20"}, map[string]int{"a": 24, "c": 20, "d": 23}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_cidr_pool.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "allocations.a", "10.0.1.0/24"),
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "allocations.c", "10.0.16.0/20"),
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "allocations.d", "10.0.2.0/23"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccCidrPoolResourceShrinkFromCidrs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCidrPoolResourceConfig([]string{"10.0.0.0/24", "10.1.0.0/23"}, []string{}, map[string]int{"a": 24, "b": 24}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "id", "10.0.0.0/24,10.1.0.0/23"),
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "allocations.a", "10.0.0.0/24"),
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "allocations.b", "10.1.0.0/24"),
+				),
+			},
+			// Removing a range moves the allocations that were in it, and keeps the others
+			{
+				Config: testAccCidrPoolResourceConfig([]string{"10.1.0.0/23"}, []string{}, map[string]int{"a": 24, "b": 24}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_cidr_pool.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("utility_cidr_pool.test", tfjsonpath.New("allocations")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "id", "10.1.0.0/23"),
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "allocations.a", "10.1.1.0/24"),
+					resource.TestCheckResourceAttr("utility_cidr_pool.test", "allocations.b", "10.1.0.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCidrPoolResourceExhausted(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCidrPoolResourceConfig([]string{"10.0.0.0/16"}, []string{"10.0.0.0/17"}, map[string]int{"a": 17, "b": 17}),
+				ExpectError: regexp.MustCompile("No available CIDR found"),
+			},
+		},
+	})
+}
+
+func testAccCidrPoolResourceConfig(from []string, used []string, requests map[string]int) string {
+	names := make([]string, 0, len(requests))
+	for name := range requests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = fmt.Sprintf("    %s = %d", name, requests[name])
+	}
+
+	return fmt.Sprintf(`
+resource "utility_cidr_pool" "test" {
+  from_cidrs = %s
+  used_cidrs = %s
+  requests = {
+%s
+  }
+}
+`, testAccStringList(from), testAccStringList(used), strings.Join(lines, "\n"))
+}
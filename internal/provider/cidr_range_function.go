@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &CidrRangeFunction{}
+
+func NewCidrRangeFunction() function.Function {
+	return &CidrRangeFunction{}
+}
+
+// CidrRangeFunction defines the function implementation.
+type CidrRangeFunction struct{}
+
+// cidrRangeResult is the object returned by the cidr_range function.
+type cidrRangeResult struct {
+	First string `tfsdk:"first"`
+	Last  string `tfsdk:"last"`
+}
+
+func (f *CidrRangeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_range"
+}
+
+func (f *CidrRangeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Return the first and last IP address of a CIDR range",
+		MarkdownDescription: "Returns an object with the `first` and `last` IP addresses of `cidr`, which is useful for rules that take an explicit start and end address. " +
+			"When `usable` is `true` the IPv4 network and broadcast addresses are excluded, except for `/31` and `/32` ranges where every address is usable. " +
+			"This matches the `first_host` and `last_host` attributes of the `utility_available_cidr` resource. Every address of an IPv6 range is usable.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "cidr",
+				MarkdownDescription: "The CIDR range to return the addresses of (ex. `10.0.0.0/24`).",
+			},
+			function.BoolParameter{
+				Name:                "usable",
+				MarkdownDescription: "Whether to return the usable host range (`true`) or the absolute range including the network and broadcast addresses (`false`).",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"first": types.StringType,
+				"last":  types.StringType,
+			},
+		},
+	}
+}
+
+func (f *CidrRangeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cidrString string
+	var usable bool
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidrString, &usable))
+	if resp.Error != nil {
+		return
+	}
+
+	_, network, err := net.ParseCIDR(cidrString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	hosts := cidrutil.Hosts(network)
+	result := cidrRangeResult{
+		First: hosts.First.String(),
+		Last:  hosts.Last.String(),
+	}
+	// Only IPv4 networks larger than a /31 reserve addresses, so the absolute range differs from the usable one
+	// exactly when there is a broadcast address.
+	if !usable && hosts.Broadcast != nil {
+		result.First = hosts.Network.String()
+		result.Last = hosts.Broadcast.String()
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCidrRangeFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  absolute = provider::utility::cidr_range("10.0.0.0/24", false)
+  usable   = provider::utility::cidr_range("10.0.0.0/24", true)
+  slash31  = provider::utility::cidr_range("10.0.0.0/31", true)
+  slash32  = provider::utility::cidr_range("10.0.0.5/32", true)
+  ipv6     = provider::utility::cidr_range("fd00::/64", true)
+}
+
+output "absolute" {
+  value = "${local.absolute.first}-${local.absolute.last}"
+}
+
+output "usable" {
+  value = "${local.usable.first}-${local.usable.last}"
+}
+
+output "slash31" {
+  value = "${local.slash31.first}-${local.slash31.last}"
+}
+
+output "slash32" {
+  value = "${local.slash32.first}-${local.slash32.last}"
+}
+
+output "ipv6" {
+  value = "${local.ipv6.first}-${local.ipv6.last}"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("absolute", "10.0.0.0-10.0.0.255"),
+					resource.TestCheckOutput("usable", "10.0.0.1-10.0.0.254"),
+					resource.TestCheckOutput("slash31", "10.0.0.0-10.0.0.1"),
+					resource.TestCheckOutput("slash32", "10.0.0.5-10.0.0.5"),
+					resource.TestCheckOutput("ipv6", "fd00::-fd00::ffff:ffff:ffff:ffff"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCidrRangeFunctionInvalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::utility::cidr_range("10.0.0.300/24", true)
+}
+`,
+				ExpectError: regexp.MustCompile("invalid CIDR address"),
+			},
+		},
+	})
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &CidrSizeFunction{}
+
+func NewCidrSizeFunction() function.Function {
+	return &CidrSizeFunction{}
+}
+
+// CidrSizeFunction defines the function implementation.
+type CidrSizeFunction struct{}
+
+func (f *CidrSizeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_size"
+}
+
+func (f *CidrSizeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Return the number of addresses in a CIDR range",
+		MarkdownDescription: "Returns the number of addresses in `cidr`, including the network and broadcast addresses (ex. `10.0.0.0/24` is `256`). " +
+			"The type of the result depends on the address family: IPv4 ranges return a `number`, while IPv6 ranges return the count as a decimal `string` " +
+			"(ex. `fd00::/64` is `\"18446744073709551616\"`), since IPv6 counts can exceed what tools reading the value as a 64-bit float represent exactly. " +
+			"Use `tonumber()` on an IPv6 result to do arithmetic with it inside Terraform.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "cidr",
+				MarkdownDescription: "The CIDR range to count the addresses of (ex. `10.0.0.0/24`).",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f *CidrSizeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cidrString string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidrString))
+	if resp.Error != nil {
+		return
+	}
+
+	_, network, err := net.ParseCIDR(cidrString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	count := cidrutil.AddressCount(network)
+
+	var result types.Dynamic
+	if cidrutil.AddressBits(network) == 32 {
+		result = types.DynamicValue(types.NumberValue(new(big.Float).SetInt(count)))
+	} else {
+		result = types.DynamicValue(types.StringValue(count.String()))
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCidrSizeFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "ipv4" {
+  value = provider::utility::cidr_size("10.0.0.0/24") * 2
+}
+
+output "ipv6" {
+  value = provider::utility::cidr_size("fd00::/64")
+}
+
+output "ipv6_is_string" {
+  value = provider::utility::cidr_size("fd00::/64") == "18446744073709551616"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("ipv4", "512"),
+					resource.TestCheckOutput("ipv6", "18446744073709551616"),
+					resource.TestCheckOutput("ipv6_is_string", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCidrSizeFunctionInvalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::utility::cidr_size("10.0.0.0")
+}
+`,
+				ExpectError: regexp.MustCompile("invalid CIDR address"),
+			},
+		},
+	})
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &CidrSplitAtFunction{}
+
+func NewCidrSplitAtFunction() function.Function {
+	return &CidrSplitAtFunction{}
+}
+
+// CidrSplitAtFunction defines the function implementation.
+type CidrSplitAtFunction struct{}
+
+// cidrSplitAtResult is the object returned by the cidr_split_at function.
+type cidrSplitAtResult struct {
+	Lower []string `tfsdk:"lower"`
+	Upper []string `tfsdk:"upper"`
+}
+
+func (f *CidrSplitAtFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_split_at"
+}
+
+func (f *CidrSplitAtFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Divide a CIDR range in two at an arbitrary address",
+		MarkdownDescription: "Returns an object with the `lower` and `upper` lists of CIDR ranges, which together cover exactly the addresses of `cidr`. " +
+			"`lower` covers the addresses before `address` and `upper` covers `address` through the end of `cidr`, each with the fewest ranges possible, in ascending order. " +
+			"This is useful for setting aside the start of a range (ex. for static assignment) and passing the rest to `utility_available_cidr`. " +
+			"`lower` is empty when `address` is the first address of `cidr`.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "cidr",
+				MarkdownDescription: "The CIDR range to divide (ex. `10.0.0.0/24`).",
+			},
+			function.StringParameter{
+				Name:                "address",
+				MarkdownDescription: "The IP address to divide `cidr` at, which becomes the first address of `upper` (ex. `10.0.0.100`). Must be within `cidr`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"lower": types.ListType{ElemType: types.StringType},
+				"upper": types.ListType{ElemType: types.StringType},
+			},
+		},
+	}
+}
+
+func (f *CidrSplitAtFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cidrString string
+	var addressString string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidrString, &addressString))
+	if resp.Error != nil {
+		return
+	}
+
+	_, network, err := net.ParseCIDR(cidrString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	address := net.ParseIP(addressString)
+	if address == nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("%q is not a valid IP address", addressString))
+		return
+	}
+
+	lower, upper, err := cidrutil.SplitAt(network, address)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	result := cidrSplitAtResult{
+		Lower: cidrutil.Strings(lower),
+		Upper: cidrutil.Strings(upper),
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCidrSplitAtFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  half  = provider::utility::cidr_split_at("10.0.0.0/24", "10.0.0.128")
+  odd   = provider::utility::cidr_split_at("10.0.0.0/24", "10.0.0.100")
+  first = provider::utility::cidr_split_at("10.0.0.0/24", "10.0.0.0")
+  ipv6  = provider::utility::cidr_split_at("fd00::/64", "fd00::8000:0:0:0")
+}
+
+output "half" {
+  value = "${join(",", local.half.lower)}|${join(",", local.half.upper)}"
+}
+
+output "odd" {
+  value = "${join(",", local.odd.lower)}|${join(",", local.odd.upper)}"
+}
+
+output "first" {
+  value = "${join(",", local.first.lower)}|${join(",", local.first.upper)}"
+}
+
+output "ipv6" {
+  value = "${join(",", local.ipv6.lower)}|${join(",", local.ipv6.upper)}"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("half", "10.0.0.0/25|10.0.0.128/25"),
+					resource.TestCheckOutput("odd", "10.0.0.0/26,10.0.0.64/27,10.0.0.96/30|10.0.0.100/30,10.0.0.104/29,10.0.0.112/28,10.0.0.128/25"),
+					resource.TestCheckOutput("first", "|10.0.0.0/24"),
+					resource.TestCheckOutput("ipv6", "fd00::/65|fd00::8000:0:0:0/65"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCidrSplitAtFunctionInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		cidr    string
+		address string
+		wantErr string
+	}{
+		{cidr: "10.0.0.300/24", address: "10.0.0.1", wantErr: "invalid CIDR address"},
+		{cidr: "10.0.0.0/24", address: "10.0.0", wantErr: "is not a valid IP address"},
+		{cidr: "10.0.0.0/24", address: "10.0.1.0", wantErr: "is not within 10.0.0.0/24"},
+	} {
+		resource.Test(t, resource.TestCase{
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(tfversion.Version1_8_0),
+			},
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config:      `output "test" { value = provider::utility::cidr_split_at("` + tc.cidr + `", "` + tc.address + `") }`,
+					ExpectError: regexp.MustCompile(tc.wantErr),
+				},
+			},
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxDefaultSubnetBits limits how many subnets are returned when subnet_count isn't set, so a large new_bits
+// doesn't produce an unbounded list.
+const maxDefaultSubnetBits = 16
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &CidrSubnetsDataSource{}
+
+func NewCidrSubnetsDataSource() datasource.DataSource {
+	return &CidrSubnetsDataSource{}
+}
+
+// CidrSubnetsDataSource defines the data source implementation.
+type CidrSubnetsDataSource struct{}
+
+// CidrSubnetsDataSourceModel describes the data source data model.
+type CidrSubnetsDataSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Cidr        types.String `tfsdk:"cidr"`
+	NewBits     types.Int64  `tfsdk:"new_bits"`
+	SubnetCount types.Int64  `tfsdk:"subnet_count"`
+	Subnets     types.List   `tfsdk:"subnets"`
+}
+
+func (d *CidrSubnetsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cidr_subnets"
+}
+
+func (d *CidrSubnetsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Splits a CIDR range into a number of equally sized, consecutive subnets.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier. The value will be identical to the `cidr` field.",
+			},
+			"cidr": schema.StringAttribute{
+				MarkdownDescription: "The CIDR range to split into subnets.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation"),
+				},
+				Required: true,
+			},
+			"new_bits": schema.Int64Attribute{
+				MarkdownDescription: "The number of bits to add to the prefix length of `cidr` for each subnet. For example, splitting a `/16` with `new_bits` of `8` produces `/24` subnets.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				Required: true,
+			},
+			"subnet_count": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The number of subnets to return, starting from the beginning of `cidr`. Must not exceed `2^new_bits`. Defaults to every subnet in `cidr`, which is only allowed when `new_bits` is %d or less.", maxDefaultSubnetBits),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Optional: true,
+			},
+			"subnets": schema.ListAttribute{
+				MarkdownDescription: "The resulting subnets, in ascending order.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *CidrSubnetsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CidrSubnetsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, network, err := net.ParseCIDR(data.Cidr.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cidr"),
+			"Error parsing cidr",
+			err.Error(),
+		)
+		return
+	}
+
+	newBits := int(data.NewBits.ValueInt64())
+	ones, bits := network.Mask.Size()
+	if ones+newBits > bits {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("new_bits"),
+			"new_bits too large",
+			fmt.Sprintf("Adding %d bits to the /%d prefix of %s exceeds the %d bits in an address", newBits, ones, network, bits),
+		)
+		return
+	}
+
+	var count int64
+	if data.SubnetCount.IsNull() {
+		if newBits > maxDefaultSubnetBits {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("subnet_count"),
+				"Missing subnet_count",
+				fmt.Sprintf("subnet_count must be set when new_bits is greater than %d", maxDefaultSubnetBits),
+			)
+			return
+		}
+		count = 1 << newBits
+	} else {
+		count = data.SubnetCount.ValueInt64()
+	}
+
+	subnets, err := cidrutil.Subnets(network, newBits, count)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("subnet_count"),
+			"Too many subnets requested",
+			err.Error(),
+		)
+		return
+	}
+
+	subnetsList, diags := types.ListValueFrom(ctx, types.StringType, cidrutil.Strings(subnets))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(network.String())
+	data.Subnets = subnetsList
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCidrSubnetsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "utility_cidr_subnets" "test" {
+  cidr     = "10.0.0.0/16"
+  new_bits = 2
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_cidr_subnets.test", "subnets.#", "4"),
+					resource.TestCheckResourceAttr("data.utility_cidr_subnets.test", "subnets.0", "10.0.0.0/18"),
+					resource.TestCheckResourceAttr("data.utility_cidr_subnets.test", "subnets.3", "10.0.192.0/18"),
+				),
+			},
+			{
+				Config: `
+data "utility_cidr_subnets" "test" {
+  cidr         = "10.0.0.0/16"
+  new_bits     = 8
+  subnet_count = 3
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_cidr_subnets.test", "subnets.#", "3"),
+					resource.TestCheckResourceAttr("data.utility_cidr_subnets.test", "subnets.2", "10.0.2.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCidrSubnetsDataSourceTooManySubnets(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "utility_cidr_subnets" "test" {
+  cidr         = "10.0.0.0/16"
+  new_bits     = 2
+  subnet_count = 5
+}
+`,
+				ExpectError: regexp.MustCompile("Too many subnets requested"),
+			},
+			{
+				Config: `
+data "utility_cidr_subnets" "test" {
+  cidr     = "10.0.0.0/16"
+  new_bits = 17
+}
+`,
+				ExpectError: regexp.MustCompile("new_bits too large"),
+			},
+		},
+	})
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &CidrSubnetsFunction{}
+
+func NewCidrSubnetsFunction() function.Function {
+	return &CidrSubnetsFunction{}
+}
+
+// CidrSubnetsFunction defines the function implementation.
+type CidrSubnetsFunction struct{}
+
+func (f *CidrSubnetsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_subnets"
+}
+
+func (f *CidrSubnetsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Split a CIDR range into equally sized subnets",
+		MarkdownDescription: "Splits `cidr` into `count` equally sized, consecutive subnets starting from the beginning of `cidr`. This behaves the same as the `utility_cidr_subnets` data source.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "cidr",
+				MarkdownDescription: "The CIDR range to split into subnets.",
+			},
+			function.Int64Parameter{
+				Name:                "new_bits",
+				MarkdownDescription: "The number of bits to add to the prefix length of `cidr` for each subnet. For example, splitting a `/16` with `new_bits` of `8` produces `/24` subnets.",
+			},
+			function.Int64Parameter{
+				Name:                "count",
+				MarkdownDescription: "The number of subnets to return. Must not exceed `2^new_bits`.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *CidrSubnetsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cidrString string
+	var newBits int64
+	var count int64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidrString, &newBits, &count))
+	if resp.Error != nil {
+		return
+	}
+
+	_, network, err := net.ParseCIDR(cidrString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	subnets, err := cidrutil.Subnets(network, int(newBits), count)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, cidrutil.Strings(subnets)))
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCidrSubnetsFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = join(",", provider::utility::cidr_subnets("10.0.0.0/16", 2, 3))
+}
+`,
+				Check: resource.TestCheckOutput("test", "10.0.0.0/18,10.0.64.0/18,10.0.128.0/18"),
+			},
+		},
+	})
+}
+
+func TestAccCidrSubnetsFunctionTooMany(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::utility::cidr_subnets("10.0.0.0/16", 2, 5)
+}
+`,
+				ExpectError: regexp.MustCompile(`can\s+only\s+be\s+split\s+into\s+4`),
+			},
+		},
+	})
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &CidrUtilizationDataSource{}
+
+func NewCidrUtilizationDataSource() datasource.DataSource {
+	return &CidrUtilizationDataSource{}
+}
+
+// CidrUtilizationDataSource defines the data source implementation.
+type CidrUtilizationDataSource struct{}
+
+// CidrUtilizationDataSourceModel describes the data source data model.
+type CidrUtilizationDataSourceModel struct {
+	Id                 types.String  `tfsdk:"id"`
+	FromCidrs          types.List    `tfsdk:"from_cidrs"`
+	UsedCidrs          types.List    `tfsdk:"used_cidrs"`
+	TotalAddresses     types.Int64   `tfsdk:"total_addresses"`
+	UsedAddresses      types.Int64   `tfsdk:"used_addresses"`
+	FreeAddresses      types.Int64   `tfsdk:"free_addresses"`
+	UtilizationPercent types.Float64 `tfsdk:"utilization_percent"`
+	LargestFreeBlock   types.String  `tfsdk:"largest_free_block"`
+}
+
+func (d *CidrUtilizationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cidr_utilization"
+}
+
+func (d *CidrUtilizationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Summarizes how much of a set of CIDR ranges is used, for tracking address space exhaustion (ex. on an IPAM dashboard).",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier. The value will be the aggregated `from_cidrs` separated by commas.",
+			},
+			"from_cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing the CIDR ranges to summarize. Overlapping ranges are only counted once.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				Required: true,
+			},
+			"used_cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing the CIDR ranges that are already used within the `from_cidrs`. Ranges outside of the `from_cidrs` are ignored, and addresses covered by more than one used range are only counted once.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				Required: true,
+			},
+			"total_addresses": schema.Int64Attribute{
+				MarkdownDescription: "The number of addresses in the `from_cidrs`. IPv6 ranges can hold more addresses than fit in a Number, so the value saturates at `9223372036854775807`.",
+				Computed:            true,
+			},
+			"used_addresses": schema.Int64Attribute{
+				MarkdownDescription: "The number of addresses in the `from_cidrs` that are covered by the `used_cidrs`. Saturates the same way as `total_addresses`.",
+				Computed:            true,
+			},
+			"free_addresses": schema.Int64Attribute{
+				MarkdownDescription: "The number of addresses in the `from_cidrs` that aren't covered by the `used_cidrs`. Saturates the same way as `total_addresses`.",
+				Computed:            true,
+			},
+			"utilization_percent": schema.Float64Attribute{
+				MarkdownDescription: "The percentage of the addresses in the `from_cidrs` that are used, from `0` to `100`. This is calculated from the exact address counts, so it is accurate even when the counts saturate.",
+				Computed:            true,
+			},
+			"largest_free_block": schema.StringAttribute{
+				MarkdownDescription: "The largest CIDR range within the `from_cidrs` that doesn't overlap any of the `used_cidrs`, which is the largest block that could still be allocated. When several are the same size the lowest is returned. This is null when the `from_cidrs` are fully used.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CidrUtilizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CidrUtilizationDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	from, diags := parseCidrs(ctx, data.FromCidrs, "from_cidrs")
+	resp.Diagnostics.Append(diags...)
+	used, diags := parseCidrs(ctx, data.UsedCidrs, "used_cidrs")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Aggregating keeps overlapping from_cidrs from being counted twice, and sorts them so the lowest of equally
+	// sized free blocks is found first.
+	from = cidrutil.Aggregate(from)
+	usage := cidrutil.Utilization(from, used)
+	free := new(big.Int).Sub(usage.Total, usage.Used)
+
+	percent, _ := new(big.Float).Quo(
+		new(big.Float).Mul(new(big.Float).SetInt(usage.Used), big.NewFloat(100)),
+		new(big.Float).SetInt(usage.Total),
+	).Float64()
+
+	data.Id = types.StringValue(strings.Join(cidrutil.Strings(from), ","))
+	data.TotalAddresses = types.Int64Value(cidrutil.SaturatedInt64(usage.Total))
+	data.UsedAddresses = types.Int64Value(cidrutil.SaturatedInt64(usage.Used))
+	data.FreeAddresses = types.Int64Value(cidrutil.SaturatedInt64(free))
+	data.UtilizationPercent = types.Float64Value(percent)
+	data.LargestFreeBlock = types.StringNull()
+	if largest := cidrutil.LargestFreeBlock(from, used); largest != nil {
+		data.LargestFreeBlock = types.StringValue(largest.String())
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
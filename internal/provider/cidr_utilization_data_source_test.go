@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCidrUtilizationDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "utility_cidr_utilization" "test" {
+  from_cidrs = ["10.0.0.0/22"]
+  used_cidrs = ["10.0.0.0/24", "10.0.0.0/25", "10.0.1.0/24"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_cidr_utilization.test", "id", "10.0.0.0/22"),
+					resource.TestCheckResourceAttr("data.utility_cidr_utilization.test", "total_addresses", "1024"),
+					resource.TestCheckResourceAttr("data.utility_cidr_utilization.test", "used_addresses", "512"),
+					resource.TestCheckResourceAttr("data.utility_cidr_utilization.test", "free_addresses", "512"),
+					resource.TestCheckResourceAttr("data.utility_cidr_utilization.test", "utilization_percent", "50"),
+					resource.TestCheckResourceAttr("data.utility_cidr_utilization.test", "largest_free_block", "10.0.2.0/23"),
+				),
+			},
+			{
+				Config: `
+data "utility_cidr_utilization" "test" {
+  from_cidrs = ["10.0.0.0/24", "10.0.0.0/24"]
+  used_cidrs = ["10.0.0.0/16"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_cidr_utilization.test", "total_addresses", "256"),
+					resource.TestCheckResourceAttr("data.utility_cidr_utilization.test", "used_addresses", "256"),
+					resource.TestCheckResourceAttr("data.utility_cidr_utilization.test", "free_addresses", "0"),
+					resource.TestCheckResourceAttr("data.utility_cidr_utilization.test", "utilization_percent", "100"),
+					resource.TestCheckNoResourceAttr("data.utility_cidr_utilization.test", "largest_free_block"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &CidrWildcardFunction{}
+
+func NewCidrWildcardFunction() function.Function {
+	return &CidrWildcardFunction{}
+}
+
+// CidrWildcardFunction defines the function implementation.
+type CidrWildcardFunction struct{}
+
+func (f *CidrWildcardFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_wildcard"
+}
+
+func (f *CidrWildcardFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Return the wildcard mask of an IPv4 CIDR range",
+		MarkdownDescription: "Returns the wildcard mask of `cidr`, the inverse of its netmask, in address notation (ex. `10.0.0.0/24` is `0.0.0.255`), as used by the access lists of some network devices. " +
+			"Wildcard masks have no conventional meaning for IPv6, so IPv6 ranges are an error.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "cidr",
+				MarkdownDescription: "The IPv4 CIDR range to return the wildcard mask of (ex. `10.0.0.0/24`).",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CidrWildcardFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cidrString string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidrString))
+	if resp.Error != nil {
+		return
+	}
+
+	_, network, err := net.ParseCIDR(cidrString)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	if cidrutil.AddressBits(network) != 32 {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("cidr_wildcard only supports IPv4 ranges, since wildcard masks have no conventional meaning for IPv6, got %s", cidrString))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, cidrutil.Wildcard(network.Mask).String()))
+}
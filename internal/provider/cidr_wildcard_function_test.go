@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCidrWildcardFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "slash_24" {
+  value = provider::utility::cidr_wildcard("10.0.0.0/24")
+}
+
+output "slash_20" {
+  value = provider::utility::cidr_wildcard("10.0.0.0/20")
+}
+
+output "host" {
+  value = provider::utility::cidr_wildcard("10.0.0.1/32")
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("slash_24", "0.0.0.255"),
+					resource.TestCheckOutput("slash_20", "0.0.15.255"),
+					resource.TestCheckOutput("host", "0.0.0.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCidrWildcardFunctionInvalid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cidr    string
+		wantErr string
+	}{
+		"ipv6":       {cidr: "fd00::/64", wantErr: "only supports IPv4 ranges"},
+		"not a CIDR": {cidr: "10.0.0.0", wantErr: "invalid CIDR address"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: `
+output "test" {
+  value = provider::utility::cidr_wildcard("` + tc.cidr + `")
+}
+`,
+						ExpectError: regexp.MustCompile(tc.wantErr),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &IntToIPFunction{}
+
+func NewIntToIPFunction() function.Function {
+	return &IntToIPFunction{}
+}
+
+// IntToIPFunction defines the function implementation.
+type IntToIPFunction struct{}
+
+func (f *IntToIPFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "int_to_ip"
+}
+
+func (f *IntToIPFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Convert an integer to an IP address",
+		MarkdownDescription: "Returns the IP address with the given integer value, the inverse of `ip_to_int` (ex. `167772161` is `10.0.0.1` for version `4`). " +
+			"Terraform numbers are arbitrary precision, so every IPv6 address can be represented exactly. An error is returned when the number isn't a whole number in the range of the address family.",
+
+		Parameters: []function.Parameter{
+			function.NumberParameter{
+				Name:                "number",
+				MarkdownDescription: "The integer value of the address, from `0` to `4294967295` for IPv4 or `340282366920938463463374607431768211455` for IPv6.",
+			},
+			function.Int64Parameter{
+				Name:                "version",
+				MarkdownDescription: "The IP version of the address, either `4` or `6`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *IntToIPFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var number *big.Float
+	var version int64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &number, &version))
+	if resp.Error != nil {
+		return
+	}
+
+	if !number.IsInt() {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%s is not a whole number", number.Text('g', -1)))
+		return
+	}
+	i, _ := number.Int(nil)
+
+	if version != 4 && version != 6 {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("version must be 4 or 6, got %d", version))
+		return
+	}
+
+	ip, err := cidrutil.IntToIP(i, int(version))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, ip.String()))
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccIntToIPFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "ipv4" {
+  value = provider::utility::int_to_ip(167772161, 4)
+}
+
+output "ipv6" {
+  value = provider::utility::int_to_ip(provider::utility::ip_to_int("fd00::1") + 1, 6)
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("ipv4", "10.0.0.1"),
+					resource.TestCheckOutput("ipv6", "fd00::2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIntToIPFunctionOverflow(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::utility::int_to_ip(4294967296, 4)
+}
+`,
+				ExpectError: regexp.MustCompile(`outside\s+of\s+the\s+range\s+of\s+IPv4\s+addresses`),
+			},
+		},
+	})
+}
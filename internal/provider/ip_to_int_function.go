@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &IPToIntFunction{}
+
+func NewIPToIntFunction() function.Function {
+	return &IPToIntFunction{}
+}
+
+// IPToIntFunction defines the function implementation.
+type IPToIntFunction struct{}
+
+func (f *IPToIntFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "ip_to_int"
+}
+
+func (f *IPToIntFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Convert an IP address to an integer",
+		MarkdownDescription: "Returns the integer value of an IPv4 or IPv6 address (ex. `10.0.0.1` is `167772161`), which is useful for sorting and address arithmetic. " +
+			"Terraform numbers are arbitrary precision, so IPv6 addresses are represented exactly. Tools outside of Terraform that read the value as a 64-bit float (ex. some JSON parsers) " +
+			"will lose precision for IPv6 addresses, so wrap the result in `tostring()` before exporting it.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "ip",
+				MarkdownDescription: "The IP address to convert (ex. `10.0.0.1`).",
+			},
+		},
+		Return: function.NumberReturn{},
+	}
+}
+
+func (f *IPToIntFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ipString string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &ipString))
+	if resp.Error != nil {
+		return
+	}
+
+	ip := net.ParseIP(ipString)
+	if ip == nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%q is not a valid IP address", ipString))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, new(big.Float).SetInt(cidrutil.IPToInt(ip))))
+}
@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/intrange"
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/planmodifiers"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &MacAddressResource{}
+var _ resource.ResourceWithConfigure = &MacAddressResource{}
+var _ resource.ResourceWithImportState = &MacAddressResource{}
+var _ resource.ResourceWithValidateConfig = &MacAddressResource{}
+
+// The least significant bit of the first octet of a MAC address is set for multicast addresses, and the next bit is
+// set for locally administered ones, so the default prefix is the lowest locally administered unicast prefix. The
+// three octets after the prefix are the NIC specific part that is allocated.
+const (
+	macMulticastBit       = 0x01
+	defaultMacOuiPrefix   = "02:00:00"
+	maxMacNicSpecificPart = 0xffffff
+)
+
+// ouiPrefixRegex matches the first three octets of a MAC address separated by colons (ex. 02:00:5e).
+var ouiPrefixRegex = regexp.MustCompile(`^[0-9A-Fa-f]{2}(?::[0-9A-Fa-f]{2}){2}$`)
+
+// macAddressRegex matches a 48-bit MAC address with its octets separated by colons or hyphens.
+var macAddressRegex = regexp.MustCompile(`^[0-9A-Fa-f]{2}(?:[:-][0-9A-Fa-f]{2}){5}$`)
+
+func NewMacAddressResource() resource.Resource {
+	return &MacAddressResource{}
+}
+
+// MacAddressResource defines the resource implementation.
+type MacAddressResource struct{}
+
+// MacAddressResourceModel describes the resource data model.
+type MacAddressResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Keepers   types.Map    `tfsdk:"keepers"`
+	OuiPrefix types.String `tfsdk:"oui_prefix"`
+	UsedMacs  types.List   `tfsdk:"used_macs"`
+	Result    types.String `tfsdk:"result"`
+}
+
+func (r *MacAddressResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mac_address"
+}
+
+func (r *MacAddressResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Given an OUI prefix and a list of already used MAC addresses, find an unused unicast MAC address with that prefix.\n\n" +
+			"The `result` is kept stable once it is allocated, since it is typically used to configure a network interface, so " +
+			"changing the inputs used to find it has no effect. To conditionally allocate a new MAC address, change the " +
+			"`keepers`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "MAC address Identifier. The value will be identical to the `result` field.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"oui_prefix": schema.StringAttribute{
+				MarkdownDescription: "The first three octets of the MAC address, separated by colons (ex. `02:00:5e`). The prefix must be unicast, so the least significant bit of the first octet must be clear. Defaults to `02:00:00`, which is locally administered so it never collides with a vendor assigned address. A vendor OUI (ex. `00:50:56`) is kept as-is, so the addresses it produces are universally administered. Changing this value after creation **HAS NO EFFECT**.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultMacOuiPrefix),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(ouiPrefixRegex, "Must be three octets separated by colons (ex. 02:00:5e)"),
+				},
+			},
+			"used_macs": schema.ListAttribute{
+				MarkdownDescription: "A list containing the MAC addresses that are already used which should be avoided to prevent collisions. Octets may be separated by colons or hyphens in either case. Addresses with a different prefix than `oui_prefix` are ignored. Changing this value after creation **HAS NO EFFECT**.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(macAddressRegex, "Must be a MAC address (ex. 02:00:00:00:00:01)")),
+				},
+				Required: true,
+			},
+			"keepers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, will trigger re-creation of resource. This field works the same as the `keepers` field in the [`Random` provider](https://registry.terraform.io/providers/hashicorp/random/latest/docs#resource-keepers). Numbers and bools are converted to strings by Terraform (ex. a `version = 3` counter is stored as `\"3\"`), so they don't need `tostring()`. Adding, removing or changing any key, including setting its value to `null`, triggers re-creation.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					planmodifiers.RequiresReplaceIfMapChanged(),
+				},
+			},
+			"result": schema.StringAttribute{
+				MarkdownDescription: "The available MAC address that was found, in lower case with the octets separated by colons (ex. `02:00:00:00:00:01`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig catches a multicast oui_prefix during plan, since every address allocated from it would be a
+// group address that can't be assigned to an interface.
+func (r *MacAddressResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var ouiPrefix types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("oui_prefix"), &ouiPrefix)...)
+	if resp.Diagnostics.HasError() || ouiPrefix.IsNull() || ouiPrefix.IsUnknown() {
+		return
+	}
+
+	// Malformed prefixes are already reported by the attribute validator.
+	if !ouiPrefixRegex.MatchString(ouiPrefix.ValueString()) {
+		return
+	}
+
+	if _, err := parseOuiPrefix(ouiPrefix.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("oui_prefix"),
+			"Invalid oui_prefix",
+			err.Error(),
+		)
+	}
+}
+
+func (r *MacAddressResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+func (r *MacAddressResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data MacAddressResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prefix, err := parseOuiPrefix(data.OuiPrefix.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing oui_prefix",
+			err.Error(),
+		)
+		return
+	}
+
+	usedMacsStrings := make([]string, len(data.UsedMacs.Elements()))
+	resp.Diagnostics.Append(data.UsedMacs.ElementsAs(ctx, &usedMacsStrings, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the NIC specific part of the addresses that share the prefix can collide with the result.
+	used := make([]int64, 0, len(usedMacsStrings))
+	for _, usedMac := range usedMacsStrings {
+		mac, err := parseMacAddress(usedMac)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error parsing used_macs",
+				fmt.Sprintf("Unable to parse %q: %s", usedMac, err.Error()),
+			)
+			return
+		}
+		if mac[0] == prefix[0] && mac[1] == prefix[1] && mac[2] == prefix[2] {
+			used = append(used, int64(mac[3])<<16|int64(mac[4])<<8|int64(mac[5]))
+		}
+	}
+
+	nic, ok := intrange.FindFirst([]intrange.Range{{First: 0, Last: maxMacNicSpecificPart}}, used)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"No available MAC address found",
+			fmt.Sprintf("All %d MAC addresses with the prefix %s are used", maxMacNicSpecificPart+1, data.OuiPrefix.ValueString()),
+		)
+		return
+	}
+
+	result := net.HardwareAddr{prefix[0], prefix[1], prefix[2], byte(nic >> 16), byte(nic >> 8), byte(nic)}
+	data.Id = types.StringValue(result.String())
+	data.Result = types.StringValue(result.String())
+
+	tflog.Trace(ctx, "found available mac address: "+data.Id.ValueString())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MacAddressResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data MacAddressResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update ensures the plan value is copied to the state to complete the update.
+func (r *MacAddressResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data MacAddressResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete does not need to explicitly call resp.State.RemoveResource() as this is automatically handled by the
+// [framework](https://github.com/hashicorp/terraform-plugin-framework/pull/301).
+func (r *MacAddressResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// ImportState accepts any unicast MAC address. The oui_prefix is taken from its first three octets, since that is
+// the prefix it must have been allocated from.
+func (r *MacAddressResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	mac, err := parseMacAddress(req.ID)
+	if err == nil && mac[0]&macMulticastBit != 0 {
+		err = fmt.Errorf("%s is a multicast address", req.ID)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Malformed resource ID (MAC address)",
+			fmt.Sprintf("The ID that was given must be a unicast MAC address (ex. 02:00:00:00:00:01): %s", err.Error()),
+		)
+		return
+	}
+
+	state := MacAddressResourceModel{
+		OuiPrefix: types.StringValue(mac[:3].String()),
+		UsedMacs:  types.ListNull(types.StringType),
+		Keepers:   types.MapNull(types.StringType),
+		Id:        types.StringValue(mac.String()),
+		Result:    types.StringValue(mac.String()),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// parseOuiPrefix returns the three octets of an OUI prefix, which must be unicast.
+func parseOuiPrefix(s string) ([]byte, error) {
+	mac, err := net.ParseMAC(s + ":00:00:00")
+	if err != nil {
+		return nil, fmt.Errorf("%q must be three octets separated by colons", s)
+	}
+	if mac[0]&macMulticastBit != 0 {
+		return nil, fmt.Errorf("%s is a multicast prefix, the least significant bit of its first octet must be clear", s)
+	}
+	return mac[:3], nil
+}
+
+// parseMacAddress parses a 48-bit MAC address with its octets separated by colons or hyphens.
+func parseMacAddress(s string) (net.HardwareAddr, error) {
+	if !macAddressRegex.MatchString(s) {
+		return nil, fmt.Errorf("%q is not a 48-bit MAC address", s)
+	}
+	return net.ParseMAC(s)
+}
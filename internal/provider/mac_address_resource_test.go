@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestMacAddressResourceSchema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	NewMacAddressResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	if diagnostics := schemaResponse.Schema.ValidateImplementation(ctx); diagnostics.HasError() {
+		t.Fatalf("schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestAccMacAddressResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "utility_mac_address" "test" {
+  used_macs = ["02:00:00:00:00:00", "02-00-00-00-00-01", "02:00:00:00:00:03", "00:50:56:00:00:02"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_mac_address.test", "result", "02:00:00:00:00:02"),
+					resource.TestCheckResourceAttr("utility_mac_address.test", "id", "02:00:00:00:00:02"),
+					resource.TestCheckResourceAttr("utility_mac_address.test", "oui_prefix", "02:00:00"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "utility_mac_address.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"used_macs"},
+			},
+			// Changing the inputs keeps the result stable
+			{
+				Config: testAccMacAddressResourceConfig("02:00:5e", `[]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_mac_address.test", "result", "02:00:00:00:00:02"),
+				),
+			},
+			{
+				ResourceName:  "utility_mac_address.test",
+				ImportState:   true,
+				ImportStateId: "01:00:5e:00:00:01",
+				ExpectError:   regexp.MustCompile("Malformed resource ID"),
+			},
+		},
+	})
+}
+
+func TestAccMacAddressResourceOuiPrefix(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMacAddressResourceConfig("00:50:56", `["00:50:56:00:00:00", "00:50:56:00:00:01"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_mac_address.test", "result", "00:50:56:00:00:02"),
+				),
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMacAddressResourceConfig("01:00:5e", `[]`),
+				ExpectError: regexp.MustCompile("multicast prefix"),
+			},
+			{
+				Config:      testAccMacAddressResourceConfig("02:00", `[]`),
+				ExpectError: regexp.MustCompile("Must be three octets separated by colons"),
+			},
+			{
+				Config:      testAccMacAddressResourceConfig("02:00:00", `["02:00:00:00:00"]`),
+				ExpectError: regexp.MustCompile("Must be a MAC address"),
+			},
+		},
+	})
+}
+
+func testAccMacAddressResourceConfig(ouiPrefix string, usedMacs string) string {
+	return fmt.Sprintf(`
+resource "utility_mac_address" "test" {
+  oui_prefix = %q
+  used_macs  = %s
+}
+`, ouiPrefix, usedMacs)
+}
@@ -3,16 +3,20 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure UtilityProvider satisfies various provider interfaces.
 var _ provider.Provider = &UtilityProvider{}
-var _ provider.ProviderWithMetadata = &UtilityProvider{}
+var _ provider.ProviderWithFunctions = &UtilityProvider{}
 
 // UtilityProvider defines the provider implementation.
 type UtilityProvider struct {
@@ -23,31 +27,149 @@ type UtilityProvider struct {
 }
 
 // UtilityProviderModel describes the provider data model.
-type UtilityProviderModel struct{}
+type UtilityProviderModel struct {
+	DefaultAllocationStrategy types.String `tfsdk:"default_allocation_strategy"`
+	AddressFamily             types.String `tfsdk:"address_family"`
+	DeterministicSeed         types.Int64  `tfsdk:"deterministic_seed"`
+	IPv4MappedCidrs           types.String `tfsdk:"ipv4_mapped_cidrs"`
+}
+
+// UtilityProviderData is the provider configuration passed to resources and data sources through their Configure
+// methods.
+type UtilityProviderData struct {
+	// DefaultAllocationStrategy is the strategy used by utility_available_cidr when its strategy is unset. It is
+	// empty when the provider doesn't set one.
+	DefaultAllocationStrategy string
+	// AddressFamily is either addressFamilyIPv4 or addressFamilyIPv6 when utility_available_cidr is restricted to
+	// a single address family, and empty when both are allowed.
+	AddressFamily string
+	// DeterministicSeed seeds every random allocation in place of the resource's keepers when it is set, and is nil
+	// otherwise.
+	DeterministicSeed *int64
+	// IPv4MappedCidrs is ipv4MappedNormalize when utility_available_cidr converts IPv4-mapped IPv6 CIDRs to IPv4,
+	// and otherwise they are rejected.
+	IPv4MappedCidrs string
+}
+
+// Address families supported by the `address_family` attribute.
+const (
+	addressFamilyIPv4 = "ipv4"
+	addressFamilyIPv6 = "ipv6"
+)
+
+// Handling of IPv4-mapped IPv6 CIDRs supported by the `ipv4_mapped_cidrs` attribute.
+const (
+	ipv4MappedReject    = "reject"
+	ipv4MappedNormalize = "normalize"
+)
 
 func (p *UtilityProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "utility"
 	resp.Version = p.version
 }
 
-func (p *UtilityProvider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
-		Attributes:          map[string]tfsdk.Attribute{},
-		MarkdownDescription: "No configuration is needed for this provider.",
-	}, nil
+func (p *UtilityProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"address_family": schema.StringAttribute{
+				MarkdownDescription: "Restricts `utility_available_cidr` resources to a single address family, either `ipv4` or `ipv6`. When set, creating a resource with any `from_cidrs` of the other family fails. Defaults to allowing both.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(addressFamilyIPv4, addressFamilyIPv6),
+				},
+			},
+			"deterministic_seed": schema.Int64Attribute{
+				MarkdownDescription: "Seeds every random allocation (ex. the `random` strategy of `utility_available_cidr` and `utility_available_integer`) with this value instead of a hash of the resource's `keepers`, so that tests get the same results on every run. Resources with different `keepers` pick the same random values when this is set, so it **should not be used in production**.",
+				Optional:            true,
+			},
+			"ipv4_mapped_cidrs": schema.StringAttribute{
+				MarkdownDescription: "How `utility_available_cidr` resources handle IPv4-mapped IPv6 CIDRs (ex. `::ffff:10.0.0.0/120`) in `from_cidrs`, `used_cidrs`, `reserved_cidrs` and `allow_cidrs`. These parse as IPv6 ranges with 128 bit masks, so allocating from them with an IPv4 `mask` goes wrong in confusing ways. `reject` fails with an error naming the IPv4 range that was probably meant, and `normalize` converts them to that IPv4 range (ex. `10.0.0.0/24`). Defaults to `reject`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ipv4MappedReject, ipv4MappedNormalize),
+				},
+			},
+			"default_allocation_strategy": schema.StringAttribute{
+				MarkdownDescription: "Allocation strategy used by `utility_available_cidr` resources that don't set `strategy`. One of `first_fit`, `last_fit`, `best_fit`, `random` or `compact`. Defaults to `first_fit`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(strategyFirstFit, strategyLastFit, strategyBestFit, strategyRandom, strategyCompact),
+				},
+			},
+		},
+		MarkdownDescription: "No configuration is needed for this provider, but defaults for the resources may be set.",
+	}
 }
 
 func (p *UtilityProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var data UtilityProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values, ex. from a resource that hasn't been created yet, fall back to the resource defaults.
+	providerData := &UtilityProviderData{
+		DefaultAllocationStrategy: data.DefaultAllocationStrategy.ValueString(),
+		AddressFamily:             data.AddressFamily.ValueString(),
+		IPv4MappedCidrs:           data.IPv4MappedCidrs.ValueString(),
+	}
+	if !data.DeterministicSeed.IsNull() && !data.DeterministicSeed.IsUnknown() {
+		providerData.DeterministicSeed = data.DeterministicSeed.ValueInt64Pointer()
+	}
+
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
 }
 
 func (p *UtilityProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAvailableCidrResource,
+		NewAvailablePortResource,
+		NewAvailableVlanResource,
+		NewAvailableAsnResource,
+		NewAvailableIntegerResource,
+		NewCidrPoolResource,
+		NewSubnetPlanResource,
+		NewMacAddressResource,
 	}
 }
 
 func (p *UtilityProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewAvailableCidrDataSource,
+		NewCidrSubnetsDataSource,
+		NewCidrInfoDataSource,
+		NewCidrContainsDataSource,
+		NewCidrOverlapDataSource,
+		NewCidrAggregateDataSource,
+		NewCidrDiffDataSource,
+		NewCidrUtilizationDataSource,
+		NewCidrIsAvailableDataSource,
+	}
+}
+
+func (p *UtilityProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewCidrAvailableFunction,
+		NewCidrCommonSupernetFunction,
+		NewCidrContainsFunction,
+		NewCidrIsPrivateFunction,
+		NewCidrMergeFunction,
+		NewCidrNetmaskFunction,
+		NewCidrOverlapsFunction,
+		NewCidrRangeFunction,
+		NewCidrSizeFunction,
+		NewCidrSplitAtFunction,
+		NewCidrHostFunction,
+		NewCidrNthSubnetFunction,
+		NewCidrSubnetsFunction,
+		NewCidrWildcardFunction,
+		NewIPToIntFunction,
+		NewIntToIPFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
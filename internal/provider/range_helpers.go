@@ -0,0 +1,8 @@
+package provider
+
+import (
+	"regexp"
+)
+
+// rangeRegex matches an inclusive range of integers in the form <first>-<last>.
+var rangeRegex = regexp.MustCompile(`^[0-9]+-[0-9]+$`)
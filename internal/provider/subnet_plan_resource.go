@@ -0,0 +1,360 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/massdriver-cloud/terraform-provider-utility/internal/cidrutil"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &SubnetPlanResource{}
+var _ resource.ResourceWithConfigure = &SubnetPlanResource{}
+var _ resource.ResourceWithValidateConfig = &SubnetPlanResource{}
+
+func NewSubnetPlanResource() resource.Resource {
+	return &SubnetPlanResource{}
+}
+
+// SubnetPlanResource defines the resource implementation.
+//
+// Every subnet in the plan is allocated together when the resource is created, so a layout made up of many subnets
+// doesn't depend on the order that separate utility_available_cidr resources are created in, and either the whole
+// layout fits or nothing is allocated.
+type SubnetPlanResource struct{}
+
+// SubnetPlanResourceModel describes the resource data model.
+type SubnetPlanResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	FromCidrs types.List   `tfsdk:"from_cidrs"`
+	UsedCidrs types.List   `tfsdk:"used_cidrs"`
+	Requests  types.List   `tfsdk:"requests"`
+	Plan      types.Map    `tfsdk:"plan"`
+}
+
+// SubnetPlanRequestModel describes one of the named requests of a subnet plan.
+type SubnetPlanRequestModel struct {
+	Name  types.String `tfsdk:"name"`
+	Mask  types.Int64  `tfsdk:"mask"`
+	Count types.Int64  `tfsdk:"count"`
+}
+
+// subnetPlanRequest is a request with known values, ready to be allocated.
+type subnetPlanRequest struct {
+	name  string
+	mask  int
+	count int
+}
+
+func (r *SubnetPlanResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subnet_plan"
+}
+
+func (r *SubnetPlanResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Allocates a named set of subnets (ex. `public`, `private` and `db` tiers of a VPC) from CIDR range(s) in a single " +
+			"allocation, so no two subnets collide and there is no ordering between separate `utility_available_cidr` resources to manage.\n\n" +
+			"The largest subnets are allocated first, which keeps the smaller ones from fragmenting the range. If any request can't be " +
+			"satisfied nothing is allocated. The plan is stable: it only changes when `from_cidrs` or `requests` change, which replaces " +
+			"the resource and allocates the whole plan again.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Subnet Plan Identifier. The value will be the `from_cidrs`, separated by commas.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"from_cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing the CIDR range(s) (ex. the CIDR of a VPC) from which to allocate the subnets. Changing this value replaces the resource.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Required: true,
+			},
+			"used_cidrs": schema.ListAttribute{
+				MarkdownDescription: "A list containing the CIDR ranges within the `from_cidrs` that are used outside of the plan and should be avoided. Changing this value after creation **HAS NO EFFECT**, so the `plan` stays stable.",
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(cidrRegex, "Must be valid CIDR notation")),
+				},
+				Optional: true,
+			},
+			"requests": schema.ListNestedAttribute{
+				MarkdownDescription: "The named groups of subnets to allocate. Changing this value replaces the resource.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the group, which is its key in `plan`. Names must be unique.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"mask": schema.Int64Attribute{
+							MarkdownDescription: "The mask (network/subnet size) of each subnet in the group.",
+							Required:            true,
+							Validators: []validator.Int64{
+								int64validator.Between(0, 128),
+							},
+						},
+						"count": schema.Int64Attribute{
+							MarkdownDescription: "The number of subnets in the group. Defaults to `1`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             int64default.StaticInt64(1),
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Required: true,
+			},
+			"plan": schema.MapAttribute{
+				MarkdownDescription: "A map of the `requests` names to the subnets allocated to them, each list in ascending order.",
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Computed:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SubnetPlanResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+}
+
+// ValidateConfig ensures that the known request names are unique, since they are the keys of the plan.
+func (r *SubnetPlanResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var requests types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("requests"), &requests)...)
+	if resp.Diagnostics.HasError() || requests.IsNull() || requests.IsUnknown() {
+		return
+	}
+
+	seen := map[string]bool{}
+	for i, element := range requests.Elements() {
+		request, ok := element.(types.Object)
+		if !ok || request.IsNull() || request.IsUnknown() {
+			continue
+		}
+		name, ok := request.Attributes()["name"].(types.String)
+		if !ok || name.IsNull() || name.IsUnknown() {
+			continue
+		}
+
+		if seen[name.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("requests").AtListIndex(i).AtName("name"),
+				"Duplicate request name",
+				fmt.Sprintf("The request name %q is used more than once, but each name must be unique since it is the key of the request in plan.", name.ValueString()),
+			)
+		}
+		seen[name.ValueString()] = true
+	}
+}
+
+func (r *SubnetPlanResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SubnetPlanResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.allocatePlan(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SubnetPlanResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SubnetPlanResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only happens when used_cidrs changes, since every other input replaces the resource, so the plan is kept
+// as it was allocated.
+func (r *SubnetPlanResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SubnetPlanResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete does not need to explicitly call resp.State.RemoveResource() as this is automatically handled by the
+// [framework](https://github.com/hashicorp/terraform-plugin-framework/pull/301).
+func (r *SubnetPlanResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// allocatePlan allocates every request in data and sets the id and plan to match.
+func (r *SubnetPlanResource) allocatePlan(ctx context.Context, data *SubnetPlanResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	fromCidrs, parseDiags := parseCidrs(ctx, data.FromCidrs, "from_cidrs")
+	diags.Append(parseDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	if err := checkSameFamily(fromCidrs, "from_cidrs"); err != nil {
+		diags.AddAttributeError(
+			path.Root("from_cidrs"),
+			"Mixed address families in from_cidrs",
+			err.Error(),
+		)
+		return diags
+	}
+
+	usedCidrs := []*net.IPNet{}
+	if !data.UsedCidrs.IsNull() {
+		usedCidrs, parseDiags = parseCidrs(ctx, data.UsedCidrs, "used_cidrs")
+		diags.Append(parseDiags...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	var requestModels []SubnetPlanRequestModel
+	diags.Append(data.Requests.ElementsAs(ctx, &requestModels, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	requests := make([]subnetPlanRequest, len(requestModels))
+	for i, request := range requestModels {
+		requests[i] = subnetPlanRequest{
+			name:  request.Name.ValueString(),
+			mask:  int(request.Mask.ValueInt64()),
+			count: int(request.Count.ValueInt64()),
+		}
+	}
+
+	plan, err := allocateSubnetPlan(fromCidrs, usedCidrs, requests)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("requests"),
+			"Unable to allocate subnet plan",
+			err.Error(),
+		)
+		return diags
+	}
+
+	planMap, mapDiags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, plan)
+	diags.Append(mapDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	data.Id = types.StringValue(strings.Join(cidrutil.Strings(fromCidrs), ","))
+	data.Plan = planMap
+
+	tflog.Trace(ctx, "allocated subnet plan", map[string]interface{}{
+		"plan": plan,
+	})
+
+	return diags
+}
+
+// allocateSubnetPlan allocates count subnets of the given mask for each of the requests, taking the lowest available
+// CIDRs and avoiding the used CIDRs and each other. The requests with the largest subnets are allocated first, in
+// the order they are listed when the sizes are the same, so that smaller subnets don't break up the space a larger
+// one needs. An error is returned for the first request that can't be satisfied.
+func allocateSubnetPlan(fromCidrs []*net.IPNet, usedCidrs []*net.IPNet, requests []subnetPlanRequest) (map[string][]string, error) {
+	addrBits := cidrutil.AddressBits(fromCidrs[0])
+
+	// The names weren't necessarily known when the configuration was validated.
+	names := make(map[string]bool, len(requests))
+	for _, request := range requests {
+		if names[request.name] {
+			return nil, fmt.Errorf("the request name %q is used more than once", request.name)
+		}
+		names[request.name] = true
+	}
+
+	ordered := append([]subnetPlanRequest{}, requests...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].mask < ordered[j].mask
+	})
+
+	plan := make(map[string][]string, len(requests))
+	used := append([]*net.IPNet{}, usedCidrs...)
+	for _, request := range ordered {
+		if request.mask > addrBits {
+			return nil, fmt.Errorf("the mask /%d requested for %q is larger than the from_cidrs address family allows", request.mask, request.name)
+		}
+
+		mask := net.CIDRMask(request.mask, addrBits)
+		subnets := make([]*net.IPNet, 0, request.count)
+		for len(subnets) < request.count {
+			subnet, err := allocate(strategyFirstFit, nil, fromCidrs, &mask, &mask, cidrutil.Normalize(used))
+			if err != nil {
+				return nil, fmt.Errorf("unable to allocate subnet %d of %d for %q: %w", len(subnets)+1, request.count, request.name, err)
+			}
+			subnets = append(subnets, subnet)
+			used = append(used, subnet)
+		}
+
+		sort.Slice(subnets, func(i, j int) bool {
+			return cidrutil.IPToInt(subnets[i].IP).Cmp(cidrutil.IPToInt(subnets[j].IP)) < 0
+		})
+		plan[request.name] = cidrutil.Strings(subnets)
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"regexp"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestSubnetPlanResourceSchema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	NewSubnetPlanResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	if diagnostics := schemaResponse.Schema.ValidateImplementation(ctx); diagnostics.HasError() {
+		t.Fatalf("schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestAllocateSubnetPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     []string
+		used     []string
+		requests []subnetPlanRequest
+		want     map[string][]string
+		wantErr  string
+	}{
+		{
+			name: "largest subnets are allocated first",
+			from: []string{"10.0.0.0/16"},
+			requests: []subnetPlanRequest{
+				{name: "public", mask: 24, count: 3},
+				{name: "private", mask: 22, count: 3},
+				{name: "db", mask: 26, count: 3},
+			},
+			want: map[string][]string{
+				"private": {"10.0.0.0/22", "10.0.4.0/22", "10.0.8.0/22"},
+				"public":  {"10.0.12.0/24", "10.0.13.0/24", "10.0.14.0/24"},
+				"db":      {"10.0.15.0/26", "10.0.15.64/26", "10.0.15.128/26"},
+			},
+		},
+		{
+			name: "used cidrs are avoided",
+			from: []string{"10.0.0.0/16"},
+			used: []string{"10.0.0.0/24", "10.0.2.0/24"},
+			requests: []subnetPlanRequest{
+				{name: "a", mask: 24, count: 2},
+				{name: "b", mask: 24, count: 1},
+			},
+			want: map[string][]string{
+				"a": {"10.0.1.0/24", "10.0.3.0/24"},
+				"b": {"10.0.4.0/24"},
+			},
+		},
+		{
+			name: "any request that doesn't fit fails the plan",
+			from: []string{"10.0.0.0/24"},
+			requests: []subnetPlanRequest{
+				{name: "a", mask: 25, count: 1},
+				{name: "b", mask: 26, count: 3},
+			},
+			wantErr: `unable to allocate subnet 3 of 3 for "b"`,
+		},
+		{
+			name: "duplicate names",
+			from: []string{"10.0.0.0/16"},
+			requests: []subnetPlanRequest{
+				{name: "a", mask: 24, count: 1},
+				{name: "a", mask: 25, count: 1},
+			},
+			wantErr: `"a" is used more than once`,
+		},
+		{
+			name:     "mask too large for the address family",
+			from:     []string{"10.0.0.0/16"},
+			requests: []subnetPlanRequest{{name: "a", mask: 33, count: 1}},
+			wantErr:  "larger than the from_cidrs address family allows",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parse := func(cidrs []string) []*net.IPNet {
+				networks := make([]*net.IPNet, len(cidrs))
+				for i, c := range cidrs {
+					_, network, err := net.ParseCIDR(c)
+					if err != nil {
+						t.Fatalf("unable to parse %s: %s", c, err)
+					}
+					networks[i] = network
+				}
+				return networks
+			}
+
+			got, err := allocateSubnetPlan(parse(tc.from), parse(tc.used), tc.requests)
+			if tc.wantErr != "" {
+				if err == nil || !regexp.MustCompile(regexp.QuoteMeta(tc.wantErr)).MatchString(err.Error()) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAccSubnetPlanResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSubnetPlanResourceConfig(`["10.0.0.0/24"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_subnet_plan.test", "id", "10.0.0.0/16"),
+					resource.TestCheckResourceAttr("utility_subnet_plan.test", "plan.%", "3"),
+					resource.TestCheckResourceAttr("utility_subnet_plan.test", "plan.private.#", "3"),
+					resource.TestCheckResourceAttr("utility_subnet_plan.test", "plan.private.0", "10.0.4.0/22"),
+					resource.TestCheckResourceAttr("utility_subnet_plan.test", "plan.public.0", "10.0.1.0/24"),
+					resource.TestCheckResourceAttr("utility_subnet_plan.test", "plan.public.2", "10.0.3.0/24"),
+					resource.TestCheckResourceAttr("utility_subnet_plan.test", "plan.db.#", "1"),
+					resource.TestCheckResourceAttr("utility_subnet_plan.test", "plan.db.0", "10.0.16.0/26"),
+				),
+			},
+			// Changing used_cidrs doesn't move any subnet
+			{
+				Config: testAccSubnetPlanResourceConfig(`["10.0.0.0/20"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_subnet_plan.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_subnet_plan.test", "plan.private.0", "10.0.4.0/22"),
+					resource.TestCheckResourceAttr("utility_subnet_plan.test", "plan.db.0", "10.0.16.0/26"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSubnetPlanResourceExhausted(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "utility_subnet_plan" "test" {
+  from_cidrs = ["10.0.0.0/24"]
+  requests = [
+    { name = "a", mask = 25, count = 2 },
+    { name = "b", mask = 26 },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile("Unable to allocate subnet plan"),
+			},
+		},
+	})
+}
+
+func TestAccSubnetPlanResourceDuplicateNames(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "utility_subnet_plan" "test" {
+  from_cidrs = ["10.0.0.0/16"]
+  requests = [
+    { name = "a", mask = 24 },
+    { name = "a", mask = 25 },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile("Duplicate request name"),
+			},
+		},
+	})
+}
+
+func testAccSubnetPlanResourceConfig(used string) string {
+	return `
+resource "utility_subnet_plan" "test" {
+  from_cidrs = ["10.0.0.0/16"]
+  used_cidrs = ` + used + `
+  requests = [
+    { name = "public", mask = 24, count = 3 },
+    { name = "private", mask = 22, count = 3 },
+    { name = "db", mask = 26 },
+  ]
+}
+`
+}